@@ -8,6 +8,7 @@ import (
 	"libero-backend/internal/routes"
 	"libero-backend/internal/scheduler"
 	"libero-backend/internal/service"
+	"log"
 	"net/http"
 	"os"
 	"os/signal"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/redis/go-redis/v9"
 )
 
 // App encapsulates app-wide dependencies and configuration
@@ -34,13 +36,35 @@ func initApp() *App {
 
 	// Initialize configuration
 	app.Config = config.New()
+	if err := app.Config.ValidateBackends(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	// Background cache work stops on shutdown
+	app.cleanupCtx, app.cleanupCancel = context.WithCancel(context.Background())
 
-	// Initialize database and repository
+	// Initialize database, Redis if a backend uses it, and repository
 	db := config.InitDB(app.Config)
+	var redisClient *redis.Client
+	if app.Config.UsesRedis() {
+		redisClient = config.InitRedis(app.Config)
+	}
 	app.Repository = repository.New(db)
+	if app.Config.CacheBackend == config.BackendRedis {
+		app.Repository.Cache = repository.NewRedisCacheRepository(redisClient)
+	}
+	if app.Config.CacheMemoryEntries > 0 {
+		// Serve recently used cache items from memory instead of a round trip to the cache backend
+		ttl := time.Duration(app.Config.CacheMemoryTTL) * time.Second
+		tier := repository.NewMemoryCacheRepository(app.Repository.Cache, app.Config.CacheMemoryEntries, ttl)
+		if redisClient != nil {
+			repository.ShareCacheInvalidations(app.cleanupCtx, redisClient, tier)
+		}
+		app.Repository.Cache = tier
+	}
 
 	// Initialize services
-	app.Service = service.New(app.Repository)
+	app.Service = service.New(app.Repository, redisClient)
 
 	// Initialize router
 	app.Router = mux.NewRouter()
@@ -49,11 +73,10 @@ func initApp() *App {
 	routes.SetupRoutes(app.Router, app.Service, app.Config, app.Repository)
 
 	// Setup cache cleanup
-	app.cleanupCtx, app.cleanupCancel = context.WithCancel(context.Background())
 	go app.startCacheCleanup()
 
 	// Initialize and start scheduler
-	app.Scheduler = scheduler.New(app.Service.Fixtures)
+	app.Scheduler = scheduler.New(app.Service.Fixtures, app.Service.Cache, app.Service.Settlement, app.Service.Catalog, app.Service.Live, app.Service.StandingsHistory)
 	app.Scheduler.Start()
 
 	return app
@@ -68,6 +91,9 @@ func (a *App) Run() error {
 		Addr:    fmt.Sprintf("%s:%d", host, port),
 		Handler: a.Router,
 	}
+	// End live event streams and WebSockets on shutdown, otherwise they keep the server from draining
+	srv.RegisterOnShutdown(a.Service.Live.Close)
+	srv.RegisterOnShutdown(a.Service.Realtime.Close)
 
 	// Channel to listen for errors coming from the server.
 	serverErrors := make(chan error, 1)
@@ -0,0 +1,59 @@
+// Command fakeprovider serves recorded football-data.org responses for offline development.
+//
+// Replay the built-in fixtures with a live match, then point the backend at it:
+//
+//	go run ./cmd/fakeprovider -scenario in-play
+//	THIRD_PARTY_BASE_URL=http://localhost:9090 go run main.go
+//
+// Record real responses into a directory while using the backend, then replay them:
+//
+//	go run ./cmd/fakeprovider -record -fixtures ./fixtures -upstream https://api.football-data.org/v4
+//	go run ./cmd/fakeprovider -fixtures ./fixtures
+package main
+
+import (
+	"flag"
+	"libero-backend/internal/fakeprovider"
+	"log"
+	"net/http"
+	"os"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "address to listen on")
+	fixtures := flag.String("fixtures", "", "fixtures directory (defaults to the built-in fixtures)")
+	scenario := flag.String("scenario", "replay", "built-in scenario (replay, rate-limited, missing-competition, in-play) or scenario JSON file")
+	shiftDates := flag.Bool("shift-dates", true, "move recorded kickoffs so the recording day is today")
+	record := flag.Bool("record", false, "proxy to -upstream and record its responses into -fixtures")
+	upstream := flag.String("upstream", "https://api.football-data.org/v4", "provider to record from")
+	flag.Parse()
+
+	options := fakeprovider.Options{
+		FixturesDir: *fixtures,
+		ShiftDates:  *shiftDates,
+	}
+	if *record {
+		options.Upstream = *upstream
+		options.APIKey = os.Getenv("THIRD_PARTY_FOOTBALL_API_KEY")
+		if options.APIKey == "" {
+			log.Fatal("Record mode needs THIRD_PARTY_FOOTBALL_API_KEY")
+		}
+	} else {
+		loaded, err := fakeprovider.LoadScenario(*scenario)
+		if err != nil {
+			log.Fatal(err)
+		}
+		options.Scenario = loaded
+	}
+
+	server, err := fakeprovider.New(options)
+	if err != nil {
+		log.Fatalf("Failed to start fake provider: %v", err)
+	}
+	if *record {
+		log.Printf("Recording %s into %s on %s", *upstream, *fixtures, *addr)
+	} else {
+		log.Printf("Serving scenario %q on %s", options.Scenario.Name, *addr)
+	}
+	log.Fatal(http.ListenAndServe(*addr, server))
+}
@@ -18,8 +18,27 @@ type Config struct {
 	MLServiceURL string // Added ML Service URL
 	ThirdPartyAPIKey   string // API key for the football data provider
 	ThirdPartyBaseURL  string // Base URL for the football data provider
+	ThirdPartyRateLimit int   // Requests per minute allowed by the football data provider
+	APIFootballAPIKey    string // API key for API-Football, the fallback provider; it's only used when set
+	APIFootballBaseURL   string // Base URL for API-Football
+	APIFootballRateLimit int    // Requests per minute allowed by API-Football
+	ProviderRoutes       string // Provider order per capability, e.g. "standings=api-football|football-data"
+	FakeProvider         string // Scenario of an in-process fake provider to use instead of football-data.org, for offline development
+	CacheMemoryEntries   int    // Cache items kept in memory in front of the cache table; 0 turns the memory tier off
+	CacheMemoryTTL       int    // Seconds a cache item is served from memory before it is read from the table again
+	CacheBackend         string // Where cache items are kept: "postgres" or "redis"
+	RateLimitBackend     string // Where provider rate limits are counted: "memory" for each process on its own, or "redis" shared by all
+	RedisURL             string // Redis server used by the redis backends, e.g. redis://localhost:6379/0
+	DemoMode             bool   // Serve generated demo data for every supported competition instead of a real provider
 }
 
+// Backends for the cache and provider rate limits
+const (
+	BackendPostgres = "postgres"
+	BackendMemory   = "memory"
+	BackendRedis    = "redis"
+)
+
 // ServerConfig holds server-specific configuration
 type ServerConfig struct {
 	Port int
@@ -87,6 +106,18 @@ func New() *Config {
 		MLServiceURL: getEnv("ML_SERVICE_URL", "http://localhost:8001"), // Added ML Service URL loading
 		ThirdPartyAPIKey:  getEnv("THIRD_PARTY_FOOTBALL_API_KEY", ""),
 		ThirdPartyBaseURL: getEnv("THIRD_PARTY_BASE_URL", ""),
+		ThirdPartyRateLimit: getEnvAsInt("THIRD_PARTY_RATE_LIMIT", 10), // Free tier allows 10 requests per minute
+		APIFootballAPIKey:    getEnv("API_FOOTBALL_API_KEY", ""),
+		APIFootballBaseURL:   getEnv("API_FOOTBALL_BASE_URL", "https://v3.football.api-sports.io"),
+		APIFootballRateLimit: getEnvAsInt("API_FOOTBALL_RATE_LIMIT", 10), // Free plan allows 10 requests per minute
+		ProviderRoutes:       getEnv("PROVIDER_ROUTES", ""),
+		FakeProvider:         getEnv("FAKE_PROVIDER", ""),
+		CacheMemoryEntries:   getEnvAsInt("CACHE_MEMORY_ENTRIES", 1000),
+		CacheMemoryTTL:       getEnvAsInt("CACHE_MEMORY_TTL", 60),
+		CacheBackend:         getEnv("CACHE_BACKEND", BackendPostgres),
+		RateLimitBackend:     getEnv("RATE_LIMIT_BACKEND", BackendMemory),
+		RedisURL:             getEnv("REDIS_URL", "redis://localhost:6379/0"),
+		DemoMode:             getEnvAsBool("DEMO_MODE", false),
 	}
 }
 
@@ -122,4 +153,12 @@ func getEnvAsInt(key string, defaultValue int) int {
 		return value
 	}
 	return defaultValue
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
 }
\ No newline at end of file
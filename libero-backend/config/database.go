@@ -23,6 +23,8 @@ func InitDB(config *Config) *gorm.DB {
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
+		// Report constraint violations as gorm.ErrDuplicatedKey and the like
+		TranslateError: true,
 	})
 
 	if err != nil {
@@ -46,9 +48,13 @@ func migrateDB(db *gorm.DB) {
 		&models.Team{},
 		&models.Player{},
 		&models.Competition{},
-		&models.CachedFixtures{},
-		&models.CachedTodayFixtures{},
 		&models.PredictionHistory{},
+		&models.UserPick{},
+		&models.League{},
+		&models.LeagueMember{},
+		&models.LeagueRemoval{},
+		&models.LeagueSeasonStanding{},
+		&models.Match{},
 		// Add more models here as needed
 	)
 
@@ -56,5 +62,32 @@ func migrateDB(db *gorm.DB) {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
+	migrateCache(db)
+
 	log.Println("Database migration completed successfully")
 }
+
+// legacyCacheKeys match cache items stored before keys were namespaced, e.g. standings_PL
+var legacyCacheKeys = []string{
+	`standings\_%`,
+	`scorers\_%`,
+	`todays\_fixtures\_%`,
+	`fixtures\_summary\_%`,
+	`upcoming\_matches`,
+	`feed\_user\_%`,
+	`provider\_%`,
+}
+
+// migrateCache drops the per-type cache tables replaced by namespaced cache items, and the items
+// stored under the old keys, which would otherwise sit unused until they expire
+func migrateCache(db *gorm.DB) {
+	if err := db.Migrator().DropTable(`cached_fixtures`, "cached_today_fixtures"); err != nil {
+		log.Printf("[WARN] Failed to drop legacy cache tables: %v", err)
+	}
+	for _, pattern := range legacyCacheKeys {
+		if err := db.Where("key LIKE ?", pattern).Delete(&models.CacheItem{}).Error; err != nil {
+			log.Printf("[WARN] Failed to remove legacy cache items: %v", err)
+			return
+		}
+	}
+}
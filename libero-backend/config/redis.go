@@ -0,0 +1,45 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// InitRedis connects to the Redis server shared by the backend processes
+func InitRedis(config *Config) *redis.Client {
+	options, err := redis.ParseURL(config.RedisURL)
+	if err != nil {
+		log.Fatalf("Invalid REDIS_URL: %v", err)
+	}
+	client := redis.NewClient(options)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		log.Fatalf("Failed to connect to Redis: %v", err)
+	}
+
+	log.Println("Connected to Redis successfully")
+	return client
+}
+
+// UsesRedis reports whether the cache or the provider rate limits are kept in Redis
+func (c *Config) UsesRedis() bool {
+	return c.CacheBackend == BackendRedis || c.RateLimitBackend == BackendRedis
+}
+
+// ValidateBackends reports an error for a CACHE_BACKEND or RATE_LIMIT_BACKEND that isn't one of
+// the known backends, rather than falling back to the default one
+func (c *Config) ValidateBackends() error {
+	if c.CacheBackend != BackendPostgres && c.CacheBackend != BackendRedis {
+		return fmt.Errorf("invalid CACHE_BACKEND %q: use %q or %q", c.CacheBackend, BackendPostgres, BackendRedis)
+	}
+	if c.RateLimitBackend != BackendMemory && c.RateLimitBackend != BackendRedis {
+		return fmt.Errorf("invalid RATE_LIMIT_BACKEND %q: use %q or %q", c.RateLimitBackend, BackendMemory, BackendRedis)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package config
+
+import "testing"
+
+func TestValidateBackends(t *testing.T) {
+	tests := []struct {
+		name             string
+		cacheBackend     string
+		rateLimitBackend string
+		wantErr          bool
+	}{
+		{name: "defaults", cacheBackend: BackendPostgres, rateLimitBackend: BackendMemory},
+		{name: "redis for both", cacheBackend: BackendRedis, rateLimitBackend: BackendRedis},
+		{name: "capitalised cache backend", cacheBackend: "Redis", rateLimitBackend: BackendMemory, wantErr: true},
+		{name: "unknown rate limit backend", cacheBackend: BackendPostgres, rateLimitBackend: "postgres", wantErr: true},
+		{name: "misspelt cache backend", cacheBackend: "postgresql", rateLimitBackend: BackendMemory, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{CacheBackend: tt.cacheBackend, RateLimitBackend: tt.rateLimitBackend}
+			if err := c.ValidateBackends(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBackends() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
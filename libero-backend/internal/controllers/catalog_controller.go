@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"libero-backend/internal/middleware"
+	"libero-backend/internal/models"
+	"libero-backend/internal/service"
+	"libero-backend/internal/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// CatalogController handles HTTP requests for the competition, team and player catalogue
+type CatalogController struct {
+	catalogService service.CatalogService
+}
+
+// NewCatalogController creates a new catalog controller instance
+func NewCatalogController(catalogService service.CatalogService) *CatalogController {
+	return &CatalogController{
+		catalogService: catalogService,
+	}
+}
+
+// GetCompetitions handles GET /api/competitions?q=&country=&sport=
+func (c *CatalogController) GetCompetitions(w http.ResponseWriter, r *http.Request) {
+	page, limit := parsePagination(r)
+	filter := catalogFilterFromQuery(r)
+
+	competitions, total, err := c.catalogService.SearchCompetitions(filter, page, limit, optionalUserID(r))
+	if err != nil {
+		fmt.Printf("Error searching competitions: %v\n", err)
+		http.Error(w, "Failed to fetch competitions", http.StatusInternalServerError)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"competitions": competitions,
+		"pagination":   paginationMeta(page, limit, total),
+	})
+}
+
+// GetCompetition handles GET /api/competitions/{id}
+func (c *CatalogController) GetCompetition(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid competition ID", http.StatusBadRequest)
+		return
+	}
+
+	competition, err := c.catalogService.GetCompetition(uint(id), optionalUserID(r))
+	if err != nil {
+		if errors.Is(err, service.ErrCompetitionNotFound) {
+			http.Error(w, "Competition not found", http.StatusNotFound)
+		} else {
+			fmt.Printf("Error fetching competition %d: %v\n", id, err)
+			http.Error(w, "Failed to fetch competition", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, competition)
+}
+
+// GetTeams handles GET /api/teams?q=&country=&sport=&competition=
+func (c *CatalogController) GetTeams(w http.ResponseWriter, r *http.Request) {
+	page, limit := parsePagination(r)
+	filter := catalogFilterFromQuery(r)
+
+	teams, total, err := c.catalogService.SearchTeams(filter, page, limit, optionalUserID(r))
+	if err != nil {
+		fmt.Printf("Error searching teams: %v\n", err)
+		http.Error(w, "Failed to fetch teams", http.StatusInternalServerError)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"teams":      teams,
+		"pagination": paginationMeta(page, limit, total),
+	})
+}
+
+// GetTeam handles GET /api/teams/{id}
+func (c *CatalogController) GetTeam(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return
+	}
+
+	team, err := c.catalogService.GetTeam(uint(id), optionalUserID(r))
+	if err != nil {
+		if errors.Is(err, service.ErrTeamNotFound) {
+			http.Error(w, "Team not found", http.StatusNotFound)
+		} else {
+			fmt.Printf("Error fetching team %d: %v\n", id, err)
+			http.Error(w, "Failed to fetch team", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, team)
+}
+
+// GetPlayers handles GET /api/players?q=&country=&competition=&team=&position=
+func (c *CatalogController) GetPlayers(w http.ResponseWriter, r *http.Request) {
+	page, limit := parsePagination(r)
+	filter := catalogFilterFromQuery(r)
+
+	if teamStr := r.URL.Query().Get("team"); teamStr != "" {
+		teamID, err := strconv.ParseUint(teamStr, 10, 32)
+		if err != nil {
+			http.Error(w, "team must be a team ID", http.StatusBadRequest)
+			return
+		}
+		filter.TeamID = uint(teamID)
+	}
+
+	players, total, err := c.catalogService.SearchPlayers(filter, page, limit, optionalUserID(r))
+	if err != nil {
+		fmt.Printf("Error searching players: %v\n", err)
+		http.Error(w, "Failed to fetch players", http.StatusInternalServerError)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"players":    players,
+		"pagination": paginationMeta(page, limit, total),
+	})
+}
+
+// GetPlayer handles GET /api/players/{id}
+func (c *CatalogController) GetPlayer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid player ID", http.StatusBadRequest)
+		return
+	}
+
+	player, err := c.catalogService.GetPlayer(uint(id), optionalUserID(r))
+	if err != nil {
+		if errors.Is(err, service.ErrPlayerNotFound) {
+			http.Error(w, "Player not found", http.StatusNotFound)
+		} else {
+			fmt.Printf("Error fetching player %d: %v\n", id, err)
+			http.Error(w, "Failed to fetch player", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, player)
+}
+
+// catalogFilterFromQuery reads the shared catalogue search parameters
+func catalogFilterFromQuery(r *http.Request) models.CatalogFilter {
+	query := r.URL.Query()
+	return models.CatalogFilter{
+		Query:       strings.TrimSpace(query.Get("q")),
+		Country:     strings.TrimSpace(query.Get("country")),
+		Sport:       strings.TrimSpace(query.Get("sport")),
+		Competition: strings.ToUpper(strings.TrimSpace(query.Get("competition"))),
+		Position:    strings.TrimSpace(query.Get("position")),
+	}
+}
+
+// optionalUserID returns the authenticated user's ID, or 0 for anonymous requests
+func optionalUserID(r *http.Request) uint {
+	if claims, ok := middleware.GetUserFromContext(r.Context()); ok {
+		return claims.UserID
+	}
+	return 0
+}
+
+// parsePagination reads the page and limit query parameters, defaulting to page 1 of 50
+func parsePagination(r *http.Request) (int, int) {
+	page := 1
+	limit := 50
+
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	return page, limit
+}
+
+// paginationMeta builds the pagination block of a list response
+func paginationMeta(page, limit int, total int64) map[string]interface{} {
+	return map[string]interface{}{
+		"page":        page,
+		"limit":       limit,
+		"total":       total,
+		"total_pages": (total + int64(limit) - 1) / int64(limit),
+	}
+}
@@ -13,6 +13,13 @@ type Controller struct {
 	SportsData        *SportsDataController
 	Prediction        *PredictionController
 	PredictionHistory *PredictionHistoryController
+	Pick              *PickController
+	League            *LeagueController
+	Match             *MatchController
+	Catalog           *CatalogController
+	Feed              *FeedController
+	Live              *LiveController
+	Realtime          *RealtimeController
 }
 
 // New creates a new service instance with all services
@@ -20,8 +27,15 @@ func New(service *serv.Service, cfg *config.Config, repo *repository.Repository)
 	return &Controller{
 		User:              NewUserController(service.User, service.Auth),
 		Oauth:             NewOAuthController(service.OAuth, cfg),
-		SportsData:        NewSportsDataController(service.ML, service.Fixtures, service.Football, repo.Cache),
+		SportsData:        NewSportsDataController(service.ML, service.Fixtures, service.Football, service.Bracket, service.StandingsHistory, service.Cache),
 		Prediction:        NewPredictionController(cfg),
 		PredictionHistory: NewPredictionHistoryController(service.PredictionHistory),
+		Pick:              NewPickController(service.Pick),
+		League:            NewLeagueController(service.League),
+		Match:             NewMatchController(service.Match),
+		Catalog:           NewCatalogController(service.Catalog),
+		Feed:              NewFeedController(service.Feed),
+		Live:              NewLiveController(service.Live),
+		Realtime:          NewRealtimeController(service.Realtime, service.Auth),
 	}
 }
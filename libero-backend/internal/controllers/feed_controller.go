@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"fmt"
+	"libero-backend/internal/middleware"
+	"libero-backend/internal/service"
+	"libero-backend/internal/utils"
+	"net/http"
+)
+
+// FeedController handles HTTP requests for the personalized user feed
+type FeedController struct {
+	feedService service.FeedService
+}
+
+// NewFeedController creates a new feed controller instance
+func NewFeedController(feedService service.FeedService) *FeedController {
+	return &FeedController{
+		feedService: feedService,
+	}
+}
+
+// GetFeed handles GET /api/users/me/feed
+func (c *FeedController) GetFeed(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	feed, err := c.feedService.GetFeed(r.Context(), claims.UserID)
+	if err != nil {
+		fmt.Printf("Error building feed for user %d: %v\n", claims.UserID, err)
+		http.Error(w, "Failed to build feed", http.StatusInternalServerError)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, feed)
+}
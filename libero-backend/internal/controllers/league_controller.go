@@ -0,0 +1,236 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"libero-backend/internal/middleware"
+	"libero-backend/internal/models"
+	"libero-backend/internal/service"
+	"libero-backend/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// LeagueController handles HTTP requests for private prediction leagues
+type LeagueController struct {
+	leagueService service.LeagueService
+}
+
+// NewLeagueController creates a new league controller instance
+func NewLeagueController(leagueService service.LeagueService) *LeagueController {
+	return &LeagueController{
+		leagueService: leagueService,
+	}
+}
+
+// CreateLeague handles POST /api/leagues
+func (c *LeagueController) CreateLeague(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	var request models.CreateLeagueRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	league, err := c.leagueService.CreateLeague(claims.UserID, &request)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInput) {
+			http.Error(w, "Invalid input: name is required", http.StatusBadRequest)
+		} else {
+			fmt.Printf("Error creating league for user %d: %v\n", claims.UserID, err)
+			http.Error(w, "Failed to create league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, league.ToResponse())
+}
+
+// GetLeagues handles GET /api/leagues
+func (c *LeagueController) GetLeagues(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	leagues, err := c.leagueService.GetUserLeagues(claims.UserID)
+	if err != nil {
+		http.Error(w, "Failed to fetch leagues", http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]models.LeagueResponse, 0, len(leagues))
+	for _, league := range leagues {
+		responses = append(responses, league.ToResponse())
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"leagues": responses,
+	})
+}
+
+// JoinLeague handles POST /api/leagues/join
+func (c *LeagueController) JoinLeague(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	var request models.JoinLeagueRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	league, err := c.leagueService.JoinLeague(claims.UserID, request.InviteCode)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidInput):
+			http.Error(w, "Invalid input: invite_code is required", http.StatusBadRequest)
+		case errors.Is(err, service.ErrLeagueNotFound):
+			http.Error(w, "Invalid invite code", http.StatusNotFound)
+		case errors.Is(err, service.ErrAlreadyMember):
+			http.Error(w, "You are already a member of this league", http.StatusConflict)
+		case errors.Is(err, service.ErrRemovedFromLeague):
+			http.Error(w, "You were removed from this league", http.StatusForbidden)
+		default:
+			fmt.Printf("Error joining league for user %d: %v\n", claims.UserID, err)
+			http.Error(w, "Failed to join league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, league.ToResponse())
+}
+
+// GetLeague handles GET /api/leagues/{id}
+func (c *LeagueController) GetLeague(w http.ResponseWriter, r *http.Request) {
+	leagueID, ok := leagueIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	league, err := c.leagueService.GetLeague(leagueID)
+	if err != nil {
+		if errors.Is(err, service.ErrLeagueNotFound) {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, league.ToResponse())
+}
+
+// GetStandings handles GET /api/leagues/{id}/standings?season=
+func (c *LeagueController) GetStandings(w http.ResponseWriter, r *http.Request) {
+	leagueID, ok := leagueIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	// Optional season number; defaults to the current season
+	season := 0
+	if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
+		s, err := strconv.Atoi(seasonStr)
+		if err != nil || s <= 0 {
+			http.Error(w, "season must be a positive number", http.StatusBadRequest)
+			return
+		}
+		season = s
+	}
+
+	standings, err := c.leagueService.GetStandings(leagueID, season)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrLeagueNotFound):
+			http.Error(w, "League not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrSeasonNotFound):
+			http.Error(w, "Season not found", http.StatusNotFound)
+		default:
+			fmt.Printf("Error fetching standings for league %d: %v\n", leagueID, err)
+			http.Error(w, "Failed to fetch league standings", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, standings)
+}
+
+// RemoveMember handles DELETE /api/leagues/{id}/members/{userId}
+func (c *LeagueController) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	leagueID, ok := leagueIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseUint(mux.Vars(r)["userId"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.leagueService.RemoveMember(leagueID, uint(userID)); err != nil {
+		switch {
+		case errors.Is(err, service.ErrCannotRemoveOwner):
+			http.Error(w, "The league owner cannot be removed", http.StatusBadRequest)
+		case errors.Is(err, service.ErrLeagueNotFound):
+			http.Error(w, "League not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrNotLeagueMember):
+			http.Error(w, "User is not a member of this league", http.StatusNotFound)
+		default:
+			fmt.Printf("Error removing user %d from league %d: %v\n", userID, leagueID, err)
+			http.Error(w, "Failed to remove member", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Member removed successfully",
+	})
+}
+
+// ArchiveSeason handles POST /api/leagues/{id}/seasons/archive
+func (c *LeagueController) ArchiveSeason(w http.ResponseWriter, r *http.Request) {
+	leagueID, ok := leagueIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	standings, err := c.leagueService.ArchiveSeason(leagueID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrLeagueNotFound):
+			http.Error(w, "League not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrSeasonConflict):
+			http.Error(w, "The season was archived in the meantime", http.StatusConflict)
+		default:
+			fmt.Printf("Error archiving season for league %d: %v\n", leagueID, err)
+			http.Error(w, "Failed to archive season", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, standings)
+}
+
+// leagueIDFromRequest parses the {id} route variable, writing a 400 response if it is invalid
+func leagueIDFromRequest(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
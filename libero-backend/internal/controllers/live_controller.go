@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"libero-backend/internal/models"
+	"libero-backend/internal/service"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// liveHeartbeatInterval keeps idle event streams open through proxies
+const liveHeartbeatInterval = 15 * time.Second
+
+// LiveController handles HTTP requests for live match events
+type LiveController struct {
+	liveService service.LiveService
+}
+
+// NewLiveController creates a new live controller instance
+func NewLiveController(liveService service.LiveService) *LiveController {
+	return &LiveController{
+		liveService: liveService,
+	}
+}
+
+// Stream handles GET /api/live/stream?competition=&team= as a Server-Sent Events stream
+func (c *LiveController) Stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	filter := models.LiveEventFilter{
+		Competition: strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("competition"))),
+		Team:        strings.TrimSpace(r.URL.Query().Get("team")),
+	}
+	events, unsubscribe := c.liveService.Subscribe(filter)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+	w.WriteHeader(http.StatusOK)
+
+	// Tell the client how quickly to reconnect if the stream drops
+	fmt.Fprint(w, "retry: 5000\n\n")
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(liveHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// Subscription ended (slow client or shutdown); the client will reconnect
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
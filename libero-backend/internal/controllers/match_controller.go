@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"libero-backend/internal/models"
+	"libero-backend/internal/service"
+	"libero-backend/internal/utils"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MatchController handles HTTP requests for stored matches
+type MatchController struct {
+	matchService service.MatchService
+}
+
+// NewMatchController creates a new match controller instance
+func NewMatchController(matchService service.MatchService) *MatchController {
+	return &MatchController{
+		matchService: matchService,
+	}
+}
+
+// GetMatches handles GET /api/matches?competition=&from=&to=&team=&status=
+func (c *MatchController) GetMatches(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// Parse pagination parameters
+	page := 1
+	limit := 50
+
+	if pageStr := query.Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	filter := models.MatchFilter{
+		Competition: strings.ToUpper(strings.TrimSpace(query.Get("competition"))),
+		Team:        strings.TrimSpace(query.Get("team")),
+	}
+
+	// Status accepts a comma-separated list, e.g. status=SCHEDULED,TIMED
+	if statusStr := query.Get("status"); statusStr != "" {
+		for _, status := range strings.Split(statusStr, ",") {
+			if status = strings.ToUpper(strings.TrimSpace(status)); status != "" {
+				filter.Status = append(filter.Status, status)
+			}
+		}
+	}
+
+	// Parse optional date range (YYYY-MM-DD or RFC3339)
+	var err error
+	if filter.From, err = parseDateParam(query.Get("from")); err != nil {
+		http.Error(w, "Invalid 'from' date, expected YYYY-MM-DD or RFC3339", http.StatusBadRequest)
+		return
+	}
+	if filter.To, err = parseEndDateParam(query.Get("to")); err != nil {
+		http.Error(w, "Invalid 'to' date, expected YYYY-MM-DD or RFC3339", http.StatusBadRequest)
+		return
+	}
+
+	matches, total, err := c.matchService.GetMatches(filter, page, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInput) {
+			http.Error(w, "'from' must be before 'to'", http.StatusBadRequest)
+		} else {
+			fmt.Printf("Error fetching matches: %v\n", err)
+			http.Error(w, "Failed to fetch matches", http.StatusInternalServerError)
+		}
+		return
+	}
+	if matches == nil {
+		matches = []models.Match{}
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"matches": matches,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": (total + int64(limit) - 1) / int64(limit),
+		},
+	})
+}
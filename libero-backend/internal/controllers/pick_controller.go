@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"libero-backend/internal/middleware"
+	"libero-backend/internal/models"
+	"libero-backend/internal/service"
+	"libero-backend/internal/utils"
+	"net/http"
+	"strconv"
+)
+
+// PickController handles HTTP requests for user picks and leaderboards
+type PickController struct {
+	pickService service.PickService
+}
+
+// NewPickController creates a new pick controller instance
+func NewPickController(pickService service.PickService) *PickController {
+	return &PickController{
+		pickService: pickService,
+	}
+}
+
+// SubmitPick handles POST /api/picks
+func (c *PickController) SubmitPick(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	var request models.SubmitPickRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	pick, err := c.pickService.SubmitPick(r.Context(), claims.UserID, &request)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidInput):
+			http.Error(w, "Invalid input: matchId is required and scores must not be negative", http.StatusBadRequest)
+		case errors.Is(err, service.ErrMatchNotFound):
+			http.Error(w, "Match not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrPickLocked):
+			http.Error(w, "Picks for this match are locked", http.StatusConflict)
+		default:
+			fmt.Printf("Error submitting pick for user %d: %v\n", claims.UserID, err)
+			http.Error(w, "Failed to save pick", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, pick.ToResponse())
+}
+
+// GetPicks handles GET /api/picks
+func (c *PickController) GetPicks(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	// Parse pagination parameters
+	page := 1
+	limit := 50
+
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	// Optional status filter: "settled", "pending" or "void"
+	status := r.URL.Query().Get("status")
+	if status != "" && status != models.PredictionStatusSettled && status != models.PredictionStatusPending && status != models.PredictionStatusVoid {
+		http.Error(w, "status must be 'settled', 'pending' or 'void'", http.StatusBadRequest)
+		return
+	}
+
+	picks, total, err := c.pickService.GetUserPicks(claims.UserID, status, page, limit)
+	if err != nil {
+		http.Error(w, "Failed to fetch picks", http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]models.UserPickResponse, 0, len(picks))
+	for _, pick := range picks {
+		responses = append(responses, pick.ToResponse())
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"picks": responses,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": (total + int64(limit) - 1) / int64(limit),
+		},
+	})
+}
+
+// GetComparison handles GET /api/picks/comparison
+func (c *PickController) GetComparison(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	summary, err := c.pickService.CompareWithModel(claims.UserID)
+	if err != nil {
+		fmt.Printf("Error comparing picks for user %d: %v\n", claims.UserID, err)
+		http.Error(w, "Failed to compare picks", http.StatusInternalServerError)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, summary)
+}
+
+// GetLeaderboard handles GET /api/leaderboard?period=weekly|monthly|season
+func (c *PickController) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = models.LeaderboardWeekly
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	leaderboard, err := c.pickService.GetLeaderboard(period, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPeriod) {
+			http.Error(w, "period must be 'weekly', 'monthly' or 'season'", http.StatusBadRequest)
+		} else {
+			fmt.Printf("Error fetching %s leaderboard: %v\n", period, err)
+			http.Error(w, "Failed to fetch leaderboard", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, leaderboard)
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"libero-backend/internal/middleware"
 	"libero-backend/internal/models"
@@ -9,6 +10,7 @@ import (
 	"libero-backend/internal/utils"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 )
@@ -80,8 +82,21 @@ func (c *PredictionHistoryController) GetPredictions(w http.ResponseWriter, r *h
 		}
 	}
 
+	// Optional status filter: "settled", "pending" or "void"
+	status := r.URL.Query().Get("status")
+	if status != "" && status != models.PredictionStatusSettled && status != models.PredictionStatusPending && status != models.PredictionStatusVoid {
+		http.Error(w, "status must be 'settled', 'pending' or 'void'", http.StatusBadRequest)
+		return
+	}
+
 	// Get predictions from service
-	predictions, total, err := c.predictionService.GetUserPredictions(claims.UserID, page, limit)
+	predictions, total, err := c.predictionService.GetUserPredictions(claims.UserID, status, page, limit)
+	if err != nil {
+		http.Error(w, "Failed to fetch predictions", http.StatusInternalServerError)
+		return
+	}
+
+	settledCount, pendingCount, err := c.predictionService.CountUserPredictionsByStatus(claims.UserID)
 	if err != nil {
 		http.Error(w, "Failed to fetch predictions", http.StatusInternalServerError)
 		return
@@ -98,11 +113,20 @@ func (c *PredictionHistoryController) GetPredictions(w http.ResponseWriter, r *h
 	}
 
 	// Convert to response format (fixed to use ToResponse properly)
+	// and split the page into settled and pending predictions
 	var responses []models.PredictionHistoryResponse
+	settled := []models.PredictionHistoryResponse{}
+	pending := []models.PredictionHistoryResponse{}
 	for _, prediction := range predictions {
 		response := prediction.ToResponse()
 		fmt.Printf("Debug: Converting prediction %d to response: %+v\n", prediction.ID, response)
 		responses = append(responses, response)
+		switch response.Status {
+		case models.PredictionStatusSettled:
+			settled = append(settled, response)
+		case models.PredictionStatusPending:
+			pending = append(pending, response)
+		}
 	}
 
 	// Debug logging for responses
@@ -114,6 +138,12 @@ func (c *PredictionHistoryController) GetPredictions(w http.ResponseWriter, r *h
 	// Return converted responses, not raw predictions
 	finalResponse := map[string]interface{}{
 		"predictions": responses, // Use converted responses
+		"settled":     settled,
+		"pending":     pending,
+		"counts": map[string]int64{
+			"settled": settledCount,
+			"pending": pendingCount,
+		},
 		"pagination": map[string]interface{}{
 			"page":        page,
 			"limit":       limit,
@@ -189,3 +219,69 @@ func (c *PredictionHistoryController) GetPredictionStatistics(w http.ResponseWri
 
 	utils.RespondWithJSON(w, http.StatusOK, stats)
 }
+
+// GetPredictionAccuracy handles GET /api/predictions/statistics/accuracy
+func (c *PredictionHistoryController) GetPredictionAccuracy(w http.ResponseWriter, r *http.Request) {
+	// Get user claims from context
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := models.PredictionAccuracyFilter{
+		League: query.Get("league"),
+		Team:   query.Get("team"),
+	}
+
+	// Parse optional date range (YYYY-MM-DD or RFC3339)
+	var err error
+	if filter.From, err = parseDateParam(query.Get("from")); err != nil {
+		http.Error(w, "Invalid 'from' date, expected YYYY-MM-DD or RFC3339", http.StatusBadRequest)
+		return
+	}
+	if filter.To, err = parseEndDateParam(query.Get("to")); err != nil {
+		http.Error(w, "Invalid 'to' date, expected YYYY-MM-DD or RFC3339", http.StatusBadRequest)
+		return
+	}
+
+	stats, err := c.predictionService.GetUserAccuracy(claims.UserID, filter)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInput) {
+			http.Error(w, "'from' must be before 'to'", http.StatusBadRequest)
+		} else {
+			http.Error(w, "Failed to fetch accuracy statistics", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, stats)
+}
+
+// parseDateParam parses an optional query parameter given either as a date or an RFC3339 timestamp.
+// An empty value yields nil.
+func parseDateParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// parseEndDateParam parses the optional end of a range like parseDateParam. A plain date includes
+// the whole day, so the end returned is the start of the next one.
+func parseEndDateParam(value string) (*time.Time, error) {
+	end, err := parseDateParam(value)
+	if err != nil || end == nil || len(value) != len("2006-01-02") {
+		return end, err
+	}
+	next := end.AddDate(0, 0, 1)
+	return &next, nil
+}
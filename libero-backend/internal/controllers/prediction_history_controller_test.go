@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEndDateParam(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{value: "2025-03-01", want: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC)},
+		{value: "2025-03-01T18:30:00Z", want: time.Date(2025, time.March, 1, 18, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		end, err := parseEndDateParam(tt.value)
+		if err != nil || end == nil || !end.Equal(tt.want) {
+			t.Errorf("parseEndDateParam(%q) = %v, %v; want %v", tt.value, end, err, tt.want)
+		}
+	}
+
+	if end, err := parseEndDateParam(""); end != nil || err != nil {
+		t.Errorf("parseEndDateParam(\"\") = %v, %v; want nil", end, err)
+	}
+	if _, err := parseEndDateParam("01/03/2025"); err == nil {
+		t.Error("parseEndDateParam(\"01/03/2025\") error = nil, want an error")
+	}
+}
@@ -0,0 +1,246 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"libero-backend/internal/middleware"
+	"libero-backend/internal/models"
+	"libero-backend/internal/service"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// wsWriteWait is how long a single write to the client may take
+	wsWriteWait = 10 * time.Second
+	// wsPongWait is how long the client may stay silent before the connection is considered dead
+	wsPongWait = 60 * time.Second
+	// wsPingInterval must be shorter than wsPongWait so pongs arrive in time
+	wsPingInterval = 50 * time.Second
+	// wsMaxMessageSize caps the size of client messages
+	wsMaxMessageSize = 4096
+	// wsReplyBuffer is how many replies may queue up before reading from the client pauses
+	wsReplyBuffer = 8
+)
+
+// wsUpgrader accepts connections from the allowed frontend origins and from non-browser clients
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		return origin == "" || middleware.IsOriginAllowed(origin)
+	},
+}
+
+// RealtimeController handles the WebSocket channel for topic subscriptions
+type RealtimeController struct {
+	realtimeService service.RealtimeService
+	authService     service.AuthService
+}
+
+// NewRealtimeController creates a new realtime controller instance
+func NewRealtimeController(realtimeService service.RealtimeService, authService service.AuthService) *RealtimeController {
+	return &RealtimeController{
+		realtimeService: realtimeService,
+		authService:     authService,
+	}
+}
+
+// realtimeSession is a single WebSocket connection. Only writePump writes to the connection.
+type realtimeSession struct {
+	conn       *websocket.Conn
+	client     *service.RealtimeClient
+	controller *RealtimeController
+	userID     uint // 0 until authenticated
+	replies    chan models.RealtimeMessage
+	writerDone chan struct{}
+	readerDone chan struct{}
+}
+
+// Connect handles GET /api/ws. Clients may authenticate with a Bearer token, a token query
+// parameter (browsers can't set headers on WebSocket requests) or an auth message.
+func (c *RealtimeController) Connect(w http.ResponseWriter, r *http.Request) {
+	var userID uint
+	token := r.URL.Query().Get("token")
+	if header := r.Header.Get("Authorization"); header != "" {
+		bearer, ok := middleware.BearerToken(header)
+		if !ok {
+			http.Error(w, "Authorization header format must be Bearer {token}", http.StatusUnauthorized)
+			return
+		}
+		token = bearer
+	}
+	if token != "" {
+		claims, err := c.authService.ValidateJWTToken(token)
+		if err != nil {
+			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+			return
+		}
+		userID = claims.UserID
+	}
+
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already replied with an error
+		return
+	}
+
+	session := &realtimeSession{
+		conn:       conn,
+		client:     c.realtimeService.Connect(),
+		controller: c,
+		userID:     userID,
+		replies:    make(chan models.RealtimeMessage, wsReplyBuffer),
+		writerDone: make(chan struct{}),
+		readerDone: make(chan struct{}),
+	}
+	defer session.client.Close()
+
+	go session.writePump()
+	session.readPump()
+	<-session.writerDone
+}
+
+// readPump handles client requests until the connection fails or the client goes silent
+func (s *realtimeSession) readPump() {
+	defer close(s.readerDone)
+
+	s.conn.SetReadLimit(wsMaxMessageSize)
+	_ = s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				fmt.Printf("[WARN] WebSocket read failed: %v\n", err)
+			}
+			return
+		}
+
+		var request models.RealtimeRequest
+		if err := json.Unmarshal(data, &request); err != nil {
+			s.reply(errorMessage("", "Invalid message format"))
+			continue
+		}
+		s.reply(s.handle(request))
+	}
+}
+
+// handle processes a single client request and returns the reply
+func (s *realtimeSession) handle(request models.RealtimeRequest) models.RealtimeMessage {
+	switch request.Action {
+	case models.RealtimeActionSubscribe:
+		topic, err := s.controller.realtimeService.ResolveTopic(request.Topic, s.userID)
+		if err != nil {
+			return topicErrorMessage(request.Topic, err)
+		}
+		if err := s.client.Subscribe(topic); err != nil {
+			return topicErrorMessage(request.Topic, err)
+		}
+		return models.RealtimeMessage{Type: models.RealtimeMessageSubscribed, Topic: topic, SentAt: time.Now().UTC()}
+
+	case models.RealtimeActionUnsubscribe:
+		topic, err := s.controller.realtimeService.ResolveTopic(request.Topic, s.userID)
+		if err != nil {
+			return topicErrorMessage(request.Topic, err)
+		}
+		s.client.Unsubscribe(topic)
+		return models.RealtimeMessage{Type: models.RealtimeMessageUnsubscribed, Topic: topic, SentAt: time.Now().UTC()}
+
+	case models.RealtimeActionAuth:
+		claims, err := s.controller.authService.ValidateJWTToken(request.Token)
+		if err != nil {
+			return errorMessage("", "Invalid or expired token")
+		}
+		if s.userID != 0 && s.userID != claims.UserID {
+			// Subscriptions made as the previous user would otherwise stay active
+			return errorMessage("", "Connection is already authenticated as another user")
+		}
+		s.userID = claims.UserID
+		return models.RealtimeMessage{Type: models.RealtimeMessageAuthenticated, SentAt: time.Now().UTC()}
+
+	case models.RealtimeActionPing:
+		return models.RealtimeMessage{Type: models.RealtimeMessagePong, SentAt: time.Now().UTC()}
+	}
+
+	return errorMessage(request.Topic, "Unknown action")
+}
+
+// reply queues a message for the writer, waiting while the client is slow to read
+func (s *realtimeSession) reply(message models.RealtimeMessage) {
+	select {
+	case s.replies <- message:
+	case <-s.writerDone:
+	}
+}
+
+// writePump sends replies, topic updates and heartbeats to the client
+func (s *realtimeSession) writePump() {
+	ticker := time.NewTicker(wsPingInterval)
+	defer func() {
+		ticker.Stop()
+		close(s.writerDone)
+		// Closing the connection also ends readPump
+		s.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-s.client.Messages():
+			if !ok {
+				// Dropped for falling behind or the server is shutting down; the client should reconnect
+				s.writeClose(websocket.CloseTryAgainLater, "reconnect")
+				return
+			}
+			if err := s.writeJSON(message); err != nil {
+				return
+			}
+		case message := <-s.replies:
+			if err := s.writeJSON(message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		case <-s.readerDone:
+			s.writeClose(websocket.CloseNormalClosure, "")
+			return
+		}
+	}
+}
+
+// writeJSON writes a single message within the write deadline
+func (s *realtimeSession) writeJSON(message models.RealtimeMessage) error {
+	_ = s.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return s.conn.WriteJSON(message)
+}
+
+// writeClose sends a close frame, ignoring failures since the connection is going away anyway
+func (s *realtimeSession) writeClose(code int, reason string) {
+	_ = s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(wsWriteWait))
+}
+
+// topicErrorMessage maps a topic error to an error reply
+func topicErrorMessage(topic string, err error) models.RealtimeMessage {
+	switch {
+	case errors.Is(err, service.ErrTopicForbidden):
+		return errorMessage(topic, "Not allowed to subscribe to this topic")
+	case errors.Is(err, service.ErrTooManyTopics):
+		return errorMessage(topic, "Too many subscriptions")
+	default:
+		return errorMessage(topic, "Invalid topic")
+	}
+}
+
+// errorMessage builds an error reply
+func errorMessage(topic, message string) models.RealtimeMessage {
+	return models.RealtimeMessage{Type: models.RealtimeMessageError, Topic: topic, Error: message, SentAt: time.Now().UTC()}
+}
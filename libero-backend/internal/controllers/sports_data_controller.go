@@ -1,13 +1,15 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"libero-backend/internal/models"
-	"libero-backend/internal/repository"
 	"libero-backend/internal/service"
 	"libero-backend/internal/utils"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,7 +21,9 @@ type SportsDataController struct {
 	mlService       service.MLService
 	fixturesService service.FixturesService
 	footballService *service.FootballService
-	cacheRepo       repository.CacheRepository
+	bracketService  service.BracketService
+	historyService  service.StandingsHistoryService
+	cache           service.CacheService
 }
 
 // NewSportsDataController creates a new sports data controller instance.
@@ -27,51 +31,41 @@ func NewSportsDataController(
 	mlService service.MLService,
 	fixturesService service.FixturesService,
 	footballService *service.FootballService,
-	cacheRepo repository.CacheRepository,
+	bracketService service.BracketService,
+	historyService service.StandingsHistoryService,
+	cache service.CacheService,
 ) *SportsDataController {
 	return &SportsDataController{
 		mlService:       mlService,
 		fixturesService: fixturesService,
 		footballService: footballService,
-		cacheRepo:       cacheRepo,
+		bracketService:  bracketService,
+		historyService:  historyService,
+		cache:           cache,
 	}
 }
 
 // HandleGetUpcomingMatches handles requests for upcoming matches.
 func (c *SportsDataController) HandleGetUpcomingMatches(w http.ResponseWriter, r *http.Request) {
-	matches, err := c.mlService.GetUpcomingMatches()
+	err := c.serveCached(w, r, service.CacheUpcomingMatches, "all", func(ctx context.Context) (interface{}, bool, error) {
+		matches, err := c.mlService.GetUpcomingMatches()
+		if err != nil {
+			return nil, false, err
+		}
+		// Only cache if matches is a non-empty slice
+		shouldCache := false
+		switch v := any(matches).(type) {
+		case []interface{}:
+			shouldCache = len(v) > 0
+		case []models.MatchDTO:
+			shouldCache = len(v) > 0
+		}
+		return matches, shouldCache, nil
+	})
 	if err != nil {
 		fmt.Printf("Error fetching upcoming matches from ML service: %v\n", err)
 		http.Error(w, "Failed to retrieve upcoming matches", http.StatusInternalServerError)
-		return
-	}
-	// Only cache if matches is a non-empty slice
-	shouldCache := false
-	switch v := any(matches).(type) {
-	case []interface{}:
-		shouldCache = len(v) > 0
-	case []models.MatchDTO:
-		shouldCache = len(v) > 0
-	}
-	if shouldCache {
-		cacheKey := "upcoming_matches"
-		newETag := fmt.Sprintf("%d", time.Now().UnixNano())
-		if matchesJSON, err := json.Marshal(matches); err == nil {
-			cacheItem := models.CacheItem{
-				Key:          cacheKey,
-				Value:        matchesJSON,
-				ETag:         newETag,
-				LastModified: time.Now(),
-				ExpiresAt:    time.Now().Add(24 * time.Hour),
-			}
-			_ = c.cacheRepo.SetWithMetadata(cacheKey, cacheItem)
-		}
-		w.Header().Set("ETag", newETag)
-		w.Header().Set("Last-Modified", time.Now().Format(http.TimeFormat))
-	} else {
-		fmt.Printf("[WARN] Not caching empty upcoming matches\n")
 	}
-	utils.RespondWithJSON(w, http.StatusOK, matches)
 }
 
 // HandleGetResults handles requests for match results.
@@ -122,43 +116,79 @@ func (c *SportsDataController) HandleGetStandings(w http.ResponseWriter, r *http
 		return
 	}
 
-	cacheKey := fmt.Sprintf("standings_%s", competition)
-	if cachedData, err := c.cacheRepo.Get(cacheKey); err == nil && cachedData != nil {
-		var standings interface{}
-		if err := json.Unmarshal(cachedData.Value, &standings); err == nil {
-			w.Header().Set("ETag", cachedData.ETag)
-			w.Header().Set("Last-Modified", cachedData.LastModified.Format(http.TimeFormat))
-			utils.RespondWithJSON(w, http.StatusOK, standings)
-			return
-		}
+	standingsType := strings.ToUpper(r.URL.Query().Get("type"))
+	switch standingsType {
+	case "":
+		standingsType = models.StandingsTypeTotal
+	case models.StandingsTypeTotal, models.StandingsTypeHome, models.StandingsTypeAway:
+	default:
+		http.Error(w, "type must be TOTAL, HOME or AWAY", http.StatusBadRequest)
+		return
 	}
 
-	standings, err := c.footballService.GetStandings(competition)
+	season, ok := seasonParam(w, r)
+	if !ok {
+		return
+	}
+
+	ns := service.StandingsCacheNamespace(c.seasonCompleted(r.Context(), competition, season))
+	err := c.serveCached(w, r, ns, service.StandingsCacheKey(competition, standingsType, season), func(ctx context.Context) (interface{}, bool, error) {
+		standings, err := c.footballService.GetStandings(ctx, competition, standingsType, season)
+		if err != nil {
+			return nil, false, err
+		}
+		return standings, len(standings.Standings) > 0, nil
+	})
 	if err != nil {
 		fmt.Printf("Error fetching standings for %s: %v\n", competition, err)
 		http.Error(w, "Failed to retrieve standings data", http.StatusInternalServerError)
+	}
+}
+
+// HandleGetStandingsHistory handles GET /api/standings/history?competition=&asOf=&season=, the
+// league table computed from stored results as of a date (YYYY-MM-DD) or a matchday
+func (c *SportsDataController) HandleGetStandingsHistory(w http.ResponseWriter, r *http.Request) {
+	query := models.StandingsHistoryQuery{Competition: strings.ToUpper(r.URL.Query().Get("competition"))}
+	if query.Competition == "" {
+		http.Error(w, "competition code is required", http.StatusBadRequest)
 		return
 	}
 
-	// Only cache if standings is non-empty
-	if len(standings.Standings) > 0 {
-		newETag := fmt.Sprintf("%d", time.Now().UnixNano())
-		if standingsJSON, err := json.Marshal(standings); err == nil {
-			cacheItem := models.CacheItem{
-				Key:          cacheKey,
-				Value:        standingsJSON,
-				ETag:         newETag,
-				LastModified: time.Now(),
-				ExpiresAt:    time.Now().Add(24 * time.Hour),
+	// A plain number is a matchday, anything else a date
+	if asOf := r.URL.Query().Get("asOf"); asOf != "" {
+		if matchday, err := strconv.Atoi(asOf); err == nil {
+			if matchday < 1 {
+				http.Error(w, "asOf matchday must be positive", http.StatusBadRequest)
+				return
 			}
-			_ = c.cacheRepo.SetWithMetadata(cacheKey, cacheItem)
+			query.Matchday = matchday
+		} else {
+			date, err := time.Parse("2006-01-02", asOf)
+			if err != nil {
+				http.Error(w, "asOf must be a YYYY-MM-DD date or a matchday", http.StatusBadRequest)
+				return
+			}
+			query.Date = &date
+		}
+	}
+
+	season, ok := seasonParam(w, r)
+	if !ok {
+		return
+	}
+	query.Season = season
+
+	history, err := c.historyService.GetStandingsHistory(query)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInput) {
+			http.Error(w, "asOf date must fall within the season", http.StatusBadRequest)
+			return
 		}
-		w.Header().Set("ETag", newETag)
-		w.Header().Set("Last-Modified", time.Now().Format(http.TimeFormat))
-	} else {
-		fmt.Printf("[WARN] Not caching empty standings for %s\n", competition)
+		fmt.Printf("Error computing standings history for %s: %v\n", query.Competition, err)
+		http.Error(w, "Failed to compute standings history", http.StatusInternalServerError)
+		return
 	}
-	utils.RespondWithJSON(w, http.StatusOK, standings)
+	utils.RespondWithJSON(w, http.StatusOK, history)
 }
 
 // HandleGetTopScorers handles requests for top scorers
@@ -168,81 +198,106 @@ func (c *SportsDataController) HandleGetTopScorers(w http.ResponseWriter, r *htt
 		http.Error(w, "competition code is required", http.StatusBadRequest)
 		return
 	}
-	cacheKey := fmt.Sprintf("scorers_%s", competition)
-	if cachedItem, err := c.cacheRepo.Get(cacheKey); err == nil && cachedItem != nil {
-		var scorers interface{}
-		if err := json.Unmarshal(cachedItem.Value, &scorers); err == nil {
-			w.Header().Set("ETag", cachedItem.ETag)
-			w.Header().Set("Last-Modified", cachedItem.LastModified.Format(http.TimeFormat))
-			utils.RespondWithJSON(w, http.StatusOK, scorers)
-			return
-		}
+
+	season, ok := seasonParam(w, r)
+	if !ok {
+		return
 	}
-	scorers, err := c.footballService.GetTopScorers(competition)
+
+	ns := service.ScorersCacheNamespace(c.seasonCompleted(r.Context(), competition, season))
+	err := c.serveCached(w, r, ns, service.ScorersCacheKey(competition, season), func(ctx context.Context) (interface{}, bool, error) {
+		scorers, err := c.footballService.GetTopScorers(ctx, competition, season)
+		if err != nil {
+			return nil, false, err
+		}
+		return scorers, len(scorers.Scorers) > 0, nil
+	})
 	if err != nil {
 		fmt.Printf("Error fetching top scorers for %s: %v\n", competition, err)
 		http.Error(w, "Failed to retrieve top scorers data", http.StatusInternalServerError)
-		return
 	}
-	// Only cache if scorers is non-empty
-	if len(scorers.Scorers) > 0 {
-		newETag := fmt.Sprintf("%d", time.Now().UnixNano())
-		if scorersJSON, err := json.Marshal(scorers); err == nil {
-			cacheItem := models.CacheItem{
-				Key:          cacheKey,
-				Value:        scorersJSON,
-				ETag:         newETag,
-				LastModified: time.Now(),
-				ExpiresAt:    time.Now().Add(24 * time.Hour),
-			}
-			_ = c.cacheRepo.SetWithMetadata(cacheKey, cacheItem)
-		}
-		w.Header().Set("ETag", newETag)
-		w.Header().Set("Last-Modified", time.Now().Format(http.TimeFormat))
-	} else {
-		fmt.Printf("[WARN] Not caching empty top scorers for %s\n", competition)
+}
+
+// seasonParam parses the optional season query parameter, the year a season starts in, with 0 for
+// the current season. It answers 400 and returns false when the parameter is invalid.
+func seasonParam(w http.ResponseWriter, r *http.Request) (int, bool) {
+	raw := r.URL.Query().Get("season")
+	if raw == "" {
+		return 0, true
+	}
+	season, err := strconv.Atoi(raw)
+	if err != nil || season < 1800 || season > time.Now().UTC().Year() {
+		http.Error(w, "season must be the year a season starts in", http.StatusBadRequest)
+		return 0, false
 	}
-	utils.RespondWithJSON(w, http.StatusOK, scorers)
+	return season, true
 }
 
-// HandleGetTodaysFixtures handles requests for today's fixtures.
-func (c *SportsDataController) HandleGetTodaysFixtures(w http.ResponseWriter, r *http.Request) {
-	cacheKey := fmt.Sprintf("todays_fixtures_%s", time.Now().Format("2006-01-02"))
-	if cachedItem, err := c.cacheRepo.Get(cacheKey); err == nil && cachedItem != nil {
-		var fixtures interface{}
-		if err := json.Unmarshal(cachedItem.Value, &fixtures); err == nil {
-			w.Header().Set("ETag", cachedItem.ETag)
-			w.Header().Set("Last-Modified", cachedItem.LastModified.Format(http.TimeFormat))
-			utils.RespondWithJSON(w, http.StatusOK, fixtures)
-			return
+// seasonCompleted reports whether a competition's season is over, going by its cached seasons
+// listing. Without a listing the season is taken to be still on, so its data isn't kept for good.
+func (c *SportsDataController) seasonCompleted(ctx context.Context, competition string, season int) bool {
+	if season == 0 {
+		return false
+	}
+	entry, err := c.cache.Fetch(ctx, service.CacheSeasons, competition, c.fetchSeasons(competition))
+	if err != nil {
+		fmt.Printf("[WARN] No seasons listing for %s to archive season %d by: %v\n", competition, season, err)
+		return false
+	}
+	var seasons models.CompetitionSeasonsDTO
+	if err := json.Unmarshal(entry.Value, &seasons); err != nil {
+		fmt.Printf("[WARN] Failed to decode cached seasons of %s: %v\n", competition, err)
+		return false
+	}
+	return service.SeasonCompleted(&seasons, season, time.Now())
+}
+
+// fetchSeasons fetches the list of a competition's seasons for the cache
+func (c *SportsDataController) fetchSeasons(competition string) service.CacheFetch {
+	return func(ctx context.Context) (interface{}, bool, error) {
+		seasons, err := c.footballService.GetSeasons(ctx, competition)
+		if err != nil {
+			return nil, false, err
 		}
+		return seasons, len(seasons.Seasons) > 0, nil
 	}
-	fixtures, err := c.fixturesService.GetTodaysFixtures()
+}
+
+// HandleGetSeasons handles requests for the list of a competition's seasons
+func (c *SportsDataController) HandleGetSeasons(w http.ResponseWriter, r *http.Request) {
+	competition := strings.ToUpper(mux.Vars(r)["code"])
+
+	err := c.serveCached(w, r, service.CacheSeasons, competition, c.fetchSeasons(competition))
 	if err != nil {
-		fmt.Printf("Error fetching today's fixtures: %v\n", err)
-		http.Error(w, "Failed to retrieve today's fixtures", http.StatusInternalServerError)
-		return
+		fmt.Printf("Error fetching seasons for %s: %v\n", competition, err)
+		http.Error(w, "Failed to retrieve seasons data", http.StatusInternalServerError)
 	}
+}
 
-	// Only cache if fixtures is non-empty
-	if len(fixtures) > 0 {
-		newETag := fmt.Sprintf("%d", time.Now().UnixNano())
-		if fixturesJSON, err := json.Marshal(fixtures); err == nil {
-			cacheItem := models.CacheItem{
-				Key:          cacheKey,
-				Value:        fixturesJSON,
-				ETag:         newETag,
-				LastModified: time.Now(),
-				ExpiresAt:    time.Now().Add(24 * time.Hour),
-			}
-			_ = c.cacheRepo.SetWithMetadata(cacheKey, cacheItem)
+// HandleGetBracket handles requests for a cup competition's knockout bracket and group tables
+func (c *SportsDataController) HandleGetBracket(w http.ResponseWriter, r *http.Request) {
+	competition := strings.ToUpper(mux.Vars(r)["code"])
+
+	err := c.serveCached(w, r, service.CacheBracket, competition, func(ctx context.Context) (interface{}, bool, error) {
+		bracket, err := c.bracketService.GetBracket(ctx, competition)
+		if err != nil {
+			return nil, false, err
 		}
-		w.Header().Set("ETag", newETag)
-		w.Header().Set("Last-Modified", time.Now().Format(http.TimeFormat))
-	} else {
-		fmt.Printf("[WARN] Not caching empty fixtures for %s\n", time.Now().Format("2006-01-02"))
+		return bracket, len(bracket.Groups) > 0 || len(bracket.Rounds) > 0, nil
+	})
+	if err != nil {
+		fmt.Printf("Error fetching bracket for %s: %v\n", competition, err)
+		http.Error(w, "Failed to retrieve bracket data", http.StatusInternalServerError)
+	}
+}
+
+// HandleGetTodaysFixtures handles requests for today's fixtures.
+func (c *SportsDataController) HandleGetTodaysFixtures(w http.ResponseWriter, r *http.Request) {
+	err := c.serveCached(w, r, service.CacheTodayFixtures, service.TodaysFixturesCacheKey(), service.TodaysFixturesFetch(c.fixturesService))
+	if err != nil {
+		fmt.Printf("Error fetching today's fixtures: %v\n", err)
+		http.Error(w, "Failed to retrieve today's fixtures", http.StatusInternalServerError)
 	}
-	utils.RespondWithJSON(w, http.StatusOK, fixtures)
 }
 
 // HandleGetFixturesSummary handles requests for fixtures summary (today, tomorrow, upcoming)
@@ -253,42 +308,30 @@ func (c *SportsDataController) HandleGetFixturesSummary(w http.ResponseWriter, r
 		return
 	}
 
-	cacheKey := fmt.Sprintf("fixtures_summary_%s", competition)
-	if cachedItem, err := c.cacheRepo.Get(cacheKey); err == nil && cachedItem != nil {
-		var summary interface{}
-		if err := json.Unmarshal(cachedItem.Value, &summary); err == nil {
-			w.Header().Set("ETag", cachedItem.ETag)
-			w.Header().Set("Last-Modified", cachedItem.LastModified.Format(http.TimeFormat))
-			utils.RespondWithJSON(w, http.StatusOK, summary)
-			return
-		}
-	}
-
-	summary, err := c.fixturesService.GetFixturesSummary(competition)
+	err := c.serveCached(w, r, service.CacheFixturesSummary, competition, service.FixturesSummaryFetch(c.fixturesService, competition))
 	if err != nil {
 		fmt.Printf("Error fetching fixtures summary for %s: %v\n", competition, err)
 		http.Error(w, "Failed to retrieve fixtures summary", http.StatusInternalServerError)
-		return
 	}
+}
 
-	// Only cache if summary has some data (at least one non-empty array)
-	hasData := len(summary.Today) > 0 || len(summary.Tomorrow) > 0 || len(summary.Upcoming) > 0
-	if hasData {
-		newETag := fmt.Sprintf("%d", time.Now().UnixNano())
-		if summaryJSON, err := json.Marshal(summary); err == nil {
-			cacheItem := models.CacheItem{
-				Key:          cacheKey,
-				Value:        summaryJSON,
-				ETag:         newETag,
-				LastModified: time.Now(),
-				ExpiresAt:    time.Now().Add(24 * time.Hour),
-			}
-			_ = c.cacheRepo.SetWithMetadata(cacheKey, cacheItem)
-		}
-		w.Header().Set("ETag", newETag)
-		w.Header().Set("Last-Modified", time.Now().Format(http.TimeFormat))
-	} else {
-		fmt.Printf("[WARN] Not caching empty fixtures summary for %s\n", competition)
+// serveCached responds with the entry cached under key, fetching and caching a new one on a miss.
+// Stale entries are served, marked as such, while the cache refreshes them or when the provider
+// fails. If fetching fails and nothing was ever cached, nothing is written and the error is returned.
+func (c *SportsDataController) serveCached(w http.ResponseWriter, r *http.Request, ns service.CacheNamespace, key string, fetch service.CacheFetch) error {
+	entry, err := c.cache.Fetch(r.Context(), ns, key, fetch)
+	if err != nil {
+		return err
+	}
+	if entry.Stale {
+		utils.RespondWithStaleJSON(w, r, entry.Value, entry.ETag, entry.LastModified, entry.Age())
+		return nil
 	}
-	utils.RespondWithJSON(w, http.StatusOK, summary)
+	utils.RespondWithConditionalJSON(w, r, entry.Value, entry.ETag, entry.LastModified)
+	return nil
+}
+
+// HandleGetCacheMetrics reports how each cache namespace has been used since the server started
+func (c *SportsDataController) HandleGetCacheMetrics(w http.ResponseWriter, r *http.Request) {
+	utils.RespondWithJSON(w, http.StatusOK, c.cache.Metrics())
 }
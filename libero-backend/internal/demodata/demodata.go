@@ -0,0 +1,76 @@
+// Package demodata serves generated football data for every supported competition, for demos and
+// offline development without a provider account. Teams come from an embedded seed; squads,
+// schedules and results are derived from it deterministically, so every process shows the same
+// season, and matches move from scheduled through live to finished along the real clock.
+//
+// Every ID handed out is 900000 or above, far from the providers' own, so demo records can't be
+// mistaken for real ones.
+package demodata
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+)
+
+// seedJSON lists the areas, competitions and teams of the demo data
+//
+//go:embed seed.json
+var seedJSON []byte
+
+// seed is the decoded seed.json
+type seed struct {
+	Areas        []seedArea        `json:"areas"`
+	Competitions []seedCompetition `json:"competitions"`
+}
+
+// seedArea is a country or region, with the names its players are given
+type seedArea struct {
+	ID         int      `json:"id"`
+	Code       string   `json:"code"`
+	Name       string   `json:"name"`
+	FirstNames []string `json:"firstNames"`
+	LastNames  []string `json:"lastNames"`
+}
+
+// seedCompetition is a league with its teams, or a cup drawing teams from the leagues
+type seedCompetition struct {
+	ID         int        `json:"id"`
+	Code       string     `json:"code"`
+	Name       string     `json:"name"`
+	Type       string     `json:"type"` // LEAGUE or CUP
+	Area       string     `json:"area"` // Area code
+	Teams      []seedTeam `json:"teams"`
+	TeamIDs    []int      `json:"teamIds"`    // Teams of a cup, by ID
+	FinalVenue string     `json:"finalVenue"` // Where a cup's final is played
+}
+
+// seedTeam is a club. Strength, from 0 to 100, skews its results.
+type seedTeam struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	ShortName string `json:"shortName"`
+	TLA       string `json:"tla"`
+	Venue     string `json:"venue"`
+	Founded   int    `json:"founded"`
+	Strength  int    `json:"strength"`
+}
+
+// loadSeed decodes the embedded seed
+func loadSeed() (*seed, error) {
+	var s seed
+	if err := json.Unmarshal(seedJSON, &s); err != nil {
+		return nil, fmt.Errorf("failed to decode demo seed: %w", err)
+	}
+	return &s, nil
+}
+
+// hash derives a stable number from parts, so generated data is the same in every process
+func hash(parts ...interface{}) uint64 {
+	h := fnv.New64a()
+	for _, part := range parts {
+		fmt.Fprintf(h, "%v|", part)
+	}
+	return h.Sum64()
+}
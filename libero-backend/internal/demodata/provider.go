@@ -0,0 +1,490 @@
+package demodata
+
+import (
+	"context"
+	"fmt"
+	"libero-backend/internal/models"
+	"libero-backend/internal/provider"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ProviderName identifies the demo provider in routes and logs
+	ProviderName = "demo"
+	// scorersLimit is the number of players on a scorers table, as the real provider returns by default
+	scorersLimit = 10
+	// dateLayout is the layout of dates in match queries
+	dateLayout = "2006-01-02"
+	// pastSeasons is the number of seasons served before the current one
+	pastSeasons = 4
+)
+
+// Provider is a FootballDataProvider serving the generated demo data. It supports the same
+// competition codes as the real providers.
+type Provider struct {
+	world *world
+
+	mutex   sync.Mutex
+	seasons map[string]*season // Generated seasons by competition code and year
+}
+
+// NewProvider creates a demo data provider from the embedded seed
+func NewProvider() (*Provider, error) {
+	s, err := loadSeed()
+	if err != nil {
+		return nil, err
+	}
+	w, err := newWorld(s)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{world: w, seasons: make(map[string]*season)}, nil
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return ProviderName
+}
+
+// Competition retrieves a competition with its area and current season
+func (p *Provider) Competition(ctx context.Context, code string) (*models.CompetitionDetailResponse, error) {
+	now := time.Now().UTC()
+	s, err := p.currentSeason(code, now)
+	if err != nil {
+		return nil, err
+	}
+	c := s.competition
+	return &models.CompetitionDetailResponse{
+		ID:            c.ID,
+		Name:          c.Name,
+		Code:          c.Code,
+		Type:          c.Type,
+		Area:          areaResponse(c.area),
+		CurrentSeason: seasonResponse(s, now),
+	}, nil
+}
+
+// CompetitionSeasons retrieves the competition's current and past seasons, most recent first
+func (p *Provider) CompetitionSeasons(ctx context.Context, code string) ([]models.SeasonResponse, error) {
+	now := time.Now().UTC()
+	seasons := make([]models.SeasonResponse, 0, pastSeasons+1)
+	for year := seasonYear(now); year >= seasonYear(now)-pastSeasons; year-- {
+		s, err := p.season(code, year)
+		if err != nil {
+			return nil, err
+		}
+		seasons = append(seasons, seasonResponse(s, now))
+	}
+	return seasons, nil
+}
+
+// CompetitionTeams retrieves the teams of a competition's current season, including their squads
+func (p *Provider) CompetitionTeams(ctx context.Context, code string) (*models.CompetitionTeamsResponse, error) {
+	now := time.Now().UTC()
+	s, err := p.currentSeason(code, now)
+	if err != nil {
+		return nil, err
+	}
+	teams := make([]models.TeamDetailResponse, 0, len(s.competition.teams))
+	for _, t := range s.competition.teams {
+		teams = append(teams, teamDetailResponse(t))
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Name < teams[j].Name })
+	return &models.CompetitionTeamsResponse{Season: seasonResponse(s, now), Teams: teams}, nil
+}
+
+// Standings retrieves a competition's standings of a season: the total, home and away tables
+func (p *Provider) Standings(ctx context.Context, code string, year int) (*models.StandingsResponse, error) {
+	now := time.Now().UTC()
+	s, err := p.servedSeason(code, year, now)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := make([]models.StandingsGroupResponse, 0, 3)
+	for _, standingsType := range []string{"TOTAL", "HOME", "AWAY"} {
+		table := make([]models.StandingsRowResponse, 0, len(s.competition.teams))
+		for i, row := range s.table(now, standingsType) {
+			table = append(table, models.StandingsRowResponse{
+				Position:       i + 1,
+				Team:           teamResponse(row.team),
+				PlayedGames:    row.played,
+				Won:            row.won,
+				Draw:           row.draw,
+				Lost:           row.lost,
+				Points:         row.points(),
+				GoalsFor:       row.goalsFor,
+				GoalsAgainst:   row.goalsAgainst,
+				GoalDifference: row.goalsFor - row.goalsAgainst,
+			})
+		}
+		standings = append(standings, models.StandingsGroupResponse{Stage: s.fixtures[0].stage, Type: standingsType, Table: table})
+	}
+	return &models.StandingsResponse{
+		Competition: models.CompetitionRefResponse{ID: s.competition.ID, Name: s.competition.Name},
+		Season:      models.SeasonRefResponse{ID: s.id, Current: s.year == seasonYear(now)},
+		Standings:   standings,
+	}, nil
+}
+
+// Scorers retrieves a competition's top scorers of a season
+func (p *Provider) Scorers(ctx context.Context, code string, year int) (*models.ScorersResponse, error) {
+	now := time.Now().UTC()
+	s, err := p.servedSeason(code, year, now)
+	if err != nil {
+		return nil, err
+	}
+
+	scorers := make([]models.ScorerResponse, 0, scorersLimit)
+	for _, row := range s.scorers(now, scorersLimit) {
+		scorers = append(scorers, models.ScorerResponse{
+			Player: models.PlayerResponse{
+				ID:          row.player.id,
+				Name:        row.player.name(),
+				Position:    row.player.position,
+				Nationality: row.player.nationality,
+			},
+			Team:      teamResponse(row.player.team),
+			Goals:     row.goals,
+			Assists:   row.assists,
+			Penalties: row.penalties,
+		})
+	}
+	return &models.ScorersResponse{
+		Competition: models.CompetitionRefResponse{ID: s.competition.ID, Name: s.competition.Name},
+		Season:      models.SeasonRefResponse{ID: s.id, Current: s.year == seasonYear(now)},
+		Scorers:     scorers,
+	}, nil
+}
+
+// Matches retrieves the matches passing the query. Without IDs or dates, today's matches are returned.
+func (p *Provider) Matches(ctx context.Context, query provider.MatchQuery) (*models.MatchesResponse, error) {
+	now := time.Now().UTC()
+	if len(query.IDs) > 0 {
+		matches := make([]models.MatchResponse, 0, len(query.IDs))
+		for _, id := range query.IDs {
+			if s, f := p.fixture(id); f != nil && matchesStatus(query.Status, f, now) {
+				matches = append(matches, matchResponse(s, f, now))
+			}
+		}
+		return &models.MatchesResponse{Matches: matches}, nil
+	}
+
+	dateFrom, dateTo := query.DateFrom, query.DateTo
+	if query.Date != "" {
+		dateFrom, dateTo = query.Date, query.Date
+	}
+	if dateFrom == "" && dateTo == "" {
+		dateFrom = now.Format(dateLayout)
+		dateTo = dateFrom
+	}
+
+	codes := query.Competitions
+	if len(codes) == 0 {
+		for code := range p.world.competitions {
+			codes = append(codes, code)
+		}
+	}
+	matches := make([]models.MatchResponse, 0)
+	for _, code := range codes {
+		// The previous season is included so date ranges reaching back across the summer are answered
+		for _, year := range []int{seasonYear(now) - 1, seasonYear(now)} {
+			s, err := p.season(code, year)
+			if err != nil {
+				continue
+			}
+			for _, f := range s.fixtures {
+				day := f.kickoff.Format(dateLayout)
+				if (dateFrom != "" && day < dateFrom) || (dateTo != "" && day > dateTo) {
+					continue
+				}
+				if matchesStatus(query.Status, f, now) {
+					matches = append(matches, matchResponse(s, f, now))
+				}
+			}
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].UtcDate.Equal(matches[j].UtcDate) {
+			return matches[i].ID < matches[j].ID
+		}
+		return matches[i].UtcDate.Before(matches[j].UtcDate)
+	})
+	return &models.MatchesResponse{Matches: matches}, nil
+}
+
+// CompetitionMatches retrieves every match of a competition's current season
+func (p *Provider) CompetitionMatches(ctx context.Context, code string) (*models.MatchesResponse, error) {
+	now := time.Now().UTC()
+	s, err := p.currentSeason(code, now)
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]models.MatchResponse, 0, len(s.fixtures))
+	for _, f := range s.fixtures {
+		matches = append(matches, matchResponse(s, f, now))
+	}
+	return &models.MatchesResponse{Matches: matches}, nil
+}
+
+// Match retrieves a single match
+func (p *Provider) Match(ctx context.Context, id int) (*models.MatchResponse, error) {
+	s, f := p.fixture(id)
+	if f == nil {
+		return nil, fmt.Errorf("demo match %d: %w", id, provider.ErrNotFound)
+	}
+	match := matchResponse(s, f, time.Now().UTC())
+	return &match, nil
+}
+
+// Team retrieves a team with its squad
+func (p *Provider) Team(ctx context.Context, id int) (*models.TeamDetailResponse, error) {
+	t, ok := p.world.teams[id]
+	if !ok {
+		return nil, fmt.Errorf("demo team %d: %w", id, provider.ErrNotFound)
+	}
+	team := teamDetailResponse(t)
+	return &team, nil
+}
+
+// Person retrieves a player with their current team
+func (p *Provider) Person(ctx context.Context, id int) (*models.PersonResponse, error) {
+	pl, ok := p.world.players[id]
+	if !ok {
+		return nil, fmt.Errorf("demo person %d: %w", id, provider.ErrNotFound)
+	}
+	shirtNumber := pl.shirtNumber
+	currentTeam := teamResponse(pl.team)
+	return &models.PersonResponse{
+		ID:          pl.id,
+		Name:        pl.name(),
+		FirstName:   pl.firstName,
+		LastName:    pl.lastName,
+		DateOfBirth: pl.dateOfBirth,
+		Nationality: pl.nationality,
+		Position:    pl.position,
+		ShirtNumber: &shirtNumber,
+		CurrentTeam: &currentTeam,
+	}, nil
+}
+
+// currentSeason returns a competition's season being played at now
+func (p *Provider) currentSeason(code string, now time.Time) (*season, error) {
+	return p.season(code, seasonYear(now))
+}
+
+// servedSeason returns a competition's season starting in year, or the current one for year 0.
+// Only the current and the past few seasons are served.
+func (p *Provider) servedSeason(code string, year int, now time.Time) (*season, error) {
+	if year == 0 {
+		return p.currentSeason(code, now)
+	}
+	if year > seasonYear(now) || year < seasonYear(now)-pastSeasons {
+		return nil, fmt.Errorf("demo season %d of %s: %w", year, code, provider.ErrNotFound)
+	}
+	return p.season(code, year)
+}
+
+// season returns a competition's season starting in year, generating it on first use
+func (p *Provider) season(code string, year int) (*season, error) {
+	c, ok := p.world.competitions[strings.ToUpper(code)]
+	if !ok {
+		return nil, fmt.Errorf("demo competition %s: %w", code, provider.ErrNotFound)
+	}
+
+	key := fmt.Sprintf("%s/%d", c.Code, year)
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	s, ok := p.seasons[key]
+	if !ok {
+		s = generateSeason(c, year)
+		p.seasons[key] = s
+	}
+	return s, nil
+}
+
+// fixture finds a match by ID, or returns a nil fixture
+func (p *Provider) fixture(id int) (*season, *fixture) {
+	competitionID, year, ok := parseMatchID(id)
+	if !ok {
+		return nil, nil
+	}
+	for code, c := range p.world.competitions {
+		if c.ID != competitionID {
+			continue
+		}
+		s, err := p.season(code, year)
+		if err != nil {
+			return nil, nil
+		}
+		for _, f := range s.fixtures {
+			if f.id == id {
+				return s, f
+			}
+		}
+	}
+	return nil, nil
+}
+
+// matchesStatus reports whether a fixture's status at now is among statuses, a comma-separated
+// list; an empty list matches every status
+func matchesStatus(statuses string, f *fixture, now time.Time) bool {
+	if statuses == "" {
+		return true
+	}
+	status, _, _ := f.state(now)
+	for _, s := range strings.Split(statuses, ",") {
+		if strings.TrimSpace(s) == status {
+			return true
+		}
+	}
+	return false
+}
+
+// matchResponse maps a fixture as it stands at now
+func matchResponse(s *season, f *fixture, now time.Time) models.MatchResponse {
+	status, goals, minute := f.state(now)
+	match := models.MatchResponse{
+		ID:       f.id,
+		UtcDate:  f.kickoff,
+		Status:   status,
+		Matchday: f.matchday,
+		Stage:    f.stage,
+		Venue:    f.venue,
+		Competition: models.MatchCompetitionResponse{
+			ID:   s.competition.ID,
+			Name: s.competition.Name,
+			Code: s.competition.Code,
+		},
+	}
+	// Knockout matches are listed before their teams are decided, with the teams left empty
+	if f.teamsKnown(now) {
+		if match.Venue == "" {
+			match.Venue = f.home.Venue
+		}
+		match.HomeTeam = teamResponse(f.home)
+		match.AwayTeam = teamResponse(f.away)
+		match.Referees = []models.RefereeResponse{refereeResponse(f)}
+	}
+	if status == "TIMED" {
+		return match
+	}
+
+	home, away := count(goals)
+	match.Score.Duration = durationRegular
+	if minute >= 45 {
+		halfHome, halfAway := count(goalsUntil(goals, 45))
+		match.Score.HalfTime = models.ScoreResponse{Home: &halfHome, Away: &halfAway}
+	}
+	if f.duration != durationRegular && !now.Before(f.kickoff.Add(fullTimeEnd)) {
+		regularHome, regularAway := count(goalsUntil(goals, 90))
+		extraHome, extraAway := home-regularHome, away-regularAway
+		match.Score.Duration = durationExtraTime
+		match.Score.RegularTime = models.ScoreResponse{Home: &regularHome, Away: &regularAway}
+		match.Score.ExtraTime = models.ScoreResponse{Home: &extraHome, Away: &extraAway}
+		if f.duration == durationPenaltyShootout && !now.Before(f.kickoff.Add(extraTimeEnd)) {
+			match.Score.Duration = durationPenaltyShootout
+		}
+		if f.duration == durationPenaltyShootout && status == "FINISHED" {
+			penaltiesHome, penaltiesAway := f.penalties[0], f.penalties[1]
+			match.Score.Penalties = models.ScoreResponse{Home: &penaltiesHome, Away: &penaltiesAway}
+			// As with football-data.org, the full-time score includes the shootout
+			home += penaltiesHome
+			away += penaltiesAway
+		}
+	}
+	match.Score.FullTime = models.ScoreResponse{Home: &home, Away: &away}
+	if status == "FINISHED" {
+		switch {
+		case home > away:
+			match.Score.Winner = "HOME_TEAM"
+		case away > home:
+			match.Score.Winner = "AWAY_TEAM"
+		default:
+			match.Score.Winner = "DRAW"
+		}
+	}
+	return match
+}
+
+// goalsUntil returns the goals scored up to and including minute
+func goalsUntil(goals []goal, minute int) []goal {
+	var until []goal
+	for _, g := range goals {
+		if g.minute <= minute {
+			until = append(until, g)
+		}
+	}
+	return until
+}
+
+// refereeResponse makes up the referee of a fixture, from the home team's country
+func refereeResponse(f *fixture) models.RefereeResponse {
+	area := f.home.area
+	if len(area.FirstNames) == 0 {
+		area = f.away.area
+	}
+	h := hash("referee", f.id)
+	return models.RefereeResponse{
+		ID:          980_000 + int(h%10_000),
+		Name:        area.FirstNames[(h>>16)%uint64(len(area.FirstNames))] + " " + area.LastNames[(h>>32)%uint64(len(area.LastNames))],
+		Type:        "REFEREE",
+		Nationality: area.Name,
+	}
+}
+
+// seasonResponse maps a season as it stands at now
+func seasonResponse(s *season, now time.Time) models.SeasonResponse {
+	currentMatchday := s.currentMatchday(now)
+	season := models.SeasonResponse{
+		ID:              s.id,
+		StartDate:       s.start.Format(dateLayout),
+		EndDate:         s.end.Format(dateLayout),
+		CurrentMatchday: &currentMatchday,
+	}
+	if winner := s.winner(now); winner != nil {
+		team := teamResponse(winner)
+		season.Winner = &team
+	}
+	return season
+}
+
+// areaResponse maps a seeded area
+func areaResponse(a *seedArea) models.AreaResponse {
+	return models.AreaResponse{ID: a.ID, Name: a.Name, Code: a.Code}
+}
+
+// teamResponse maps a team reference
+func teamResponse(t *team) models.TeamResponse {
+	return models.TeamResponse{ID: t.ID, Name: t.Name, ShortName: t.ShortName}
+}
+
+// teamDetailResponse maps a team with its squad
+func teamDetailResponse(t *team) models.TeamDetailResponse {
+	founded := t.Founded
+	squad := make([]models.SquadMemberResponse, 0, len(t.squad))
+	for _, p := range t.squad {
+		shirtNumber := p.shirtNumber
+		squad = append(squad, models.SquadMemberResponse{
+			ID:          p.id,
+			Name:        p.name(),
+			Position:    p.position,
+			DateOfBirth: p.dateOfBirth,
+			Nationality: p.nationality,
+			ShirtNumber: &shirtNumber,
+		})
+	}
+	return models.TeamDetailResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		ShortName: t.ShortName,
+		TLA:       t.TLA,
+		Venue:     t.Venue,
+		Founded:   &founded,
+		Area:      areaResponse(t.area),
+		Squad:     squad,
+	}
+}
@@ -0,0 +1,818 @@
+package demodata
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+const (
+	// squadSize is the number of players generated per team
+	squadSize = 18
+	// cupMatchdays is the number of league-stage matchdays of a cup
+	cupMatchdays = 8
+	// cupMatchdayInterval is the time between two cup matchdays
+	cupMatchdayInterval = 21 * 24 * time.Hour
+	// firstHalfEnd and secondHalfStart bound half-time, counted from kickoff
+	firstHalfEnd    = 47 * time.Minute
+	secondHalfStart = 62 * time.Minute
+	// fullTimeEnd is when a match is over, counted from kickoff
+	fullTimeEnd = 109 * time.Minute
+	// extraTimeStart and extraTimeEnd bound extra time, and shootoutEnd is when a match decided on
+	// penalties is over, counted from kickoff
+	extraTimeStart = 114 * time.Minute
+	extraTimeEnd   = 147 * time.Minute
+	shootoutEnd    = 162 * time.Minute
+)
+
+// Durations of a match, as football-data.org names them
+const (
+	durationRegular         = "REGULAR"
+	durationExtraTime       = "EXTRA_TIME"
+	durationPenaltyShootout = "PENALTY_SHOOTOUT"
+)
+
+// knockoutRound is a round of a cup's knockout phase. Dates are counted from the previous round's
+// last matchday.
+type knockoutRound struct {
+	stage string
+	legs  []time.Duration // Days of each leg
+}
+
+// knockoutRounds follow a cup's league stage. The first round pairs the top eight of the table.
+var knockoutRounds = []knockoutRound{
+	{"QUARTER_FINALS", []time.Duration{28 * 24 * time.Hour, 35 * 24 * time.Hour}},
+	{"SEMI_FINALS", []time.Duration{21 * 24 * time.Hour, 28 * 24 * time.Hour}},
+	{"FINAL", []time.Duration{25 * 24 * time.Hour}},
+}
+
+// quarterFinalSeeds pairs the league-stage positions (from 0) drawn against each other, so the
+// top two can only meet in the final
+var quarterFinalSeeds = [][2]int{{0, 7}, {3, 4}, {1, 6}, {2, 5}}
+
+// Player positions, as football-data.org names them
+const (
+	positionGoalkeeper = "Goalkeeper"
+	positionDefence    = "Defence"
+	positionMidfield   = "Midfield"
+	positionOffence    = "Offence"
+)
+
+// squadSlots lays out a squad: position, shirt number and how likely the player scores and assists
+var squadSlots = [squadSize]struct {
+	position     string
+	shirtNumber  int
+	goalWeight   int
+	assistWeight int
+}{
+	{positionGoalkeeper, 1, 0, 0},
+	{positionGoalkeeper, 13, 0, 0},
+	{positionDefence, 2, 1, 2},
+	{positionDefence, 3, 1, 3},
+	{positionDefence, 4, 1, 1},
+	{positionDefence, 5, 1, 1},
+	{positionDefence, 6, 1, 2},
+	{positionDefence, 15, 1, 1},
+	{positionMidfield, 8, 3, 5},
+	{positionMidfield, 10, 4, 7},
+	{positionMidfield, 14, 2, 4},
+	{positionMidfield, 16, 2, 3},
+	{positionMidfield, 18, 2, 3},
+	{positionMidfield, 20, 2, 3},
+	{positionOffence, 7, 6, 5},
+	{positionOffence, 9, 12, 3},
+	{positionOffence, 11, 6, 5},
+	{positionOffence, 19, 4, 2},
+}
+
+// leagueKickoffs are the kickoff times of a league matchday's matches, from the Saturday's midnight (UTC)
+var leagueKickoffs = []time.Duration{
+	-5 * time.Hour,
+	11*time.Hour + 30*time.Minute,
+	14 * time.Hour,
+	14 * time.Hour,
+	14 * time.Hour,
+	16*time.Hour + 30*time.Minute,
+	37 * time.Hour,
+	39*time.Hour + 15*time.Minute,
+	41*time.Hour + 30*time.Minute,
+	67 * time.Hour,
+}
+
+// cupKickoffs are the kickoff times of a cup matchday's matches, from the matchday's midnight (UTC)
+var cupKickoffs = []time.Duration{
+	16*time.Hour + 45*time.Minute,
+	19 * time.Hour,
+}
+
+// cupWeekdays are the weekdays each cup plays on
+var cupWeekdays = map[string]time.Weekday{
+	"CL": time.Tuesday,
+	"EL": time.Thursday,
+}
+
+// world is the seed with generated squads, indexed for lookups
+type world struct {
+	areas        map[string]*seedArea
+	competitions map[string]*competition // By code
+	teams        map[int]*team
+	players      map[int]*player
+}
+
+// competition is a seeded competition with its teams
+type competition struct {
+	seedCompetition
+	area  *seedArea
+	teams []*team
+}
+
+// team is a seeded team with its generated squad
+type team struct {
+	seedTeam
+	area  *seedArea
+	squad []*player
+}
+
+// player is a generated squad member
+type player struct {
+	id           int
+	firstName    string
+	lastName     string
+	dateOfBirth  string
+	nationality  string
+	position     string
+	shirtNumber  int
+	goalWeight   int
+	assistWeight int
+	team         *team
+}
+
+// name returns the player's full name
+func (p *player) name() string {
+	return p.firstName + " " + p.lastName
+}
+
+// season is a competition's generated season
+type season struct {
+	id          int
+	year        int // Year the season starts in
+	competition *competition
+	fixtures    []*fixture // By kickoff
+	start       time.Time
+	end         time.Time
+}
+
+// fixture is a generated match. Its goals are decided up front and revealed as the clock passes them.
+type fixture struct {
+	id        int
+	matchday  int // 0 for knockout matches
+	stage     string
+	kickoff   time.Time
+	home      *team
+	away      *team
+	venue     string    // Neutral venue, e.g. of a final
+	drawnAt   time.Time // Teams are to be decided until then
+	goals     []goal    // By minute
+	duration  string
+	penalties [2]int // Shootout score, home first
+}
+
+// goal is a goal of a fixture
+type goal struct {
+	minute  int
+	home    bool // Scored by the home team
+	scorer  *player
+	assist  *player // nil if unassisted
+	penalty bool
+}
+
+// newWorld indexes the seed and generates every team's squad
+func newWorld(s *seed) (*world, error) {
+	w := &world{
+		areas:        make(map[string]*seedArea),
+		competitions: make(map[string]*competition),
+		teams:        make(map[int]*team),
+		players:      make(map[int]*player),
+	}
+	var nameAreas []*seedArea
+	for i := range s.Areas {
+		area := &s.Areas[i]
+		w.areas[area.Code] = area
+		if len(area.FirstNames) > 0 && len(area.LastNames) > 0 {
+			nameAreas = append(nameAreas, area)
+		}
+	}
+	if len(nameAreas) == 0 {
+		return nil, fmt.Errorf("demo seed has no player names")
+	}
+
+	for i := range s.Competitions {
+		c := &competition{seedCompetition: s.Competitions[i], area: w.areas[s.Competitions[i].Area]}
+		if c.area == nil {
+			return nil, fmt.Errorf("demo competition %s has unknown area %q", c.Code, c.Area)
+		}
+		for _, seeded := range c.Teams {
+			t := &team{seedTeam: seeded, area: c.area}
+			t.squad = generateSquad(t, nameAreas)
+			for _, p := range t.squad {
+				w.players[p.id] = p
+			}
+			w.teams[t.ID] = t
+			c.teams = append(c.teams, t)
+		}
+		w.competitions[c.Code] = c
+	}
+
+	// Cups draw their teams from the leagues, so they are resolved once every league is in
+	for _, c := range w.competitions {
+		for _, id := range c.TeamIDs {
+			t, ok := w.teams[id]
+			if !ok {
+				return nil, fmt.Errorf("demo competition %s has unknown team %d", c.Code, id)
+			}
+			c.teams = append(c.teams, t)
+		}
+		if len(c.teams)%2 != 0 {
+			return nil, fmt.Errorf("demo competition %s needs an even number of teams", c.Code)
+		}
+	}
+	return w, nil
+}
+
+// generateSquad makes up a team's players. Most are named after the team's country, the rest
+// after another one.
+func generateSquad(t *team, nameAreas []*seedArea) []*player {
+	squad := make([]*player, 0, squadSize)
+	for slot, layout := range squadSlots {
+		h := hash("player", t.ID, slot)
+		area := t.area
+		if h%4 == 0 || len(area.FirstNames) == 0 {
+			area = nameAreas[(h/4)%uint64(len(nameAreas))]
+		}
+		squad = append(squad, &player{
+			id:           t.ID*100 + slot + 1,
+			firstName:    area.FirstNames[(h>>8)%uint64(len(area.FirstNames))],
+			lastName:     area.LastNames[(h>>16)%uint64(len(area.LastNames))],
+			dateOfBirth:  fmt.Sprintf("%d-%02d-%02d", 1991+(h>>24)%15, 1+(h>>32)%12, 1+(h>>40)%28),
+			nationality:  area.Name,
+			position:     layout.position,
+			shirtNumber:  layout.shirtNumber,
+			goalWeight:   layout.goalWeight,
+			assistWeight: layout.assistWeight,
+			team:         t,
+		})
+	}
+	return squad
+}
+
+// seasonYear is the year of the season being played at now. Seasons start in August; from July on
+// the coming season is shown.
+func seasonYear(now time.Time) int {
+	if now.Month() >= time.July {
+		return now.Year()
+	}
+	return now.Year() - 1
+}
+
+// matchID numbers a fixture so its competition and season can be read back from the ID
+func matchID(c *competition, year, index int) int {
+	return 900_000_000 + (c.ID-900_000)*1_000_000 + (year%100)*1_000 + index
+}
+
+// parseMatchID returns the competition ID and season year encoded in a match ID
+func parseMatchID(id int) (competitionID, year int, ok bool) {
+	if id < 900_000_000 || id >= 1_000_000_000 {
+		return 0, 0, false
+	}
+	rest := id - 900_000_000
+	return 900_000 + rest/1_000_000, 2000 + (rest/1_000)%100, true
+}
+
+// generateSeason schedules a competition's season starting in year and decides every result.
+// Leagues play a double round robin on weekends from mid-August; cups play a single-leg league
+// stage midweek every three weeks from mid-September, followed by a knockout phase.
+func generateSeason(c *competition, year int) *season {
+	s := &season{
+		id:          900_000 + (c.ID-900_000)*100 + year%100,
+		year:        year,
+		competition: c,
+	}
+
+	teams := make([]*team, len(c.teams))
+	order := rand.New(rand.NewSource(int64(hash("order", c.Code, year)))).Perm(len(c.teams))
+	for i, j := range order {
+		teams[i] = c.teams[j]
+	}
+	rounds := roundRobin(teams)
+
+	var stage string
+	var kickoffs []time.Duration
+	var firstMatchday time.Time
+	var interval time.Duration
+	if c.Type == "CUP" {
+		if len(rounds) > cupMatchdays {
+			rounds = rounds[:cupMatchdays]
+		}
+		stage = "LEAGUE_STAGE"
+		kickoffs = cupKickoffs
+		firstMatchday = nextWeekday(time.Date(year, time.September, 16, 0, 0, 0, 0, time.UTC), cupWeekdays[c.Code])
+		interval = cupMatchdayInterval
+	} else {
+		// The second half of the season replays the first with home and away swapped
+		firstHalf := rounds
+		for _, round := range firstHalf {
+			mirrored := make([][2]*team, len(round))
+			for i, pairing := range round {
+				mirrored[i] = [2]*team{pairing[1], pairing[0]}
+			}
+			rounds = append(rounds, mirrored)
+		}
+		stage = "REGULAR_SEASON"
+		kickoffs = leagueKickoffs
+		firstMatchday = nextWeekday(time.Date(year, time.August, 15, 0, 0, 0, 0, time.UTC), time.Saturday)
+		interval = 7 * 24 * time.Hour
+	}
+
+	for r, round := range rounds {
+		day := firstMatchday.Add(time.Duration(r) * interval)
+		for i, pairing := range round {
+			f := &fixture{
+				id:       matchID(c, year, len(s.fixtures)+1),
+				matchday: r + 1,
+				stage:    stage,
+				kickoff:  day.Add(kickoffs[i%len(kickoffs)]),
+				home:     pairing[0],
+				away:     pairing[1],
+				duration: durationRegular,
+			}
+			f.goals = decideGoals(f)
+			s.fixtures = append(s.fixtures, f)
+		}
+	}
+	if c.Type == "CUP" {
+		s.scheduleKnockouts(firstMatchday.Add(time.Duration(len(rounds)-1) * interval))
+	}
+	sort.SliceStable(s.fixtures, func(i, j int) bool {
+		return s.fixtures[i].kickoff.Before(s.fixtures[j].kickoff)
+	})
+	s.start = s.fixtures[0].kickoff
+	s.end = s.fixtures[len(s.fixtures)-1].kickoff
+	return s
+}
+
+// scheduleKnockouts draws a cup's knockout phase from the final league-stage table and decides
+// every tie, one round after the other. lastMatchday is the day of the league stage's last matchday.
+func (s *season) scheduleKnockouts(lastMatchday time.Time) {
+	drawnAt := lastMatchday
+	for _, f := range s.fixtures {
+		if end := f.end(); end.After(drawnAt) {
+			drawnAt = end
+		}
+	}
+	table := s.table(drawnAt, "TOTAL")
+	if len(table) < 2*len(quarterFinalSeeds) {
+		return
+	}
+	// Ties list the higher seed first; the lower seed hosts the first leg
+	ties := make([][2]*team, 0, len(quarterFinalSeeds))
+	for _, seeds := range quarterFinalSeeds {
+		ties = append(ties, [2]*team{table[seeds[0]].team, table[seeds[1]].team})
+	}
+
+	day := lastMatchday
+	for _, round := range knockoutRounds {
+		var winners []*team
+		roundOver := drawnAt
+		for _, tie := range ties {
+			legs := make([]*fixture, 0, len(round.legs))
+			for leg, offset := range round.legs {
+				home, away := tie[1], tie[0]
+				if leg%2 == 1 {
+					home, away = away, home
+				}
+				f := &fixture{
+					id:       matchID(s.competition, s.year, len(s.fixtures)+1),
+					stage:    round.stage,
+					kickoff:  day.Add(offset + 19*time.Hour),
+					home:     home,
+					away:     away,
+					drawnAt:  drawnAt,
+					duration: durationRegular,
+				}
+				if len(round.legs) == 1 {
+					f.venue = s.competition.FinalVenue
+				}
+				f.goals = decideGoals(f)
+				legs = append(legs, f)
+				s.fixtures = append(s.fixtures, f)
+			}
+			winners = append(winners, decideTie(legs))
+			if end := legs[len(legs)-1].end(); end.After(roundOver) {
+				roundOver = end
+			}
+		}
+
+		// Winners of neighbouring ties meet in the next round
+		ties = ties[:0]
+		for i := 0; i+1 < len(winners); i += 2 {
+			ties = append(ties, [2]*team{winners[i], winners[i+1]})
+		}
+		day = day.Add(round.legs[len(round.legs)-1])
+		drawnAt = roundOver
+	}
+}
+
+// decideTie settles a knockout tie over its legs. When the teams are level on aggregate, the last
+// leg goes to extra time and then penalties; away goals don't count, as in UEFA competitions since
+// 2021. It returns the team going through.
+func decideTie(legs []*fixture) *team {
+	last := legs[len(legs)-1]
+	aggregate := func() (home, away int) {
+		for _, f := range legs {
+			h, a := count(f.goals)
+			if f.home == last.home {
+				home, away = home+h, away+a
+			} else {
+				home, away = home+a, away+h
+			}
+		}
+		return home, away
+	}
+
+	home, away := aggregate()
+	if home == away {
+		last.goals = append(last.goals, decideExtraTime(last)...)
+		last.duration = durationExtraTime
+		home, away = aggregate()
+	}
+	if home == away {
+		last.penalties = decidePenalties(last)
+		last.duration = durationPenaltyShootout
+		home, away = last.penalties[0], last.penalties[1]
+	}
+	if home > away {
+		return last.home
+	}
+	return last.away
+}
+
+// roundRobin pairs every team with every other once using the circle method, alternating home
+// and away
+func roundRobin(teams []*team) [][][2]*team {
+	n := len(teams)
+	circle := append([]*team{}, teams...)
+	rounds := make([][][2]*team, 0, n-1)
+	for r := 0; r < n-1; r++ {
+		round := make([][2]*team, 0, n/2)
+		for i := 0; i < n/2; i++ {
+			home, away := circle[i], circle[n-1-i]
+			if (i == 0 && r%2 == 1) || (i > 0 && i%2 == 1) {
+				home, away = away, home
+			}
+			round = append(round, [2]*team{home, away})
+		}
+		rounds = append(rounds, round)
+		// Keep the first team in place and rotate the rest
+		last := circle[n-1]
+		copy(circle[2:], circle[1:n-1])
+		circle[1] = last
+	}
+	return rounds
+}
+
+// nextWeekday returns the first day on or after day that falls on weekday
+func nextWeekday(day time.Time, weekday time.Weekday) time.Time {
+	return day.AddDate(0, 0, (int(weekday)-int(day.Weekday())+7)%7)
+}
+
+// decideGoals draws a fixture's goals. Stronger teams score more, and the home team a little more.
+func decideGoals(f *fixture) []goal {
+	rng := rand.New(rand.NewSource(int64(hash("match", f.id))))
+	difference := float64(f.home.Strength-f.away.Strength) / 20
+	return drawGoals(rng, f, math.Min(3.2, math.Max(0.25, 1.35+difference)), math.Min(3.0, math.Max(0.2, 1.1-difference)), 1, 90)
+}
+
+// decideExtraTime draws the goals of a fixture's extra time, about a third as many as in regular time
+func decideExtraTime(f *fixture) []goal {
+	rng := rand.New(rand.NewSource(int64(hash("extra time", f.id))))
+	difference := float64(f.home.Strength-f.away.Strength) / 60
+	return drawGoals(rng, f, math.Max(0.1, 0.45+difference), math.Max(0.1, 0.35-difference), 91, 30)
+}
+
+// decidePenalties takes a fixture's shootout: five kicks each, stopping once a team can't catch
+// up, then sudden death. The score is home first.
+func decidePenalties(f *fixture) [2]int {
+	rng := rand.New(rand.NewSource(int64(hash("penalties", f.id))))
+	var score [2]int
+	for kick := 0; ; kick++ {
+		for side := range score {
+			if rng.Intn(4) != 0 {
+				score[side]++
+			}
+			if kick < 5 {
+				left := [2]int{4 - kick, 4 - kick}
+				if side == 0 {
+					left[1]++
+				}
+				if score[0]+left[0] < score[1] || score[1]+left[1] < score[0] {
+					return score
+				}
+			}
+		}
+		if kick >= 4 && score[0] != score[1] {
+			return score
+		}
+	}
+}
+
+// drawGoals draws goals with the given means per team, in minutes from firstMinute on
+func drawGoals(rng *rand.Rand, f *fixture, homeMean, awayMean float64, firstMinute, minutes int) []goal {
+	homeGoals := poisson(rng, homeMean)
+	awayGoals := poisson(rng, awayMean)
+
+	goals := make([]goal, 0, homeGoals+awayGoals)
+	for i := 0; i < homeGoals+awayGoals; i++ {
+		scoring := f.home
+		if i >= homeGoals {
+			scoring = f.away
+		}
+		g := goal{
+			minute:  firstMinute + rng.Intn(minutes),
+			home:    i < homeGoals,
+			penalty: rng.Intn(9) == 0,
+		}
+		g.scorer = pickPlayer(rng, scoring.squad, nil, func(p *player) int { return p.goalWeight })
+		if !g.penalty && rng.Intn(4) != 0 {
+			g.assist = pickPlayer(rng, scoring.squad, g.scorer, func(p *player) int { return p.assistWeight })
+		}
+		goals = append(goals, g)
+	}
+	sort.SliceStable(goals, func(i, j int) bool {
+		return goals[i].minute < goals[j].minute
+	})
+	return goals
+}
+
+// poisson draws from a Poisson distribution with the given mean
+func poisson(rng *rand.Rand, mean float64) int {
+	limit := math.Exp(-mean)
+	k, product := 0, rng.Float64()
+	for product > limit {
+		k++
+		product *= rng.Float64()
+	}
+	return k
+}
+
+// pickPlayer draws a player other than except, weighted by weight
+func pickPlayer(rng *rand.Rand, squad []*player, except *player, weight func(*player) int) *player {
+	total := 0
+	for _, p := range squad {
+		if p != except {
+			total += weight(p)
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	n := rng.Intn(total)
+	for _, p := range squad {
+		if p == except {
+			continue
+		}
+		if n -= weight(p); n < 0 {
+			return p
+		}
+	}
+	return nil
+}
+
+// end is when a fixture is over, depending on whether it went to extra time or penalties
+func (f *fixture) end() time.Time {
+	switch f.duration {
+	case durationExtraTime:
+		return f.kickoff.Add(extraTimeEnd)
+	case durationPenaltyShootout:
+		return f.kickoff.Add(shootoutEnd)
+	}
+	return f.kickoff.Add(fullTimeEnd)
+}
+
+// teamsKnown reports whether a fixture's teams are decided at now
+func (f *fixture) teamsKnown(now time.Time) bool {
+	return !now.Before(f.drawnAt)
+}
+
+// state returns a fixture's status at now and the goals scored by then
+func (f *fixture) state(now time.Time) (status string, scored []goal, minute int) {
+	elapsed := now.Sub(f.kickoff)
+	switch {
+	case elapsed < 0:
+		return "TIMED", nil, 0
+	case elapsed < firstHalfEnd:
+		minute = int(elapsed / time.Minute)
+		status = "IN_PLAY"
+	case elapsed < secondHalfStart:
+		minute = 45
+		status = "PAUSED"
+	case elapsed < fullTimeEnd:
+		minute = int((elapsed - (secondHalfStart - 45*time.Minute)) / time.Minute)
+		if f.duration != durationRegular && minute > 90 {
+			minute = 90
+		}
+		status = "IN_PLAY"
+	case !now.Before(f.end()):
+		if f.duration == durationRegular {
+			return "FINISHED", f.goals, 90
+		}
+		return "FINISHED", f.goals, 120
+	case elapsed < extraTimeStart:
+		minute = 90
+		status = "PAUSED"
+	case elapsed < extraTimeEnd:
+		minute = min(90+int((elapsed-extraTimeStart)/time.Minute), 120)
+		status = "IN_PLAY"
+	default:
+		// The shootout, whose kicks are revealed once it is over
+		minute = 120
+		status = "IN_PLAY"
+	}
+	for _, g := range f.goals {
+		if g.minute <= minute {
+			scored = append(scored, g)
+		}
+	}
+	return status, scored, minute
+}
+
+// currentMatchday is the matchday of the first match not finished at now, or the last one once
+// the league stage is over. Knockout matches have no matchday.
+func (s *season) currentMatchday(now time.Time) int {
+	last := 0
+	for _, f := range s.fixtures {
+		if f.matchday == 0 {
+			continue
+		}
+		if status, _, _ := f.state(now); status != "FINISHED" {
+			return f.matchday
+		}
+		last = f.matchday
+	}
+	return last
+}
+
+// winner is the team that won the season, i.e. topped a league's table or won a cup's final, or
+// nil until the season is over
+func (s *season) winner(now time.Time) *team {
+	last := s.fixtures[len(s.fixtures)-1]
+	if status, _, _ := last.state(now); status != "FINISHED" {
+		return nil
+	}
+	if s.competition.Type != "CUP" {
+		return s.table(now, "TOTAL")[0].team
+	}
+	home, away := count(last.goals)
+	home, away = home+last.penalties[0], away+last.penalties[1]
+	if home > away {
+		return last.home
+	}
+	return last.away
+}
+
+// standingsRow is a team's record in a table
+type standingsRow struct {
+	team                                            *team
+	played, won, draw, lost, goalsFor, goalsAgainst int
+}
+
+// record adds a finished match to the row
+func (r *standingsRow) record(goalsFor, goalsAgainst int) {
+	r.played++
+	r.goalsFor += goalsFor
+	r.goalsAgainst += goalsAgainst
+	switch {
+	case goalsFor > goalsAgainst:
+		r.won++
+	case goalsFor < goalsAgainst:
+		r.lost++
+	default:
+		r.draw++
+	}
+}
+
+// points returns the row's points
+func (r *standingsRow) points() int {
+	return 3*r.won + r.draw
+}
+
+// table ranks the teams by the league matches finished at now, on points, goal difference, goals
+// scored and then name. standingsType is TOTAL, or HOME or AWAY to only count the teams' home or
+// away matches.
+func (s *season) table(now time.Time, standingsType string) []*standingsRow {
+	rows := make(map[int]*standingsRow, len(s.competition.teams))
+	for _, t := range s.competition.teams {
+		rows[t.ID] = &standingsRow{team: t}
+	}
+	for _, f := range s.fixtures {
+		status, goals, _ := f.state(now)
+		if status != "FINISHED" || f.matchday == 0 {
+			continue
+		}
+		homeGoals, awayGoals := count(goals)
+		if standingsType != "AWAY" {
+			rows[f.home.ID].record(homeGoals, awayGoals)
+		}
+		if standingsType != "HOME" {
+			rows[f.away.ID].record(awayGoals, homeGoals)
+		}
+	}
+
+	table := make([]*standingsRow, 0, len(rows))
+	for _, t := range s.competition.teams {
+		table = append(table, rows[t.ID])
+	}
+	sort.SliceStable(table, func(i, j int) bool {
+		a, b := table[i], table[j]
+		if a.points() != b.points() {
+			return a.points() > b.points()
+		}
+		if a.goalsFor-a.goalsAgainst != b.goalsFor-b.goalsAgainst {
+			return a.goalsFor-a.goalsAgainst > b.goalsFor-b.goalsAgainst
+		}
+		if a.goalsFor != b.goalsFor {
+			return a.goalsFor > b.goalsFor
+		}
+		return a.team.Name < b.team.Name
+	})
+	return table
+}
+
+// scorerRow is a player's goal tally
+type scorerRow struct {
+	player                    *player
+	goals, assists, penalties int
+}
+
+// scorers ranks the players by goals in the matches finished at now, then by assists and name
+func (s *season) scorers(now time.Time, limit int) []*scorerRow {
+	rows := make(map[int]*scorerRow)
+	row := func(p *player) *scorerRow {
+		r, ok := rows[p.id]
+		if !ok {
+			r = &scorerRow{player: p}
+			rows[p.id] = r
+		}
+		return r
+	}
+	for _, f := range s.fixtures {
+		status, goals, _ := f.state(now)
+		if status != "FINISHED" {
+			continue
+		}
+		for _, g := range goals {
+			scorer := row(g.scorer)
+			scorer.goals++
+			if g.penalty {
+				scorer.penalties++
+			}
+			if g.assist != nil {
+				row(g.assist).assists++
+			}
+		}
+	}
+
+	ranked := make([]*scorerRow, 0, len(rows))
+	for _, r := range rows {
+		if r.goals > 0 {
+			ranked = append(ranked, r)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.goals != b.goals {
+			return a.goals > b.goals
+		}
+		if a.assists != b.assists {
+			return a.assists > b.assists
+		}
+		return a.player.id < b.player.id
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// count returns the home and away goals among goals
+func count(goals []goal) (home, away int) {
+	for _, g := range goals {
+		if g.home {
+			home++
+		} else {
+			away++
+		}
+	}
+	return home, away
+}
@@ -0,0 +1,301 @@
+package fakeprovider
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// embeddedFixtures is a small recorded Premier League matchday, used when no fixtures directory is given
+//
+//go:embed fixtures
+var embeddedFixtures embed.FS
+
+const (
+	// manifestFile describes a fixtures directory
+	manifestFile = "manifest.json"
+	// matchesFile holds every recorded match; /matches listings are filtered from it
+	matchesFile = "matches.json"
+	// dateLayout is the layout of dates in match queries and the manifest
+	dateLayout = "2006-01-02"
+)
+
+// manifest describes when a fixtures directory was recorded and from where
+type manifest struct {
+	RecordedOn string `json:"recordedOn"` // YYYY-MM-DD
+	Source     string `json:"source"`
+}
+
+// match is a recorded match. It is kept as raw JSON so replays serve every field the provider sent.
+type match map[string]interface{}
+
+// fixtureStore reads recorded responses laid out by request path, e.g. competitions/PL/standings.json
+// for /competitions/PL/standings and competitions/PL/standings.season-2023.json for its 2023 season,
+// and writes them when recording
+type fixtureStore struct {
+	fsys fs.FS
+	dir  string // Set when the fixtures live on disk and can be recorded into
+
+	mutex sync.Mutex // Serializes read-modify-write of the matches file while recording
+}
+
+// newFixtureStore opens the fixtures in dir, or the embedded fixtures if dir is empty
+func newFixtureStore(dir string) (*fixtureStore, error) {
+	if dir == "" {
+		sub, err := fs.Sub(embeddedFixtures, "fixtures")
+		if err != nil {
+			return nil, err
+		}
+		return &fixtureStore{fsys: sub}, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create fixtures directory: %w", err)
+	}
+	return &fixtureStore{fsys: os.DirFS(dir), dir: dir}, nil
+}
+
+// fixtureFile maps a request path and its season parameter, empty for the current season, onto its
+// fixture file
+func fixtureFile(requestPath, season string) string {
+	file := strings.Trim(path.Clean(requestPath), "/")
+	if season != "" {
+		file += ".season-" + season
+	}
+	return file + ".json"
+}
+
+// read returns the recorded response for a request path and season, or fs.ErrNotExist
+func (s *fixtureStore) read(requestPath, season string) ([]byte, error) {
+	return fs.ReadFile(s.fsys, fixtureFile(requestPath, season))
+}
+
+// manifest returns the fixtures' manifest, or an empty one if there is none
+func (s *fixtureStore) manifest() manifest {
+	var m manifest
+	if data, err := fs.ReadFile(s.fsys, manifestFile); err == nil {
+		_ = json.Unmarshal(data, &m)
+	}
+	return m
+}
+
+// matches returns every recorded match
+func (s *fixtureStore) matches() ([]match, error) {
+	data, err := fs.ReadFile(s.fsys, matchesFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []match{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var recorded struct {
+		Matches []match `json:"matches"`
+	}
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", matchesFile, err)
+	}
+	return recorded.Matches, nil
+}
+
+// write records the response for a request path and season
+func (s *fixtureStore) write(requestPath, season string, body []byte) error {
+	if s.dir == "" {
+		return errors.New("embedded fixtures are read-only")
+	}
+	file := filepath.Join(s.dir, filepath.FromSlash(fixtureFile(requestPath, season)))
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, indentJSON(body), 0o644)
+}
+
+// mergeMatches records matches into the matches file, replacing earlier recordings by ID
+func (s *fixtureStore) mergeMatches(recorded []match) error {
+	if s.dir == "" {
+		return errors.New("embedded fixtures are read-only")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, err := s.matches()
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]match, len(existing)+len(recorded))
+	for _, m := range existing {
+		byID[m.id()] = m
+	}
+	for _, m := range recorded {
+		byID[m.id()] = m
+	}
+
+	merged := make([]match, 0, len(byID))
+	for _, m := range byID {
+		merged = append(merged, m)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].kickoff().Equal(merged[j].kickoff()) {
+			return merged[i].id() < merged[j].id()
+		}
+		return merged[i].kickoff().Before(merged[j].kickoff())
+	})
+
+	body, err := json.Marshal(map[string]interface{}{
+		"resultSet": map[string]int{"count": len(merged)},
+		"matches":   merged,
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dir, matchesFile), indentJSON(body), 0o644)
+}
+
+// writeManifest records when and from where the fixtures were recorded
+func (s *fixtureStore) writeManifest(source string) error {
+	body, err := json.Marshal(manifest{RecordedOn: time.Now().UTC().Format(dateLayout), Source: source})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dir, manifestFile), indentJSON(body), 0o644)
+}
+
+// indentJSON pretty-prints JSON so recorded fixtures are easy to read and diff
+func indentJSON(body []byte) []byte {
+	var out bytes.Buffer
+	if err := json.Indent(&out, body, "", "  "); err != nil {
+		return body
+	}
+	out.WriteString("\n")
+	return out.Bytes()
+}
+
+// id returns the match's provider ID
+func (m match) id() int {
+	if v, ok := m["id"].(float64); ok {
+		return int(v)
+	}
+	return 0
+}
+
+// kickoff returns the match's kickoff time
+func (m match) kickoff() time.Time {
+	value, _ := m["utcDate"].(string)
+	kickoff, _ := time.Parse(time.RFC3339, value)
+	return kickoff
+}
+
+// status returns the match's status
+func (m match) status() string {
+	status, _ := m["status"].(string)
+	return status
+}
+
+// seasonYear returns the year the match's season starts in, e.g. "2025"
+func (m match) seasonYear() string {
+	season, _ := m["season"].(map[string]interface{})
+	startDate, _ := season["startDate"].(string)
+	if len(startDate) < 4 {
+		return ""
+	}
+	return startDate[:4]
+}
+
+// competitionCode returns the code of the match's competition
+func (m match) competitionCode() string {
+	competition, _ := m["competition"].(map[string]interface{})
+	code, _ := competition["code"].(string)
+	return code
+}
+
+// copy returns a copy of the match that can be changed without touching the recording
+func (m match) copy() match {
+	data, _ := json.Marshal(m)
+	var c match
+	_ = json.Unmarshal(data, &c)
+	return c
+}
+
+// matchFilter is a /matches query
+type matchFilter struct {
+	ids          map[int]bool
+	competitions map[string]bool
+	statuses     map[string]bool
+	season       string // Year the season starts in
+	dateFrom     string
+	dateTo       string
+}
+
+// parseMatchFilter reads the football-data.org /matches query parameters
+func parseMatchFilter(query map[string][]string) matchFilter {
+	get := func(key string) string {
+		if values := query[key]; len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	set := func(value string) map[string]bool {
+		if value == "" {
+			return nil
+		}
+		items := make(map[string]bool)
+		for _, item := range strings.Split(value, ",") {
+			items[strings.TrimSpace(item)] = true
+		}
+		return items
+	}
+
+	f := matchFilter{
+		competitions: set(get("competitions")),
+		statuses:     set(get("status")),
+		season:       get("season"),
+		dateFrom:     get("dateFrom"),
+		dateTo:       get("dateTo"),
+	}
+	if date := get("date"); date != "" {
+		f.dateFrom, f.dateTo = date, date
+	}
+	if ids := get("ids"); ids != "" {
+		f.ids = make(map[int]bool)
+		for _, id := range strings.Split(ids, ",") {
+			if n, err := strconv.Atoi(strings.TrimSpace(id)); err == nil {
+				f.ids[n] = true
+			}
+		}
+	}
+	return f
+}
+
+// matches reports whether a match passes the filter
+func (f matchFilter) matches(m match) bool {
+	if f.ids != nil && !f.ids[m.id()] {
+		return false
+	}
+	if f.competitions != nil && !f.competitions[m.competitionCode()] {
+		return false
+	}
+	if f.statuses != nil && !f.statuses[m.status()] {
+		return false
+	}
+	if f.season != "" && m.seasonYear() != f.season {
+		return false
+	}
+	day := m.kickoff().Format(dateLayout)
+	if f.dateFrom != "" && day < f.dateFrom {
+		return false
+	}
+	if f.dateTo != "" && day > f.dateTo {
+		return false
+	}
+	return true
+}
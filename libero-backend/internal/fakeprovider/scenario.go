@@ -0,0 +1,159 @@
+package fakeprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Scenario scripts how the fake provider misbehaves on top of replaying fixtures. The zero
+// value replays fixtures as recorded.
+type Scenario struct {
+	Name string `json:"name"`
+	// RequestsPerMinute enforces a per-minute quota like the real provider's, reporting it in the
+	// X-Requests-Available-Minute and X-RequestCounter-Reset headers. 0 means unlimited.
+	RequestsPerMinute int `json:"requestsPerMinute"`
+	// RateLimitBursts answers runs of requests with 429s
+	RateLimitBursts *RateLimitBurst `json:"rateLimitBursts"`
+	// MissingCompetitions are answered with 404s, as the provider does for competitions outside a plan
+	MissingCompetitions []string `json:"missingCompetitions"`
+	// InPlay moves matches through scripted score changes while the server runs
+	InPlay []Progression `json:"inPlay"`
+}
+
+// RateLimitBurst answers Length requests with 429 after every Every requests that succeed
+type RateLimitBurst struct {
+	Every      int `json:"every"`
+	Length     int `json:"length"`
+	RetryAfter int `json:"retryAfter"` // Seconds
+}
+
+// Progression moves a match through Steps, one every StepSeconds. The match kicks off when the
+// server starts and stays on the last step once it is reached.
+type Progression struct {
+	MatchID     int    `json:"matchId"`
+	StepSeconds int    `json:"stepSeconds"`
+	Steps       []Step `json:"steps"`
+}
+
+// Step is the state of a match at one point of a progression
+type Step struct {
+	Status string `json:"status"` // IN_PLAY, PAUSED or FINISHED
+	Home   int    `json:"home"`
+	Away   int    `json:"away"`
+}
+
+// Scenarios are the built-in scenarios by name
+var Scenarios = map[string]Scenario{
+	"replay": {
+		Name: "replay",
+	},
+	"rate-limited": {
+		Name:              "rate-limited",
+		RequestsPerMinute: 10,
+		RateLimitBursts:   &RateLimitBurst{Every: 5, Length: 3, RetryAfter: 5},
+	},
+	"missing-competition": {
+		Name:                "missing-competition",
+		MissingCompetitions: []string{"PL"},
+	},
+	"in-play": {
+		Name: "in-play",
+		InPlay: []Progression{{
+			MatchID:     537011,
+			StepSeconds: 60,
+			Steps: []Step{
+				{Status: "IN_PLAY", Home: 0, Away: 0},
+				{Status: "IN_PLAY", Home: 1, Away: 0},
+				{Status: "PAUSED", Home: 1, Away: 0},
+				{Status: "IN_PLAY", Home: 1, Away: 1},
+				{Status: "IN_PLAY", Home: 1, Away: 2},
+				{Status: "FINISHED", Home: 1, Away: 2},
+			},
+		}},
+	},
+}
+
+// LoadScenario returns the built-in scenario of that name, or reads a scenario from a JSON file
+func LoadScenario(nameOrPath string) (Scenario, error) {
+	if nameOrPath == "" {
+		return Scenarios["replay"], nil
+	}
+	if scenario, ok := Scenarios[nameOrPath]; ok {
+		return scenario, nil
+	}
+
+	data, err := os.ReadFile(nameOrPath)
+	if err != nil {
+		names := make([]string, 0, len(Scenarios))
+		for name := range Scenarios {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return Scenario{}, fmt.Errorf("unknown scenario %q, use one of %s or a JSON file", nameOrPath, strings.Join(names, ", "))
+	}
+	var scenario Scenario
+	if err := json.Unmarshal(data, &scenario); err != nil {
+		return Scenario{}, fmt.Errorf("failed to decode scenario %s: %w", nameOrPath, err)
+	}
+	if scenario.Name == "" {
+		scenario.Name = nameOrPath
+	}
+	return scenario, nil
+}
+
+// competitionMissing reports whether the scenario answers a competition with 404
+func (s Scenario) competitionMissing(code string) bool {
+	for _, missing := range s.MissingCompetitions {
+		if strings.EqualFold(missing, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// progression returns the progression of a match, if it has one
+func (s Scenario) progression(matchID int) (Progression, bool) {
+	for _, p := range s.InPlay {
+		if p.MatchID == matchID && len(p.Steps) > 0 {
+			return p, true
+		}
+	}
+	return Progression{}, false
+}
+
+// apply moves a match to the step reached after elapsed, with kickoff at startedAt
+func (p Progression) apply(m match, startedAt time.Time, elapsed time.Duration) {
+	stepDuration := time.Duration(p.StepSeconds) * time.Second
+	if stepDuration <= 0 {
+		stepDuration = time.Minute
+	}
+	index := int(elapsed / stepDuration)
+	if index >= len(p.Steps) {
+		index = len(p.Steps) - 1
+	}
+	step := p.Steps[index]
+
+	m["utcDate"] = startedAt.UTC().Truncate(time.Minute).Format(time.RFC3339)
+	m["status"] = step.Status
+	score, _ := m["score"].(map[string]interface{})
+	if score == nil {
+		score = make(map[string]interface{})
+		m["score"] = score
+	}
+	score["fullTime"] = map[string]interface{}{"home": step.Home, "away": step.Away}
+	score["duration"] = "REGULAR"
+	switch {
+	case step.Home > step.Away:
+		score["winner"] = "HOME_TEAM"
+	case step.Away > step.Home:
+		score["winner"] = "AWAY_TEAM"
+	case step.Status == "FINISHED":
+		score["winner"] = "DRAW"
+	default:
+		score["winner"] = nil
+	}
+}
@@ -0,0 +1,349 @@
+// Package fakeprovider is a stand-in for football-data.org that replays recorded responses, so the
+// sports endpoints can be developed and exercised without an API key. Scenarios script rate
+// limiting, missing competitions and live matches on top of the recordings, and record mode
+// proxies to the real provider to capture new fixtures.
+package fakeprovider
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options configures a fake provider
+type Options struct {
+	FixturesDir string   // Fixtures to replay, or the embedded fixtures if empty
+	Scenario    Scenario // How the server misbehaves on top of the fixtures
+	ShiftDates  bool     // Move recorded kickoffs so the day the fixtures were recorded on is today
+
+	// Record mode: requests are proxied to Upstream and its responses recorded into FixturesDir
+	Upstream string
+	APIKey   string // Sent upstream as X-Auth-Token
+}
+
+// Server serves recorded fixtures in football-data.org v4's format
+type Server struct {
+	options   Options
+	store     *fixtureStore
+	startedAt time.Time
+	shift     time.Duration // Added to recorded kickoffs
+	client    *http.Client  // Upstream client in record mode
+
+	mutex       sync.Mutex
+	windowStart time.Time // Start of the current quota minute
+	windowCount int       // Requests served in the current quota minute
+	sinceBurst  int       // Requests served since the last 429 burst
+	burstLeft   int       // 429s left in the current burst
+}
+
+// New creates a fake provider
+func New(options Options) (*Server, error) {
+	if options.Upstream != "" && options.FixturesDir == "" {
+		return nil, errors.New("record mode needs a fixtures directory")
+	}
+	store, err := newFixtureStore(options.FixturesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		options:   options,
+		store:     store,
+		startedAt: time.Now(),
+	}
+	if options.Upstream != "" {
+		s.client = &http.Client{Timeout: 30 * time.Second}
+		if err := store.writeManifest(options.Upstream); err != nil {
+			return nil, fmt.Errorf("failed to write manifest: %w", err)
+		}
+	} else if options.ShiftDates {
+		if recordedOn, err := time.Parse(dateLayout, store.manifest().RecordedOn); err == nil {
+			today := s.startedAt.UTC().Truncate(24 * time.Hour)
+			s.shift = today.Sub(recordedOn)
+		}
+	}
+	return s, nil
+}
+
+// Start runs a fake provider on a free loopback port and returns its base URL, e.g. to point the
+// provider client at it from within the process
+func Start(options Options) (string, func() error, error) {
+	s, err := New(options)
+	if err != nil {
+		return "", nil, err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	srv := &http.Server{Handler: s}
+	go func() {
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("[ERROR] Fake provider stopped: %v", err)
+		}
+	}()
+	return "http://" + listener.Addr().String(), srv.Close, nil
+}
+
+// ServeHTTP answers a provider request
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Accept base URLs with or without the API version
+	requestPath := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v4"), "/")
+
+	if s.options.Upstream != "" {
+		s.record(w, r, requestPath)
+		return
+	}
+	if s.throttle(w) {
+		return
+	}
+
+	parts := strings.Split(strings.Trim(requestPath, "/"), "/")
+	switch {
+	case len(parts) >= 2 && parts[0] == "competitions" && s.options.Scenario.competitionMissing(parts[1]):
+		writeError(w, http.StatusNotFound, fmt.Sprintf("The resource you are looking for does not exist. (competition %s)", parts[1]))
+	case requestPath == "/matches":
+		s.serveMatches(w, r, "")
+	case len(parts) == 3 && parts[0] == "competitions" && parts[2] == "matches":
+		s.serveMatches(w, r, parts[1])
+	case len(parts) == 2 && parts[0] == "matches":
+		s.serveMatch(w, r, parts[1])
+	default:
+		// Seasons other than the recorded one are only served if they were recorded too, rather than
+		// passing current data off as a past season's
+		body, err := s.store.read(requestPath, r.URL.Query().Get("season"))
+		if errors.Is(err, fs.ErrNotExist) {
+			writeError(w, http.StatusNotFound, "The resource you are looking for does not exist.")
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeBody(w, r, body)
+	}
+}
+
+// serveMatches answers a /matches listing from the recorded matches, or a competition's
+// /competitions/{code}/matches listing if competition is set
+func (s *Server) serveMatches(w http.ResponseWriter, r *http.Request, competition string) {
+	matches, err := s.replayMatches()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filter := parseMatchFilter(r.URL.Query())
+	if competition != "" {
+		filter.competitions = map[string]bool{competition: true}
+	}
+	result := make([]match, 0, len(matches))
+	for _, m := range matches {
+		if filter.matches(m) {
+			result = append(result, m)
+		}
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"resultSet": map[string]int{"count": len(result)},
+		"matches":   result,
+	})
+}
+
+// serveMatch answers a single recorded match
+func (s *Server) serveMatch(w http.ResponseWriter, r *http.Request, rawID string) {
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid match id.")
+		return
+	}
+	matches, err := s.replayMatches()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	for _, m := range matches {
+		if m.id() == id {
+			writeJSON(w, r, m)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "The resource you are looking for does not exist.")
+}
+
+// replayMatches returns the recorded matches as they are now: shifted to today and moved along
+// their scripted progressions
+func (s *Server) replayMatches() ([]match, error) {
+	recorded, err := s.store.matches()
+	if err != nil {
+		return nil, err
+	}
+
+	elapsed := time.Since(s.startedAt)
+	matches := make([]match, 0, len(recorded))
+	for _, m := range recorded {
+		m = m.copy()
+		if s.shift != 0 {
+			m["utcDate"] = m.kickoff().Add(s.shift).Format(time.RFC3339)
+		}
+		if progression, ok := s.options.Scenario.progression(m.id()); ok {
+			progression.apply(m, s.startedAt, elapsed)
+		}
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+// throttle applies the scenario's quota and 429 bursts, answering with a 429 and reporting true
+// when the request is rate limited
+func (s *Server) throttle(w http.ResponseWriter) bool {
+	scenario := s.options.Scenario
+	retryAfter := 0
+
+	s.mutex.Lock()
+	if burst := scenario.RateLimitBursts; burst != nil && burst.Every > 0 && burst.Length > 0 {
+		if s.burstLeft > 0 {
+			s.burstLeft--
+			retryAfter = max(burst.RetryAfter, 1)
+		} else if s.sinceBurst++; s.sinceBurst >= burst.Every {
+			s.sinceBurst = 0
+			s.burstLeft = burst.Length
+		}
+	}
+	if retryAfter == 0 && scenario.RequestsPerMinute > 0 {
+		now := time.Now()
+		if now.Sub(s.windowStart) >= time.Minute {
+			s.windowStart = now
+			s.windowCount = 0
+		}
+		reset := int(math.Ceil(s.windowStart.Add(time.Minute).Sub(now).Seconds()))
+		if s.windowCount >= scenario.RequestsPerMinute {
+			retryAfter = reset
+		} else {
+			s.windowCount++
+		}
+		w.Header().Set("X-Requests-Available-Minute", strconv.Itoa(scenario.RequestsPerMinute-s.windowCount))
+		w.Header().Set("X-RequestCounter-Reset", strconv.Itoa(reset))
+	}
+	s.mutex.Unlock()
+
+	if retryAfter == 0 {
+		return false
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	writeError(w, http.StatusTooManyRequests, fmt.Sprintf("You reached your request limit. Wait %d seconds.", retryAfter))
+	return true
+}
+
+// record proxies a request upstream and records a successful response
+func (s *Server) record(w http.ResponseWriter, r *http.Request, requestPath string) {
+	target := strings.TrimRight(s.options.Upstream, "/") + requestPath
+	if r.URL.RawQuery != "" {
+		target += "?" + r.URL.RawQuery
+	}
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, target, nil)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	req.Header.Set("X-Auth-Token", s.options.APIKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	if resp.StatusCode == http.StatusOK && r.Method == http.MethodGet {
+		if err := s.save(requestPath, r.URL.Query().Get("season"), body); err != nil {
+			log.Printf("[WARN] Failed to record %s: %v", requestPath, err)
+		} else {
+			log.Printf("Recorded %s", requestPath)
+		}
+	}
+
+	for _, header := range []string{"Content-Type", "ETag", "Last-Modified", "Retry-After", "X-Requests-Available-Minute", "X-RequestCounter-Reset"} {
+		if value := resp.Header.Get(header); value != "" {
+			w.Header().Set(header, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	w.Write(body)
+}
+
+// save records a response. Matches, including competition match listings, are merged into the
+// matches file so any /matches query can be replayed from them; everything else is stored by path
+// and season.
+func (s *Server) save(requestPath, season string, body []byte) error {
+	switch {
+	case requestPath == "/matches" || (strings.HasPrefix(requestPath, "/competitions/") && strings.HasSuffix(requestPath, "/matches")):
+		var listing struct {
+			Matches []match `json:"matches"`
+		}
+		if err := json.Unmarshal(body, &listing); err != nil {
+			return err
+		}
+		return s.store.mergeMatches(listing.Matches)
+	case strings.HasPrefix(requestPath, "/matches/"):
+		var single match
+		if err := json.Unmarshal(body, &single); err != nil {
+			return err
+		}
+		return s.store.mergeMatches([]match{single})
+	default:
+		return s.store.write(requestPath, season, body)
+	}
+}
+
+// writeBody writes a recorded JSON body with an ETag of its content, answering 304 when the
+// client already has it
+func writeBody(w http.ResponseWriter, r *http.Request, body []byte) {
+	sum := sha1.Sum(body)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// writeJSON encodes and writes a JSON response
+func writeJSON(w http.ResponseWriter, r *http.Request, value interface{}) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeBody(w, r, body)
+}
+
+// writeError writes an error in the provider's format
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":   message,
+		"errorCode": status,
+	})
+}
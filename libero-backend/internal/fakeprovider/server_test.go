@@ -0,0 +1,68 @@
+package fakeprovider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// get requests a path from a fake provider and returns the status and decoded body
+func get(t *testing.T, s *Server, target string) (int, map[string]interface{}) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	var body map[string]interface{}
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("GET %s body error = %v", target, err)
+		}
+	}
+	return rec.Code, body
+}
+
+func TestServerSeasons(t *testing.T) {
+	dir := t.TempDir()
+	write := func(file, body string) {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, file)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("competitions/PL/standings.json", `{"season":{"startDate":"2025-08-15"}}`)
+	write("competitions/PL/standings.season-2023.json", `{"season":{"startDate":"2023-08-11"}}`)
+	write(matchesFile, `{"matches":[
+		{"id":1,"utcDate":"2024-03-02T15:00:00Z","competition":{"code":"PL"},"season":{"startDate":"2023-08-11"}},
+		{"id":2,"utcDate":"2025-09-13T15:00:00Z","competition":{"code":"PL"},"season":{"startDate":"2025-08-15"}}
+	]}`)
+	s, err := New(Options{FixturesDir: dir})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	startDate := func(body map[string]interface{}) string {
+		season, _ := body["season"].(map[string]interface{})
+		date, _ := season["startDate"].(string)
+		return date
+	}
+	if status, body := get(t, s, "/v4/competitions/PL/standings"); status != http.StatusOK || startDate(body) != "2025-08-15" {
+		t.Errorf("current standings = %d, season %q; want the current recording", status, startDate(body))
+	}
+	if status, body := get(t, s, "/v4/competitions/PL/standings?season=2023"); status != http.StatusOK || startDate(body) != "2023-08-11" {
+		t.Errorf("2023 standings = %d, season %q; want the 2023 recording", status, startDate(body))
+	}
+	// Unrecorded seasons aren't served from the current season's recording
+	if status, _ := get(t, s, "/v4/competitions/PL/standings?season=2022"); status != http.StatusNotFound {
+		t.Errorf("unrecorded 2022 standings = %d, want 404", status)
+	}
+
+	status, body := get(t, s, "/v4/competitions/PL/matches?season=2023")
+	matches, _ := body["matches"].([]interface{})
+	if status != http.StatusOK || len(matches) != 1 || matches[0].(map[string]interface{})["id"].(float64) != 1 {
+		t.Errorf("2023 matches = %d, %v; want match 1 only", status, matches)
+	}
+}
@@ -2,9 +2,13 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 	"libero-backend/internal/service" // Import service package
+
+	"github.com/gorilla/mux"
 )
 
 // userContextKey is the key used to store user information in the request context
@@ -26,14 +30,12 @@ func AuthMiddleware(authService service.AuthService) func(next http.Handler) htt
 			}
 
 			// Check if it's a Bearer token
-			parts := strings.Split(authHeader, " ")
-			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+			tokenString, ok := BearerToken(authHeader)
+			if !ok {
 				http.Error(w, "Authorization header format must be Bearer {token}", http.StatusUnauthorized)
 				return
 			}
 
-			tokenString := parts[1]
-
 			// Validate token using AuthService
 			claims, err := authService.ValidateJWTToken(tokenString)
 			if err != nil {
@@ -51,6 +53,45 @@ func AuthMiddleware(authService service.AuthService) func(next http.Handler) htt
 	}
 }
 
+// OptionalAuthMiddleware creates a middleware for public routes that personalize their response
+// for authenticated users. Requests without an Authorization header pass through anonymously,
+// while a malformed or invalid token is still rejected so clients know to refresh it.
+func OptionalAuthMiddleware(authService service.AuthService) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			tokenString, ok := BearerToken(authHeader)
+			if !ok {
+				http.Error(w, "Authorization header format must be Bearer {token}", http.StatusUnauthorized)
+				return
+			}
+
+			claims, err := authService.ValidateJWTToken(tokenString)
+			if err != nil {
+				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), UserKey, claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// BearerToken extracts the token from an "Authorization: Bearer {token}" header value
+func BearerToken(authHeader string) (string, bool) {
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return "", false
+	}
+	return parts[1], true
+}
+
 // RoleMiddleware creates a middleware to check user roles
 func RoleMiddleware(allowedRoles ...string) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -64,25 +105,66 @@ func RoleMiddleware(allowedRoles ...string) func(next http.Handler) http.Handler
 			}
 
 			// Check if user has one of the allowed roles
-			hasRole := false
-			for _, role := range allowedRoles {
-				if claims.Role == role {
-					hasRole = true
-					break
+			if !hasRole(claims.Role, allowedRoles) {
+				http.Error(w, "Forbidden: Insufficient permissions", http.StatusForbidden)
+				return
+			}
+
+			// Call the next handler
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// LeagueRoleMiddleware creates a middleware to check the user's role within the league
+// identified by the {id} route variable. Roles are league-scoped, so a user can administer
+// one league while being a regular member of another.
+func LeagueRoleMiddleware(leagueService service.LeagueService, allowedRoles ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := r.Context().Value(UserKey).(*service.JWTClaims)
+			if !ok {
+				http.Error(w, "Unauthorized: User claims not found in context", http.StatusUnauthorized)
+				return
+			}
+
+			leagueID, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+			if err != nil {
+				http.Error(w, "Invalid league ID", http.StatusBadRequest)
+				return
+			}
+
+			role, err := leagueService.GetMemberRole(uint(leagueID), claims.UserID)
+			if err != nil {
+				if errors.Is(err, service.ErrNotLeagueMember) {
+					// Don't reveal whether a league exists to non-members
+					http.Error(w, "League not found", http.StatusNotFound)
+				} else {
+					http.Error(w, "Failed to check league membership", http.StatusInternalServerError)
 				}
+				return
 			}
 
-			if !hasRole {
+			if !hasRole(role, allowedRoles) {
 				http.Error(w, "Forbidden: Insufficient permissions", http.StatusForbidden)
 				return
 			}
 
-			// Call the next handler
 			next.ServeHTTP(w, r)
 		})
 	}
 }
 
+// hasRole reports whether role is one of the allowed roles
+func hasRole(role string, allowedRoles []string) bool {
+	for _, allowed := range allowedRoles {
+		if role == allowed {
+			return true
+		}
+	}
+	return false
+}
+
 // GetUserFromContext extracts user claims (*service.JWTClaims) from the request context
 func GetUserFromContext(ctx context.Context) (*service.JWTClaims, bool) { // Return service.JWTClaims
 	claims, ok := ctx.Value(UserKey).(*service.JWTClaims) // Use service.JWTClaims
@@ -0,0 +1,24 @@
+package middleware
+
+import "testing"
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+		wantOK bool
+	}{
+		{header: "Bearer abc.def", want: "abc.def", wantOK: true},
+		{header: "bearer abc.def", want: "abc.def", wantOK: true},
+		{header: "abc.def"},
+		{header: "Basic dXNlcjpwYXNz"},
+		{header: "Bearer abc def"},
+		{header: "Bearer"},
+	}
+	for _, tt := range tests {
+		got, ok := BearerToken(tt.header)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("BearerToken(%q) = %q, %v; want %q, %v", tt.header, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -5,31 +5,32 @@ import (
 	"net/http"
 )
 
+// allowedOrigins are the frontend origins allowed to make credentialed requests
+var allowedOrigins = []string{
+	"http://localhost:5173", // Vite dev server
+	"http://localhost:3000", // Alternative frontend port
+	"http://localhost:8080", // Backend port (for debugging)
+	"http://127.0.0.1:5173",
+	"http://127.0.0.1:3000",
+}
+
+// IsOriginAllowed reports whether the origin is one of the allowed frontend origins
+func IsOriginAllowed(origin string) bool {
+	for _, allowedOrigin := range allowedOrigins {
+		if origin == allowedOrigin {
+			return true
+		}
+	}
+	return false
+}
+
 // CORSMiddleware adds CORS headers to responses with proper configuration
 func CORSMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		origin := r.Header.Get("Origin")
 
-		// Define allowed origins
-		allowedOrigins := []string{
-			"http://localhost:5173", // Vite dev server
-			"http://localhost:3000", // Alternative frontend port
-			"http://localhost:8080", // Backend port (for debugging)
-			"http://127.0.0.1:5173",
-			"http://127.0.0.1:3000",
-		}
-
-		// Check if the origin is allowed
-		originAllowed := false
-		for _, allowedOrigin := range allowedOrigins {
-			if origin == allowedOrigin {
-				originAllowed = true
-				break
-			}
-		}
-
 		// Set the appropriate CORS headers
-		if originAllowed {
+		if IsOriginAllowed(origin) {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 		} else {
@@ -42,7 +43,10 @@ func CORSMiddleware(next http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 
 		// Allow all headers the client might send
-		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, Accept, X-Requested-With, Cache-Control, Origin")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, Accept, X-Requested-With, Cache-Control, Origin, If-None-Match, If-Modified-Since")
+
+		// Let clients read the validators they send back in conditional requests, and the data flags
+		w.Header().Set("Access-Control-Expose-Headers", "ETag, Last-Modified, Age, X-Data-Stale, X-Demo-Data")
 
 		// Set max age for preflight requests
 		w.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
@@ -0,0 +1,11 @@
+package middleware
+
+import "net/http"
+
+// DemoDataMiddleware flags every response as demo data, so clients can tell generated data from real data
+func DemoDataMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Demo-Data", "true")
+		next.ServeHTTP(w, r)
+	})
+}
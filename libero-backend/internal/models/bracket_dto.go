@@ -0,0 +1,42 @@
+package models
+
+// BracketDTO represents a cup competition's knockout bracket, alongside the tables of its league
+// or group phase
+type BracketDTO struct {
+	CompetitionName string              `json:"competition_name"`
+	CompetitionCode string              `json:"competition_code"`
+	Season          int                 `json:"season"`
+	Groups          []StandingsGroupDTO `json:"groups"`
+	Rounds          []BracketRoundDTO   `json:"rounds"`         // In the order they are played
+	Demo            bool                `json:"demo,omitempty"` // Set when the data is generated demo data
+}
+
+// BracketRoundDTO represents a knockout round. Ties are in bracket order, so neighbouring ties
+// feed the same tie of the next round.
+type BracketRoundDTO struct {
+	Stage string          `json:"stage"` // e.g. LAST_16, QUARTER_FINALS, FINAL
+	Ties  []BracketTieDTO `json:"ties"`
+}
+
+// BracketTieDTO represents a knockout tie over one or two legs. The home team is the one hosting
+// the first leg, and every score pair counts it first.
+type BracketTieDTO struct {
+	ID        string            `json:"id"` // e.g. QUARTER_FINALS-1
+	HomeTeam  BracketTeamDTO    `json:"home_team"`
+	AwayTeam  BracketTeamDTO    `json:"away_team"`
+	Legs      []FixtureMatchDTO `json:"legs"`
+	Aggregate *ScoreDTO         `json:"aggregate,omitempty"`  // Goals scored in play over every leg
+	AwayGoals *ScoreDTO         `json:"away_goals,omitempty"` // Goals scored away, for two-legged ties
+	ExtraTime bool              `json:"extra_time"`
+	Penalties *ScoreDTO         `json:"penalties,omitempty"`
+	Winner    string            `json:"winner,omitempty"`     // HOME_TEAM or AWAY_TEAM once decided
+	DecidedBy string            `json:"decided_by,omitempty"` // AGGREGATE, AWAY_GOALS, EXTRA_TIME or PENALTIES
+	NextTie   string            `json:"next_tie,omitempty"`   // ID of the tie the winner goes on to
+}
+
+// BracketTeamDTO identifies a team of a tie. Teams that aren't known yet have no ID and are named TBD.
+type BracketTeamDTO struct {
+	ID    int    `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Crest string `json:"crest,omitempty"`
+}
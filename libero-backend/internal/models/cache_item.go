@@ -10,6 +10,16 @@ type CacheItem struct {
 	Value        []byte    `gorm:"type:bytea"`
 	ETag         string    `gorm:"index"` // For tracking data version
 	ExpiresAt    time.Time `gorm:"index"`
-	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"` // When the current value was stored; every write moves it
 	LastModified time.Time // Track when the actual data was last modified
 }
+
+// CacheMetrics counts how a cache namespace is used
+type CacheMetrics struct {
+	Hits      uint64 `json:"hits"`
+	StaleHits uint64 `json:"staleHits"` // Stale entries served while they were refreshed
+	Misses    uint64 `json:"misses"`
+	Writes    uint64 `json:"writes"`
+	Refreshes uint64 `json:"refreshes"` // Background refreshes of stale entries
+	Errors    uint64 `json:"errors"`
+}
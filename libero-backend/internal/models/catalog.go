@@ -0,0 +1,125 @@
+package models
+
+import "time"
+
+// CatalogFilter narrows down catalogue searches. Zero values are ignored, and each
+// field only applies to the entities it makes sense for.
+type CatalogFilter struct {
+	Query       string // Prefix search on names
+	Country     string
+	Sport       string
+	Competition string // Competition code, e.g. PL (teams and players)
+	TeamID      uint   // Players only
+	Position    string // Players only
+}
+
+// --- DTOs for Catalogue API ---
+
+// CompetitionSummary defines the catalogue entry of a competition.
+// Followed is only set when the caller is authenticated.
+type CompetitionSummary struct {
+	ID              uint       `json:"id"`
+	Name            string     `json:"name"`
+	Code            string     `json:"code,omitempty"`
+	Type            string     `json:"type,omitempty"`
+	Country         string     `json:"country,omitempty"`
+	LogoURL         string     `json:"logo_url,omitempty"`
+	Sport           string     `json:"sport,omitempty"`
+	Season          string     `json:"season,omitempty"`
+	SeasonStart     *time.Time `json:"season_start,omitempty"`
+	SeasonEnd       *time.Time `json:"season_end,omitempty"`
+	CurrentMatchday *int       `json:"current_matchday,omitempty"`
+	Followed        *bool      `json:"followed,omitempty"`
+}
+
+// TeamSummary defines the catalogue entry of a team
+type TeamSummary struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	ShortName string `json:"short_name,omitempty"`
+	TLA       string `json:"tla,omitempty"`
+	LogoURL   string `json:"logo_url,omitempty"`
+	Country   string `json:"country,omitempty"`
+	Venue     string `json:"venue,omitempty"`
+	Founded   *int   `json:"founded,omitempty"`
+	Sport     string `json:"sport,omitempty"`
+	Followed  *bool  `json:"followed,omitempty"`
+}
+
+// PlayerSummary defines the catalogue entry of a player
+type PlayerSummary struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Position    string     `json:"position,omitempty"`
+	TeamID      uint       `json:"team_id,omitempty"`
+	Country     string     `json:"country,omitempty"`
+	PhotoURL    string     `json:"photo_url,omitempty"`
+	DateOfBirth *time.Time `json:"date_of_birth,omitempty"`
+	ShirtNumber *int       `json:"shirt_number,omitempty"`
+	Followed    *bool      `json:"followed,omitempty"`
+}
+
+// CompetitionDetail defines the detail view of a competition with its teams
+type CompetitionDetail struct {
+	CompetitionSummary
+	Teams []TeamSummary `json:"teams"`
+}
+
+// TeamDetail defines the detail view of a team with its competitions and squad
+type TeamDetail struct {
+	TeamSummary
+	Competitions []CompetitionSummary `json:"competitions"`
+	Squad        []PlayerSummary      `json:"squad"`
+}
+
+// PlayerDetail defines the detail view of a player with their team
+type PlayerDetail struct {
+	PlayerSummary
+	Team *TeamSummary `json:"team,omitempty"`
+}
+
+// ToSummary converts Competition to CompetitionSummary
+func (c *Competition) ToSummary() CompetitionSummary {
+	return CompetitionSummary{
+		ID:              c.ID,
+		Name:            c.Name,
+		Code:            c.Code,
+		Type:            c.Type,
+		Country:         c.Country,
+		LogoURL:         c.LogoURL,
+		Sport:           c.Sport,
+		Season:          c.Season,
+		SeasonStart:     c.SeasonStart,
+		SeasonEnd:       c.SeasonEnd,
+		CurrentMatchday: c.CurrentMatchday,
+	}
+}
+
+// ToSummary converts Team to TeamSummary
+func (t *Team) ToSummary() TeamSummary {
+	return TeamSummary{
+		ID:        t.ID,
+		Name:      t.Name,
+		ShortName: t.ShortName,
+		TLA:       t.TLA,
+		LogoURL:   t.LogoURL,
+		Country:   t.Country,
+		Venue:     t.Venue,
+		Founded:   t.Founded,
+		Sport:     t.Sport,
+	}
+}
+
+// ToSummary converts Player to PlayerSummary
+func (p *Player) ToSummary() PlayerSummary {
+	return PlayerSummary{
+		ID:          p.ID,
+		Name:        p.Name,
+		Position:    p.Position,
+		TeamID:      p.TeamID,
+		Country:     p.Country,
+		PhotoURL:    p.PhotoURL,
+		DateOfBirth: p.DateOfBirth,
+		ShirtNumber: p.ShirtNumber,
+	}
+}
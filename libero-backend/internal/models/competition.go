@@ -4,13 +4,21 @@ import "time"
 
 // Competition represents a sports competition or league
 type Competition struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	Name      string    `gorm:"not null" json:"name"`
-	Code      string    `gorm:"uniqueIndex" json:"code,omitempty"`
-	Country   string    `json:"country,omitempty"`
-	LogoURL   string    `json:"logo_url,omitempty"`
-	Sport     string    `json:"sport,omitempty"`
-	Season    string    `json:"season,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	ProviderID      *int       `gorm:"uniqueIndex" json:"provider_id,omitempty"` // football-data competition ID
+	Name            string     `gorm:"not null" json:"name"`
+	Code            string     `gorm:"uniqueIndex" json:"code,omitempty"`
+	Type            string     `json:"type,omitempty"` // LEAGUE or CUP
+	Country         string     `json:"country,omitempty"`
+	LogoURL         string     `json:"logo_url,omitempty"`
+	Sport           string     `json:"sport,omitempty"`
+	Season          string     `json:"season,omitempty"`
+	SeasonStart     *time.Time `json:"season_start,omitempty"`
+	SeasonEnd       *time.Time `json:"season_end,omitempty"`
+	CurrentMatchday *int       `json:"current_matchday,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Relationships
+	Teams []*Team `gorm:"many2many:competition_teams;" json:"-"` // Teams taking part in the current season
 }
\ No newline at end of file
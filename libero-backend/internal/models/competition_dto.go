@@ -2,61 +2,93 @@ package models
 
 // StandingsResponse represents the league standings data
 type StandingsResponse struct {
-	Competition struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
-	} `json:"competition"`
-	Season struct {
-		ID      int  `json:"id"`
-		Current bool `json:"current"`
-	} `json:"season"`
-	Standings []struct {
-		Stage string `json:"stage"`
-		Table []struct {
-			Position       int          `json:"position"`
-			Team           TeamResponse `json:"team"`
-			PlayedGames    int          `json:"playedGames"`
-			Won            int          `json:"won"`
-			Draw           int          `json:"draw"`
-			Lost           int          `json:"lost"`
-			Points         int          `json:"points"`
-			GoalsFor       int          `json:"goalsFor"`
-			GoalsAgainst   int          `json:"goalsAgainst"`
-			GoalDifference int          `json:"goalDifference"`
-		} `json:"table"`
-	} `json:"standings"`
+	Competition CompetitionRefResponse   `json:"competition"`
+	Season      SeasonRefResponse        `json:"season"`
+	Standings   []StandingsGroupResponse `json:"standings"`
+}
+
+// CompetitionRefResponse identifies the competition a response belongs to
+type CompetitionRefResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// SeasonRefResponse identifies the season a response belongs to
+type SeasonRefResponse struct {
+	ID      int  `json:"id"`
+	Current bool `json:"current"`
+}
+
+// StandingsGroupResponse represents a single table of a competition's standings
+type StandingsGroupResponse struct {
+	Stage string                 `json:"stage"`
+	Type  string                 `json:"type"`  // TOTAL, HOME or AWAY; empty means TOTAL
+	Group string                 `json:"group"` // e.g. GROUP_A, empty outside group stages
+	Table []StandingsRowResponse `json:"table"`
+}
+
+// StandingsRowResponse represents a team's row in a standings table
+type StandingsRowResponse struct {
+	Position       int          `json:"position"`
+	Team           TeamResponse `json:"team"`
+	PlayedGames    int          `json:"playedGames"`
+	Won            int          `json:"won"`
+	Draw           int          `json:"draw"`
+	Lost           int          `json:"lost"`
+	Points         int          `json:"points"`
+	GoalsFor       int          `json:"goalsFor"`
+	GoalsAgainst   int          `json:"goalsAgainst"`
+	GoalDifference int          `json:"goalDifference"`
+	Form           string       `json:"form"` // e.g. "W,D,L,W,W", if the provider has it
 }
 
 // ScorersResponse represents the top scorers data
 type ScorersResponse struct {
-	Competition struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
-	} `json:"competition"`
-	Season struct {
-		ID      int  `json:"id"`
-		Current bool `json:"current"`
-	} `json:"season"`
-	Scorers []struct {
-		Player    PlayerResponse `json:"player"`
-		Team      TeamResponse   `json:"team"`
-		Goals     int            `json:"goals"`
-		Assists   int            `json:"assists"`
-		Penalties int            `json:"penalties"`
-	} `json:"scorers"`
+	Competition CompetitionRefResponse `json:"competition"`
+	Season      SeasonRefResponse      `json:"season"`
+	Scorers     []ScorerResponse       `json:"scorers"`
 }
 
-// CompetitionStandingsDTO represents formatted standings data for a competition
+// ScorerResponse represents a player's entry in the top scorers data
+type ScorerResponse struct {
+	Player    PlayerResponse `json:"player"`
+	Team      TeamResponse   `json:"team"`
+	Goals     int            `json:"goals"`
+	Assists   int            `json:"assists"`
+	Penalties int            `json:"penalties"`
+}
+
+// Standings table types, as football-data.org names them
+const (
+	StandingsTypeTotal = "TOTAL"
+	StandingsTypeHome  = "HOME"
+	StandingsTypeAway  = "AWAY"
+)
+
+// CompetitionStandingsDTO represents formatted standings data for a competition. Standings is the
+// main table, i.e. the regular season's or else the first group's, and Groups holds every table
+// of the type.
 type CompetitionStandingsDTO struct {
 	CompetitionName string              `json:"competition_name"`
 	CompetitionCode string              `json:"competition_code"`
 	Season          int                 `json:"season"`
+	Type            string              `json:"type"` // TOTAL, HOME or AWAY
 	Standings       []StandingsTableDTO `json:"standings"`
+	Groups          []StandingsGroupDTO `json:"groups"`
+	Demo            bool                `json:"demo,omitempty"` // Set when the data is generated demo data
+}
+
+// StandingsGroupDTO represents one table of a competition's standings, e.g. a group's
+type StandingsGroupDTO struct {
+	Stage string              `json:"stage"`           // e.g. REGULAR_SEASON, LEAGUE_STAGE, GROUP_STAGE
+	Group string              `json:"group,omitempty"` // e.g. GROUP_A
+	Table []StandingsTableDTO `json:"table"`
 }
 
 // StandingsTableDTO represents a single standings table entry
 type StandingsTableDTO struct {
 	Position       int    `json:"position"`
+	TeamID         int    `json:"team_id,omitempty"` // Provider team ID
 	TeamName       string `json:"team_name"`
 	TeamCrest      string `json:"team_crest"`
 	PlayedGames    int    `json:"played"`
@@ -67,6 +99,7 @@ type StandingsTableDTO struct {
 	GoalsAgainst   int    `json:"goals_against"`
 	GoalDifference int    `json:"goal_difference"`
 	Points         int    `json:"points"`
+	Form           string `json:"form,omitempty"` // Last five results of the table's matches, most recent last, e.g. "WWDLW"
 }
 
 // CompetitionScorersDTO represents formatted top scorers data for a competition
@@ -75,10 +108,33 @@ type CompetitionScorersDTO struct {
 	CompetitionCode string           `json:"competition_code"`
 	Season          int              `json:"season"`
 	Scorers         []ScorerStatsDTO `json:"scorers"`
+	Demo            bool             `json:"demo,omitempty"` // Set when the data is generated demo data
+}
+
+// CompetitionSeasonsDTO lists a competition's seasons, most recent first
+type CompetitionSeasonsDTO struct {
+	CompetitionCode string      `json:"competition_code"`
+	Seasons         []SeasonDTO `json:"seasons"`
+	Demo            bool        `json:"demo,omitempty"` // Set when the data is generated demo data
+}
+
+// SeasonDTO represents one season of a competition. Year is what the standings and top scorers
+// endpoints take as their season parameter.
+type SeasonDTO struct {
+	ID          int    `json:"id"`   // Provider season ID, as in standings and top scorers
+	Year        int    `json:"year"` // Year the season starts in
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date"`
+	Current     bool   `json:"current"`
+	Completed   bool   `json:"completed"`
+	WinnerID    int    `json:"winner_id,omitempty"` // Provider team ID
+	WinnerName  string `json:"winner_name,omitempty"`
+	WinnerCrest string `json:"winner_crest,omitempty"`
 }
 
 // ScorerStatsDTO represents stats for a single scorer
 type ScorerStatsDTO struct {
+	PlayerID   int    `json:"player_id,omitempty"` // Provider person ID
 	PlayerName string `json:"player_name"`
 	TeamName   string `json:"team_name"`
 	TeamCrest  string `json:"team_crest"`
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// UserFeed is the personalized feed built from what a user follows
+type UserFeed struct {
+	GeneratedAt       time.Time                   `json:"generated_at"`
+	Fixtures          []Match                     `json:"fixtures"`           // Upcoming and live matches of followed teams
+	Standings         []FeedStandingsSnippet      `json:"standings"`          // Snippets for followed competitions
+	Scorers           []FeedScorerPosition        `json:"scorers"`            // Scorer-table positions of followed players
+	RecentPredictions []PredictionHistoryResponse `json:"recent_predictions"` // Latest settled predictions
+}
+
+// FeedStandingsSnippet is the top of a competition's table plus the rows of followed teams
+type FeedStandingsSnippet struct {
+	CompetitionID   uint                `json:"competition_id"`
+	CompetitionCode string              `json:"competition_code"`
+	CompetitionName string              `json:"competition_name"`
+	Rows            []StandingsTableDTO `json:"rows"`
+}
+
+// FeedScorerPosition is a followed player's position on a competition's scorer table
+type FeedScorerPosition struct {
+	PlayerID        uint   `json:"player_id"`
+	PlayerName      string `json:"player_name"`
+	TeamName        string `json:"team_name"`
+	CompetitionCode string `json:"competition_code"`
+	Position        int    `json:"position"`
+	Goals           int    `json:"goals"`
+	Assists         int    `json:"assists"`
+}
@@ -3,16 +3,44 @@ package models
 import "time"
 
 // FixtureMatchDTO represents a single match within a competition's fixtures.
+// HomeScore and AwayScore are the goals scored in play; a penalty shootout is reported
+// separately in Penalties.
 type FixtureMatchDTO struct {
-	MatchDate    time.Time `json:"match_date"`
-	HomeTeamName string    `json:"home_team_name"`
-	AwayTeamName string    `json:"away_team_name"`
-	HomeScore    *int      `json:"home_score,omitempty"`
-	AwayScore    *int      `json:"away_score,omitempty"`
-	MatchStatus  string    `json:"match_status"`
-	Venue        string    `json:"venue,omitempty"`
-	HomeLogoURL  string    `json:"home_logo_url,omitempty"`
-	AwayLogoURL  string    `json:"away_logo_url,omitempty"`
+	MatchID      int          `json:"match_id,omitempty"`
+	MatchDate    time.Time    `json:"match_date"`
+	HomeTeamID   int          `json:"home_team_id,omitempty"` // Provider team ID, unset while the team is TBD
+	HomeTeamName string       `json:"home_team_name"`
+	AwayTeamID   int          `json:"away_team_id,omitempty"` // Provider team ID, unset while the team is TBD
+	AwayTeamName string       `json:"away_team_name"`
+	HomeScore    *int         `json:"home_score,omitempty"`
+	AwayScore    *int         `json:"away_score,omitempty"`
+	MatchStatus  string       `json:"match_status"`
+	Venue        string       `json:"venue,omitempty"`
+	HomeLogoURL  string       `json:"home_logo_url,omitempty"`
+	AwayLogoURL  string       `json:"away_logo_url,omitempty"`
+	Matchday     *int         `json:"matchday,omitempty"`
+	Stage        string       `json:"stage,omitempty"`    // e.g. REGULAR_SEASON, QUARTER_FINALS
+	Group        string       `json:"group,omitempty"`    // e.g. GROUP_A
+	Winner       string       `json:"winner,omitempty"`   // HOME_TEAM, AWAY_TEAM or DRAW
+	Duration     string       `json:"duration,omitempty"` // REGULAR, EXTRA_TIME or PENALTY_SHOOTOUT
+	HalfTime     *ScoreDTO    `json:"half_time,omitempty"`
+	FullTime     *ScoreDTO    `json:"full_time,omitempty"` // After 90 minutes
+	ExtraTime    *ScoreDTO    `json:"extra_time,omitempty"`
+	Penalties    *ScoreDTO    `json:"penalties,omitempty"`
+	Referees     []RefereeDTO `json:"referees,omitempty"`
+}
+
+// ScoreDTO is a home/away score pair of a period that has been played.
+type ScoreDTO struct {
+	Home int `json:"home"`
+	Away int `json:"away"`
+}
+
+// RefereeDTO represents a match official.
+type RefereeDTO struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"` // e.g. REFEREE, ASSISTANT_REFEREE_N1
+	Nationality string `json:"nationality,omitempty"`
 }
 
 // CompetitionFixturesDTO groups fixtures by competition.
@@ -31,4 +59,5 @@ type FixturesSummaryDTO struct {
 	Today           []FixtureMatchDTO `json:"today"`
 	Tomorrow        []FixtureMatchDTO `json:"tomorrow"`
 	Upcoming        []FixtureMatchDTO `json:"upcoming"`
+	Demo            bool              `json:"demo,omitempty"` // Set when the data is generated demo data
 }
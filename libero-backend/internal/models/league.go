@@ -0,0 +1,133 @@
+package models
+
+import "time"
+
+// League-scoped member roles
+const (
+	LeagueRoleAdmin  = "admin"
+	LeagueRoleMember = "member"
+)
+
+// League represents a private prediction league that users join with an invite code
+type League struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	Name            string    `gorm:"not null" json:"name"`
+	Description     string    `json:"description,omitempty"`
+	InviteCode      string    `gorm:"uniqueIndex;not null" json:"invite_code"`
+	OwnerID         uint      `gorm:"not null;index" json:"owner_id"`
+	Season          int       `gorm:"not null;default:1" json:"season"`
+	SeasonStartedAt time.Time `gorm:"not null" json:"season_started_at"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	// Relationships
+	Owner   User           `gorm:"foreignKey:OwnerID" json:"-"`
+	Members []LeagueMember `gorm:"foreignKey:LeagueID;constraint:OnDelete:CASCADE" json:"members,omitempty"`
+}
+
+// LeagueMember represents a user's membership and role within a league
+type LeagueMember struct {
+	ID       uint      `gorm:"primaryKey" json:"id"`
+	LeagueID uint      `gorm:"not null;uniqueIndex:idx_league_members_league_user" json:"league_id"`
+	UserID   uint      `gorm:"not null;uniqueIndex:idx_league_members_league_user;index" json:"user_id"`
+	Role     string    `gorm:"not null;default:member" json:"role"`
+	JoinedAt time.Time `gorm:"autoCreateTime" json:"joined_at"`
+
+	// Relationship
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// LeagueRemoval records that an admin removed a user from a league, which keeps them from
+// rejoining it with the invite code
+type LeagueRemoval struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	LeagueID  uint      `gorm:"not null;uniqueIndex:idx_league_removals_league_user" json:"league_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_league_removals_league_user" json:"user_id"`
+	RemovedAt time.Time `gorm:"autoCreateTime" json:"removed_at"`
+}
+
+// LeagueSeasonStanding is a member's final standing in an archived league season
+type LeagueSeasonStanding struct {
+	ID              uint      `gorm:"primaryKey" json:"-"`
+	LeagueID        uint      `gorm:"not null;uniqueIndex:idx_league_season_standings_league_season_user" json:"-"`
+	Season          int       `gorm:"not null;uniqueIndex:idx_league_season_standings_league_season_user" json:"-"`
+	SeasonStartedAt time.Time `gorm:"not null" json:"-"`
+	SeasonEndedAt   time.Time `gorm:"not null" json:"-"`
+	Rank            int       `json:"rank"`
+	UserID          uint      `gorm:"not null;uniqueIndex:idx_league_season_standings_league_season_user" json:"userId"`
+	Username        string    `json:"username"`
+	Name            string    `json:"name,omitempty"`
+	Points          int       `json:"points"`
+	Picks           int       `json:"picks"`
+	ExactScores     int       `json:"exactScores"`
+	CorrectOutcomes int       `json:"correctOutcomes"`
+}
+
+// --- DTOs for Leagues API ---
+
+// CreateLeagueRequest defines the request body for creating a league
+type CreateLeagueRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// JoinLeagueRequest defines the request body for joining a league
+type JoinLeagueRequest struct {
+	InviteCode string `json:"invite_code"`
+}
+
+// LeagueMemberResponse defines the response format for a league member
+type LeagueMemberResponse struct {
+	UserID   uint      `json:"user_id"`
+	Username string    `json:"username"`
+	Name     string    `json:"name,omitempty"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// LeagueResponse defines the response format for a league
+type LeagueResponse struct {
+	ID              uint                   `json:"id"`
+	Name            string                 `json:"name"`
+	Description     string                 `json:"description,omitempty"`
+	InviteCode      string                 `json:"invite_code"`
+	OwnerID         uint                   `json:"owner_id"`
+	Season          int                    `json:"season"`
+	SeasonStartedAt time.Time              `json:"season_started_at"`
+	Members         []LeagueMemberResponse `json:"members,omitempty"`
+	CreatedAt       time.Time              `json:"created_at"`
+}
+
+// ToResponse converts League to LeagueResponse, including members when they are loaded
+func (l *League) ToResponse() LeagueResponse {
+	response := LeagueResponse{
+		ID:              l.ID,
+		Name:            l.Name,
+		Description:     l.Description,
+		InviteCode:      l.InviteCode,
+		OwnerID:         l.OwnerID,
+		Season:          l.Season,
+		SeasonStartedAt: l.SeasonStartedAt,
+		CreatedAt:       l.CreatedAt,
+	}
+	for _, m := range l.Members {
+		response.Members = append(response.Members, LeagueMemberResponse{
+			UserID:   m.UserID,
+			Username: m.User.Username,
+			Name:     m.User.Name,
+			Role:     m.Role,
+			JoinedAt: m.JoinedAt,
+		})
+	}
+	return response
+}
+
+// LeagueStandingsResponse defines the standings table of a league season
+type LeagueStandingsResponse struct {
+	LeagueID  uint               `json:"league_id"`
+	Season    int                `json:"season"`
+	From      time.Time          `json:"from"`
+	To        *time.Time         `json:"to,omitempty"` // nil for the current season
+	Archived  bool               `json:"archived"`
+	Standings []LeaderboardEntry `json:"standings"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Live event types published while matches are polled
+const (
+	LiveEventGoal            = "goal"
+	LiveEventScoreCorrection = "score_correction"
+	LiveEventStatusChange    = "status_change"
+	LiveEventFullTime        = "full_time"
+)
+
+// LiveEvent describes a change detected between two snapshots of a match
+type LiveEvent struct {
+	ID              uint64    `json:"id"`
+	Type            string    `json:"type"`
+	MatchID         int       `json:"match_id"` // Provider match ID
+	CompetitionCode string    `json:"competition_code"`
+	HomeTeamID      int       `json:"home_team_id"`
+	HomeTeam        string    `json:"home_team"`
+	AwayTeamID      int       `json:"away_team_id"`
+	AwayTeam        string    `json:"away_team"`
+	Status          string    `json:"status"`
+	PreviousStatus  string    `json:"previous_status,omitempty"`
+	HomeScore       *int      `json:"home_score"`
+	AwayScore       *int      `json:"away_score"`
+	ScoringSide     string    `json:"scoring_side,omitempty"` // HOME or AWAY for goals
+	OccurredAt      time.Time `json:"occurred_at"`
+}
+
+// LiveEventFilter limits a live event subscription. Zero values match everything.
+type LiveEventFilter struct {
+	Competition string // Competition code, e.g. PL
+	Team        string // Provider team ID or (partial) team name
+}
+
+// Matches reports whether the event passes the filter
+func (f LiveEventFilter) Matches(e LiveEvent) bool {
+	if f.Competition != "" && !strings.EqualFold(f.Competition, e.CompetitionCode) {
+		return false
+	}
+	if f.Team == "" {
+		return true
+	}
+	if teamID, err := strconv.Atoi(f.Team); err == nil {
+		return e.HomeTeamID == teamID || e.AwayTeamID == teamID
+	}
+	team := strings.ToLower(f.Team)
+	return strings.Contains(strings.ToLower(e.HomeTeam), team) || strings.Contains(strings.ToLower(e.AwayTeam), team)
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Match represents a fixture stored from the data provider, keyed by the provider's match ID
+type Match struct {
+	ID                uint      `gorm:"primaryKey" json:"id"`
+	ProviderID        int       `gorm:"uniqueIndex;not null" json:"provider_id"`
+	CompetitionCode   string    `gorm:"index" json:"competition_code"`
+	CompetitionName   string    `json:"competition_name"`
+	CompetitionEmblem string    `json:"competition_emblem,omitempty"`
+	Matchday          *int      `json:"matchday,omitempty"`
+	Stage             string    `gorm:"index" json:"stage,omitempty"` // e.g. REGULAR_SEASON, QUARTER_FINALS
+	GroupName         string    `json:"group,omitempty"`              // e.g. GROUP_A
+	KickoffAt         time.Time `gorm:"not null;index" json:"kickoff_at"`
+	Status            string    `gorm:"index" json:"status"`
+	Venue             string    `json:"venue,omitempty"`
+	HomeTeamID        int       `gorm:"index" json:"home_team_id,omitempty"` // Provider team ID
+	HomeTeamName      string    `json:"home_team_name"`
+	HomeTeamCrest     string    `json:"home_team_crest,omitempty"`
+	AwayTeamID        int       `gorm:"index" json:"away_team_id,omitempty"` // Provider team ID
+	AwayTeamName      string    `json:"away_team_name"`
+	AwayTeamCrest     string    `json:"away_team_crest,omitempty"`
+	HomeScore         *int      `json:"home_score,omitempty"` // Goals in play, without a penalty shootout
+	AwayScore         *int      `json:"away_score,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// MatchFilter narrows down stored matches. Zero values are ignored.
+type MatchFilter struct {
+	Competition string     // Competition code, e.g. PL
+	Team        string     // Provider team ID or (partial) team name
+	TeamIDs     []int      // Provider team IDs, any of which may play
+	Status      []string   // Provider statuses, e.g. SCHEDULED, FINISHED
+	From        *time.Time // Kickoff at or after
+	To          *time.Time // Kickoff before
+}
@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// ScoreResponse represents a home/away score pair in the API responses.
+// Values are nil until the corresponding period has been played.
+type ScoreResponse struct {
+	Home *int `json:"home"`
+	Away *int `json:"away"`
+}
+
+// MatchResponse represents a single match in the API responses. Teams that aren't known yet,
+// e.g. in knockout rounds, come with a zero ID and an empty name.
+type MatchResponse struct {
+	ID          int                      `json:"id"`
+	UtcDate     time.Time                `json:"utcDate"`
+	Status      string                   `json:"status"`
+	Matchday    int                      `json:"matchday"`
+	Stage       string                   `json:"stage"` // e.g. REGULAR_SEASON, LEAGUE_STAGE, QUARTER_FINALS
+	Group       string                   `json:"group"` // e.g. GROUP_A, empty outside group stages
+	Venue       string                   `json:"venue"`
+	Competition MatchCompetitionResponse `json:"competition"`
+	HomeTeam    TeamResponse             `json:"homeTeam"`
+	AwayTeam    TeamResponse             `json:"awayTeam"`
+	Score       MatchScoreResponse       `json:"score"`
+	Referees    []RefereeResponse        `json:"referees"`
+}
+
+// RefereeResponse represents a match official
+type RefereeResponse struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"` // e.g. REFEREE, ASSISTANT_REFEREE_N1, VIDEO_ASSISTANT_REFEREE_N1
+	Nationality string `json:"nationality"`
+}
+
+// MatchCompetitionResponse represents the competition a match is played in
+type MatchCompetitionResponse struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Code   string `json:"code"`
+	Emblem string `json:"emblem"`
+}
+
+// MatchScoreResponse represents the scores of a match. As in football-data.org v4, FullTime
+// counts every goal including a penalty shootout's; RegularTime, ExtraTime and Penalties are only
+// set for matches that went beyond 90 minutes, and ExtraTime holds the goals of extra time alone.
+type MatchScoreResponse struct {
+	Winner      string        `json:"winner"`   // HOME_TEAM, AWAY_TEAM or DRAW
+	Duration    string        `json:"duration"` // REGULAR, EXTRA_TIME or PENALTY_SHOOTOUT
+	FullTime    ScoreResponse `json:"fullTime"`
+	HalfTime    ScoreResponse `json:"halfTime"`
+	RegularTime ScoreResponse `json:"regularTime"`
+	ExtraTime   ScoreResponse `json:"extraTime"`
+	Penalties   ScoreResponse `json:"penalties"`
+}
+
+// Result returns the goals scored in play, leaving out a penalty shootout. Values are nil until
+// the match has started.
+func (s MatchScoreResponse) Result() ScoreResponse {
+	if s.Duration != "PENALTY_SHOOTOUT" || s.Penalties.Home == nil || s.Penalties.Away == nil ||
+		s.FullTime.Home == nil || s.FullTime.Away == nil {
+		return s.FullTime
+	}
+	home := *s.FullTime.Home - *s.Penalties.Home
+	away := *s.FullTime.Away - *s.Penalties.Away
+	return ScoreResponse{Home: &home, Away: &away}
+}
+
+// MatchesResponse represents a list of matches in the API responses
+type MatchesResponse struct {
+	Matches []MatchResponse `json:"matches"`
+}
@@ -4,12 +4,15 @@ import "time"
 
 // Player represents a sports player
 type Player struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	Name      string    `gorm:"not null" json:"name"`
-	Position  string    `json:"position,omitempty"`
-	TeamID    uint      `json:"team_id,omitempty"`
-	Country   string    `json:"country,omitempty"`
-	PhotoURL  string    `json:"photo_url,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	ProviderID  *int       `gorm:"uniqueIndex" json:"provider_id,omitempty"` // football-data person ID
+	Name        string     `gorm:"not null" json:"name"`
+	Position    string     `json:"position,omitempty"`
+	TeamID      uint       `gorm:"index" json:"team_id,omitempty"`
+	Country     string     `json:"country,omitempty"`
+	PhotoURL    string     `json:"photo_url,omitempty"`
+	DateOfBirth *time.Time `json:"date_of_birth,omitempty"`
+	ShirtNumber *int       `json:"shirt_number,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
 }
\ No newline at end of file
@@ -23,10 +23,43 @@ type PredictionHistory struct {
 	CreatedAt          time.Time `gorm:"column:created_at" json:"createdAt"`
 	UpdatedAt          time.Time `gorm:"column:updated_at" json:"updatedAt"`
 
+	// Link to the provider match, used to settle the prediction once the match has finished
+	MatchID   *int       `gorm:"index;column:match_id" json:"matchId,omitempty"`
+	KickoffAt *time.Time `gorm:"index;column:kickoff_at" json:"kickoffAt,omitempty"`
+
+	// Settlement results (nil while the prediction is pending)
+	ActualHomeScore *int       `gorm:"column:actual_home_score" json:"actualHomeScore,omitempty"`
+	ActualAwayScore *int       `gorm:"column:actual_away_score" json:"actualAwayScore,omitempty"`
+	OutcomeCorrect  *bool      `gorm:"column:outcome_correct" json:"outcomeCorrect,omitempty"`
+	ExactScore      *bool      `gorm:"column:exact_score" json:"exactScore,omitempty"`
+	BrierScore      *float64   `gorm:"column:brier_score" json:"brierScore,omitempty"`
+	LogLoss         *float64   `gorm:"column:log_loss" json:"logLoss,omitempty"`
+	SettledAt       *time.Time `gorm:"index;column:settled_at" json:"settledAt,omitempty"`
+	VoidedAt        *time.Time `gorm:"index;column:voided_at" json:"voidedAt,omitempty"`            // Set instead when the match was cancelled
+	LastCheckedAt   *time.Time `gorm:"index;column:last_checked_at" json:"lastCheckedAt,omitempty"` // Last settlement attempt
+
 	// Relationship
 	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
 }
 
+// Prediction settlement statuses
+const (
+	PredictionStatusSettled = "settled"
+	PredictionStatusPending = "pending"
+	PredictionStatusVoid    = "void" // The match was cancelled, so there is nothing to settle against
+)
+
+// Status returns whether the prediction has been settled against a final score, or voided
+func (p *PredictionHistory) Status() string {
+	switch {
+	case p.SettledAt != nil:
+		return PredictionStatusSettled
+	case p.VoidedAt != nil:
+		return PredictionStatusVoid
+	}
+	return PredictionStatusPending
+}
+
 // --- DTOs for Prediction History API ---
 
 // CreatePredictionRequest defines the request body for creating a new prediction
@@ -44,6 +77,10 @@ type CreatePredictionRequest struct {
 	AwayWinProbability float64 `json:"awayWinProbability,omitempty" binding:"-"`
 	PredictedResult    string  `json:"predictedResult,omitempty" binding:"-"`
 
+	// Optional link to the provider match so the prediction can be settled
+	MatchID   int        `json:"matchId,omitempty" binding:"-"`
+	KickoffAt *time.Time `json:"kickoffAt,omitempty" binding:"-"`
+
 	// Support snake_case for backward compatibility
 	HomeTeamSnake           string  `json:"home_team,omitempty" binding:"-"`
 	AwayTeamSnake           string  `json:"away_team,omitempty" binding:"-"`
@@ -57,6 +94,9 @@ type CreatePredictionRequest struct {
 	DrawProbabilitySnake    float64 `json:"draw_probability,omitempty" binding:"-"`
 	AwayWinProbabilitySnake float64 `json:"away_win_probability,omitempty" binding:"-"`
 	PredictedResultSnake    string  `json:"predicted_result,omitempty" binding:"-"`
+
+	MatchIDSnake   int        `json:"match_id,omitempty" binding:"-"`
+	KickoffAtSnake *time.Time `json:"kickoff_at,omitempty" binding:"-"`
 }
 
 // Normalize ensures that camelCase fields take precedence over snake_case
@@ -99,6 +139,12 @@ func (r *CreatePredictionRequest) Normalize() {
 	if r.PredictedResult == "" && r.PredictedResultSnake != "" {
 		r.PredictedResult = r.PredictedResultSnake
 	}
+	if r.MatchID == 0 && r.MatchIDSnake != 0 {
+		r.MatchID = r.MatchIDSnake
+	}
+	if r.KickoffAt == nil && r.KickoffAtSnake != nil {
+		r.KickoffAt = r.KickoffAtSnake
+	}
 }
 
 // PredictionHistoryResponse defines the response format for prediction history
@@ -117,6 +163,19 @@ type PredictionHistoryResponse struct {
 	AwayWinProbability float64   `json:"awayWinProbability"`
 	PredictedResult    string    `json:"predictedResult"`
 	CreatedAt          time.Time `json:"createdAt"`
+
+	// Settlement details
+	Status          string     `json:"status"`
+	MatchID         *int       `json:"matchId,omitempty"`
+	KickoffAt       *time.Time `json:"kickoffAt,omitempty"`
+	ActualHomeScore *int       `json:"actualHomeScore,omitempty"`
+	ActualAwayScore *int       `json:"actualAwayScore,omitempty"`
+	OutcomeCorrect  *bool      `json:"outcomeCorrect,omitempty"`
+	ExactScore      *bool      `json:"exactScore,omitempty"`
+	BrierScore      *float64   `json:"brierScore,omitempty"`
+	LogLoss         *float64   `json:"logLoss,omitempty"`
+	SettledAt       *time.Time `json:"settledAt,omitempty"`
+	VoidedAt        *time.Time `json:"voidedAt,omitempty"`
 }
 
 // ToResponse converts PredictionHistory to PredictionHistoryResponse
@@ -136,6 +195,17 @@ func (p *PredictionHistory) ToResponse() PredictionHistoryResponse {
 		AwayWinProbability: p.AwayWinProbability,
 		PredictedResult:    p.PredictedResult,
 		CreatedAt:          p.CreatedAt,
+		Status:             p.Status(),
+		MatchID:            p.MatchID,
+		KickoffAt:          p.KickoffAt,
+		ActualHomeScore:    p.ActualHomeScore,
+		ActualAwayScore:    p.ActualAwayScore,
+		OutcomeCorrect:     p.OutcomeCorrect,
+		ExactScore:         p.ExactScore,
+		BrierScore:         p.BrierScore,
+		LogLoss:            p.LogLoss,
+		SettledAt:          p.SettledAt,
+		VoidedAt:           p.VoidedAt,
 	}
 }
 
@@ -148,4 +218,43 @@ type PredictionStatistics struct {
 	HomeWinPercentage float64 `json:"homeWinPercentage"`
 	DrawPercentage    float64 `json:"drawPercentage"`
 	AwayWinPercentage float64 `json:"awayWinPercentage"`
+
+	// Settlement breakdown
+	Settled            int     `json:"settled"`
+	Pending            int     `json:"pending"`
+	Voided             int     `json:"voided"`
+	CorrectOutcomes    int     `json:"correctOutcomes"`
+	ExactScores        int     `json:"exactScores"`
+	OutcomeAccuracy    float64 `json:"outcomeAccuracy"`
+	ExactScoreAccuracy float64 `json:"exactScoreAccuracy"`
+	AverageBrierScore  float64 `json:"averageBrierScore"`
+	AverageLogLoss     float64 `json:"averageLogLoss"`
+}
+
+// PredictionAccuracyFilter narrows the predictions included in accuracy statistics
+type PredictionAccuracyFilter struct {
+	League string     // Matches either the home or the away league
+	Team   string     // Matches either the home or the away team
+	From   *time.Time // Inclusive lower bound on kickoff (or creation) time
+	To     *time.Time // Exclusive upper bound on kickoff (or creation) time
+}
+
+// CalibrationBucket compares predicted probabilities in a range against the observed frequency
+type CalibrationBucket struct {
+	LowerBound        float64 `json:"lowerBound"`
+	UpperBound        float64 `json:"upperBound"`
+	Count             int     `json:"count"`
+	AveragePredicted  float64 `json:"averagePredicted"`
+	ObservedFrequency float64 `json:"observedFrequency"`
+}
+
+// PredictionAccuracyStatistics represents how well a user's settled predictions performed
+type PredictionAccuracyStatistics struct {
+	Settled                int                 `json:"settled"`
+	HitRate                float64             `json:"hitRate"`
+	ExactScoreRate         float64             `json:"exactScoreRate"`
+	AverageBrierScore      float64             `json:"averageBrierScore"`
+	AverageLogLoss         float64             `json:"averageLogLoss"`
+	RankedProbabilityScore float64             `json:"rankedProbabilityScore"`
+	Calibration            []CalibrationBucket `json:"calibration"`
 }
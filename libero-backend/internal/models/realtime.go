@@ -0,0 +1,69 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Realtime message types sent to WebSocket clients
+const (
+	RealtimeMessageUpdate        = "update"
+	RealtimeMessageSubscribed    = "subscribed"
+	RealtimeMessageUnsubscribed  = "unsubscribed"
+	RealtimeMessageAuthenticated = "authenticated"
+	RealtimeMessagePong          = "pong"
+	RealtimeMessageError         = "error"
+)
+
+// Realtime actions sent by WebSocket clients
+const (
+	RealtimeActionSubscribe   = "subscribe"
+	RealtimeActionUnsubscribe = "unsubscribe"
+	RealtimeActionAuth        = "auth"
+	RealtimeActionPing        = "ping"
+)
+
+// Realtime update events that aren't live match events
+const (
+	RealtimeEventStandingsUpdated  = "standings_updated"
+	RealtimeEventPredictionSettled = "prediction_settled"
+	RealtimeEventPickSettled       = "pick_settled"
+)
+
+// RealtimeRequest is a message sent by a WebSocket client
+type RealtimeRequest struct {
+	Action string `json:"action"`
+	Topic  string `json:"topic,omitempty"`
+	Token  string `json:"token,omitempty"` // For the auth action
+}
+
+// RealtimeMessage is a message sent to a WebSocket client
+type RealtimeMessage struct {
+	Type   string      `json:"type"`
+	Topic  string      `json:"topic,omitempty"`
+	Event  string      `json:"event,omitempty"` // For updates, e.g. goal or standings_updated
+	Data   interface{} `json:"data,omitempty"`
+	Error  string      `json:"error,omitempty"`
+	SentAt time.Time   `json:"sent_at"`
+}
+
+// StandingsDelta carries the standings rows that changed since the last update
+type StandingsDelta struct {
+	CompetitionCode string              `json:"competition_code"`
+	Rows            []StandingsTableDTO `json:"rows"`
+}
+
+// MatchTopic is the topic of a match's live events, keyed by provider match ID
+func MatchTopic(matchID int) string {
+	return fmt.Sprintf("match:%d", matchID)
+}
+
+// StandingsTopic is the topic of a competition's standings updates
+func StandingsTopic(competitionCode string) string {
+	return fmt.Sprintf("competition:%s:standings", competitionCode)
+}
+
+// UserPredictionsTopic is the private topic of a user's prediction and pick settlements
+func UserPredictionsTopic(userID uint) string {
+	return fmt.Sprintf("user:%d:predictions", userID)
+}
@@ -25,3 +25,74 @@ type PlayerStatsDTO struct {
 	Goals       int    `json:"goals"`
 	Assists     int    `json:"assists"`
 }
+
+// AreaResponse represents the country or region of a competition or team in the API responses
+type AreaResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Code string `json:"code"`
+	Flag string `json:"flag"`
+}
+
+// SeasonResponse represents a competition season in the API responses
+type SeasonResponse struct {
+	ID              int           `json:"id"`
+	StartDate       string        `json:"startDate"` // YYYY-MM-DD
+	EndDate         string        `json:"endDate"`   // YYYY-MM-DD
+	CurrentMatchday *int          `json:"currentMatchday"`
+	Winner          *TeamResponse `json:"winner"` // Set once the season is over, if the provider names one
+}
+
+// CompetitionDetailResponse represents a competition with its area and current season
+type CompetitionDetailResponse struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Code          string           `json:"code"`
+	Type          string           `json:"type"`
+	Emblem        string           `json:"emblem"`
+	Area          AreaResponse     `json:"area"`
+	CurrentSeason SeasonResponse   `json:"currentSeason"`
+	Seasons       []SeasonResponse `json:"seasons"` // Every season, most recent first, if the provider lists them
+}
+
+// SquadMemberResponse represents a player in a team's squad
+type SquadMemberResponse struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Position    string `json:"position"`
+	DateOfBirth string `json:"dateOfBirth"` // YYYY-MM-DD
+	Nationality string `json:"nationality"`
+	ShirtNumber *int   `json:"shirtNumber"`
+}
+
+// TeamDetailResponse represents a team with its area, venue and squad
+type TeamDetailResponse struct {
+	ID        int                   `json:"id"`
+	Name      string                `json:"name"`
+	ShortName string                `json:"shortName"`
+	TLA       string                `json:"tla"`
+	Crest     string                `json:"crest"`
+	Venue     string                `json:"venue"`
+	Founded   *int                  `json:"founded"`
+	Area      AreaResponse          `json:"area"`
+	Squad     []SquadMemberResponse `json:"squad"`
+}
+
+// CompetitionTeamsResponse represents the teams taking part in a competition season
+type CompetitionTeamsResponse struct {
+	Season SeasonResponse       `json:"season"`
+	Teams  []TeamDetailResponse `json:"teams"`
+}
+
+// PersonResponse represents a player or staff member with their current team
+type PersonResponse struct {
+	ID          int           `json:"id"`
+	Name        string        `json:"name"`
+	FirstName   string        `json:"firstName"`
+	LastName    string        `json:"lastName"`
+	DateOfBirth string        `json:"dateOfBirth"` // YYYY-MM-DD
+	Nationality string        `json:"nationality"`
+	Position    string        `json:"position"`
+	ShirtNumber *int          `json:"shirtNumber"`
+	CurrentTeam *TeamResponse `json:"currentTeam"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// StandingsHistoryQuery selects the table to compute from stored results: as of a date, as of a
+// matchday, or else as it stands now. Zero values are ignored.
+type StandingsHistoryQuery struct {
+	Competition string     // Competition code, e.g. PL
+	Season      int        // Year the season starts in; defaults to the season of Date, or the current one
+	Date        *time.Time // Counts results of matches kicking off on or before this day
+	Matchday    int        // Counts results of matchdays up to this one
+}
+
+// StandingsHistoryDTO represents a league table computed from stored match results as of a date
+// or matchday, with every team's position after each matchday
+type StandingsHistoryDTO struct {
+	CompetitionName string              `json:"competition_name"`
+	CompetitionCode string              `json:"competition_code"`
+	Season          int                 `json:"season"`          // Year the season starts in
+	AsOf            string              `json:"as_of"`           // YYYY-MM-DD: the date asked for, or the day of the last result counted
+	Matchday        int                 `json:"matchday"`        // The matchday asked for, or the last one with a result counted
+	Tiebreakers     []string            `json:"tiebreakers"`     // Applied in order to teams level on points
+	ResultsCounted  int                 `json:"results_counted"` // Stored results the table is computed from
+	Standings       []StandingsTableDTO `json:"standings"`
+	Positions       []PositionSeriesDTO `json:"positions"` // In table order
+}
+
+// PositionSeriesDTO represents a team's position in the table after each matchday
+type PositionSeriesDTO struct {
+	TeamID    int                   `json:"team_id"` // Provider team ID
+	TeamName  string                `json:"team_name"`
+	Positions []MatchdayPositionDTO `json:"positions"`
+}
+
+// MatchdayPositionDTO represents a team's position once a matchday's results are in
+type MatchdayPositionDTO struct {
+	Matchday int `json:"matchday"`
+	Position int `json:"position"`
+}
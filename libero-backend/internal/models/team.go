@@ -4,14 +4,20 @@ import "time"
 
 // Team represents a sports team
 type Team struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	Name      string    `gorm:"not null" json:"name"`
-	LogoURL   string    `json:"logo_url,omitempty"`
-	Country   string    `json:"country,omitempty"`
-	Sport     string    `json:"sport,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ProviderID *int      `gorm:"uniqueIndex" json:"provider_id,omitempty"` // football-data team ID
+	Name       string    `gorm:"not null" json:"name"`
+	ShortName  string    `json:"short_name,omitempty"`
+	TLA        string    `json:"tla,omitempty"`
+	LogoURL    string    `json:"logo_url,omitempty"`
+	Country    string    `json:"country,omitempty"`
+	Venue      string    `json:"venue,omitempty"`
+	Founded    *int      `json:"founded,omitempty"`
+	Sport      string    `json:"sport,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 
 	// Relationships
-	FollowedByUsers []*User `gorm:"many2many:user_followed_teams;"` // Users who follow this team
+	FollowedByUsers []*User        `gorm:"many2many:user_followed_teams;"`        // Users who follow this team
+	Competitions    []*Competition `gorm:"many2many:competition_teams;" json:"-"` // Competitions the team plays in
 }
\ No newline at end of file
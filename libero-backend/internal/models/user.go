@@ -7,6 +7,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// UserRoleAdmin is the site-wide role of administrators
+const UserRoleAdmin = "admin"
+
 // User represents the user data model
 type User struct {
 	ID         uint   `gorm:"primaryKey" json:"id"`
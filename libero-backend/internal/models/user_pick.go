@@ -0,0 +1,158 @@
+package models
+
+import "time"
+
+// Points awarded for a settled pick
+const (
+	PickPointsExactScore     = 3
+	PickPointsCorrectOutcome = 1
+)
+
+// Leaderboard periods
+const (
+	LeaderboardWeekly  = "weekly"
+	LeaderboardMonthly = "monthly"
+	LeaderboardSeason  = "season"
+)
+
+// UserPick represents a user's own scoreline pick for an upcoming fixture
+type UserPick struct {
+	ID                 uint      `gorm:"primaryKey" json:"id"`
+	UserID             uint      `gorm:"not null;uniqueIndex:idx_user_picks_user_match" json:"userId"`
+	MatchID            int       `gorm:"not null;uniqueIndex:idx_user_picks_user_match;index" json:"matchId"`
+	HomeTeam           string    `gorm:"not null" json:"homeTeam"`
+	AwayTeam           string    `gorm:"not null" json:"awayTeam"`
+	KickoffAt          time.Time `gorm:"not null;index" json:"kickoffAt"`
+	PredictedHomeScore int       `gorm:"not null" json:"predictedHomeScore"`
+	PredictedAwayScore int       `gorm:"not null" json:"predictedAwayScore"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+
+	// Settlement results (nil while the pick is pending)
+	ActualHomeScore *int       `json:"actualHomeScore,omitempty"`
+	ActualAwayScore *int       `json:"actualAwayScore,omitempty"`
+	Points          *int       `json:"points,omitempty"`
+	SettledAt       *time.Time `gorm:"index" json:"settledAt,omitempty"`
+	VoidedAt        *time.Time `gorm:"index" json:"voidedAt,omitempty"`      // Set instead when the match was cancelled
+	LastCheckedAt   *time.Time `gorm:"index" json:"lastCheckedAt,omitempty"` // Last settlement attempt
+
+	// Relationship
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// Status returns whether the pick has been settled against a final score, or voided
+func (p *UserPick) Status() string {
+	switch {
+	case p.SettledAt != nil:
+		return PredictionStatusSettled
+	case p.VoidedAt != nil:
+		return PredictionStatusVoid
+	}
+	return PredictionStatusPending
+}
+
+// --- DTOs for Picks API ---
+
+// SubmitPickRequest defines the request body for submitting a pick
+type SubmitPickRequest struct {
+	MatchID   int `json:"matchId"`
+	HomeScore int `json:"homeScore"`
+	AwayScore int `json:"awayScore"`
+}
+
+// UserPickResponse defines the response format for a pick
+type UserPickResponse struct {
+	ID                 uint       `json:"id"`
+	MatchID            int        `json:"matchId"`
+	HomeTeam           string     `json:"homeTeam"`
+	AwayTeam           string     `json:"awayTeam"`
+	KickoffAt          time.Time  `json:"kickoffAt"`
+	PredictedHomeScore int        `json:"predictedHomeScore"`
+	PredictedAwayScore int        `json:"predictedAwayScore"`
+	Status             string     `json:"status"`
+	ActualHomeScore    *int       `json:"actualHomeScore,omitempty"`
+	ActualAwayScore    *int       `json:"actualAwayScore,omitempty"`
+	Points             *int       `json:"points,omitempty"`
+	SettledAt          *time.Time `json:"settledAt,omitempty"`
+	VoidedAt           *time.Time `json:"voidedAt,omitempty"`
+}
+
+// ToResponse converts UserPick to UserPickResponse
+func (p *UserPick) ToResponse() UserPickResponse {
+	return UserPickResponse{
+		ID:                 p.ID,
+		MatchID:            p.MatchID,
+		HomeTeam:           p.HomeTeam,
+		AwayTeam:           p.AwayTeam,
+		KickoffAt:          p.KickoffAt,
+		PredictedHomeScore: p.PredictedHomeScore,
+		PredictedAwayScore: p.PredictedAwayScore,
+		Status:             p.Status(),
+		ActualHomeScore:    p.ActualHomeScore,
+		ActualAwayScore:    p.ActualAwayScore,
+		Points:             p.Points,
+		SettledAt:          p.SettledAt,
+		VoidedAt:           p.VoidedAt,
+	}
+}
+
+// LeaderboardEntry represents a user's standing on a leaderboard
+type LeaderboardEntry struct {
+	Rank            int    `json:"rank"`
+	UserID          uint   `json:"userId"`
+	Username        string `json:"username"`
+	Name            string `json:"name,omitempty"`
+	Points          int    `json:"points"`
+	Picks           int    `json:"picks"`
+	ExactScores     int    `json:"exactScores"`
+	CorrectOutcomes int    `json:"correctOutcomes"`
+}
+
+// Leaderboard represents the ranked users for a period
+type Leaderboard struct {
+	Period  string             `json:"period"`
+	From    time.Time          `json:"from"`
+	To      time.Time          `json:"to"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// PickComparisonRow is a settled pick joined with the model's prediction for the same match
+type PickComparisonRow struct {
+	MatchID            int
+	HomeTeam           string
+	AwayTeam           string
+	KickoffAt          time.Time
+	PredictedHomeScore int
+	PredictedAwayScore int
+	ActualHomeScore    int
+	ActualAwayScore    int
+	ModelHomeScore     int
+	ModelAwayScore     int
+}
+
+// PickComparison compares a user's pick with the model's prediction for one match
+type PickComparison struct {
+	MatchID         int       `json:"matchId"`
+	HomeTeam        string    `json:"homeTeam"`
+	AwayTeam        string    `json:"awayTeam"`
+	KickoffAt       time.Time `json:"kickoffAt"`
+	ActualHomeScore int       `json:"actualHomeScore"`
+	ActualAwayScore int       `json:"actualAwayScore"`
+	UserHomeScore   int       `json:"userHomeScore"`
+	UserAwayScore   int       `json:"userAwayScore"`
+	UserPoints      int       `json:"userPoints"`
+	ModelHomeScore  int       `json:"modelHomeScore"`
+	ModelAwayScore  int       `json:"modelAwayScore"`
+	ModelPoints     int       `json:"modelPoints"`
+}
+
+// PickComparisonSummary aggregates how a user's picks fared against the model
+type PickComparisonSummary struct {
+	Matches     int              `json:"matches"`
+	UserPoints  int              `json:"userPoints"`
+	ModelPoints int              `json:"modelPoints"`
+	UserBetter  int              `json:"userBetter"`
+	ModelBetter int              `json:"modelBetter"`
+	Level       int              `json:"level"`
+	Comparisons []PickComparison `json:"comparisons"`
+}
@@ -0,0 +1,891 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"libero-backend/internal/models"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// APIFootballName identifies the API-Football provider in routes and logs
+	APIFootballName = "api-football"
+	// apiFootballSeasonTTL is how long a league's current season is reused before it is looked up again
+	apiFootballSeasonTTL = 24 * time.Hour
+	// apiFootballMaxIDs is the most fixtures API-Football returns for a single ids lookup
+	apiFootballMaxIDs = 20
+	// apiFootballDefaultRange bounds open-ended date ranges, which API-Football doesn't accept
+	apiFootballDefaultRange = 14 * 24 * time.Hour
+)
+
+// apiFootballLeagues maps our competition codes to API-Football league IDs
+var apiFootballLeagues = map[string]int{
+	"PL":  39,
+	"PD":  140,
+	"SA":  135,
+	"BL1": 78,
+	"FL1": 61,
+	"CL":  2,
+	"EL":  3,
+}
+
+// APIFootball is the API-Football v3 adapter. Only the competitions in apiFootballLeagues are
+// supported, and team, match and person IDs are API-Football's, which differ from
+// football-data.org's. Seasons are identified by the year they start in.
+type APIFootball struct {
+	client *Client
+
+	mu      sync.Mutex
+	seasons map[int]apiFootballSeason // Current season by league ID
+}
+
+// apiFootballSeason is a league's current season, looked up at fetchedAt
+type apiFootballSeason struct {
+	league    apiFootballLeague
+	fetchedAt time.Time
+}
+
+// NewAPIFootball creates an API-Football adapter
+func NewAPIFootball(baseURL, apiKey string, limiter Limiter, store ResponseStore) *APIFootball {
+	return &APIFootball{
+		client:  NewClient(baseURL, "x-apisports-key", apiKey, limiter, store),
+		seasons: make(map[int]apiFootballSeason),
+	}
+}
+
+// Name returns the provider name
+func (p *APIFootball) Name() string {
+	return APIFootballName
+}
+
+// API-Football payloads. Every response is wrapped in an envelope whose errors field is an
+// empty array on success and an object keyed by error type otherwise.
+
+type apiFootballEnvelope struct {
+	Errors   json.RawMessage `json:"errors"`
+	Response json.RawMessage `json:"response"`
+}
+
+type apiFootballLeague struct {
+	League struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+		Logo string `json:"logo"`
+	} `json:"league"`
+	Country struct {
+		Name string `json:"name"`
+		Code string `json:"code"`
+		Flag string `json:"flag"`
+	} `json:"country"`
+	Seasons []struct {
+		Year    int    `json:"year"`
+		Start   string `json:"start"`
+		End     string `json:"end"`
+		Current bool   `json:"current"`
+	} `json:"seasons"`
+}
+
+type apiFootballTeam struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Logo   string `json:"logo"`
+	Winner *bool  `json:"winner"`
+}
+
+type apiFootballStandings struct {
+	League struct {
+		ID        int    `json:"id"`
+		Name      string `json:"name"`
+		Season    int    `json:"season"`
+		Standings [][]struct {
+			Rank      int               `json:"rank"`
+			Team      apiFootballTeam   `json:"team"`
+			Points    int               `json:"points"`
+			GoalsDiff int               `json:"goalsDiff"`
+			Group     string            `json:"group"` // e.g. "Group A", or the league's name
+			Form      string            `json:"form"`
+			All       apiFootballRecord `json:"all"`
+			Home      apiFootballRecord `json:"home"`
+			Away      apiFootballRecord `json:"away"`
+		} `json:"standings"`
+	} `json:"league"`
+}
+
+// apiFootballRecord is a team's record over all, home or away matches
+type apiFootballRecord struct {
+	Played int `json:"played"`
+	Win    int `json:"win"`
+	Draw   int `json:"draw"`
+	Lose   int `json:"lose"`
+	Goals  struct {
+		For     int `json:"for"`
+		Against int `json:"against"`
+	} `json:"goals"`
+}
+
+type apiFootballPlayer struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Firstname   string `json:"firstname"`
+	Lastname    string `json:"lastname"`
+	Nationality string `json:"nationality"`
+	Position    string `json:"position"`
+	Number      *int   `json:"number"`
+	Birth       struct {
+		Date string `json:"date"`
+	} `json:"birth"`
+}
+
+type apiFootballScorer struct {
+	Player     apiFootballPlayer `json:"player"`
+	Statistics []struct {
+		Team  apiFootballTeam `json:"team"`
+		Games struct {
+			Position string `json:"position"`
+		} `json:"games"`
+		Goals struct {
+			Total   *int `json:"total"`
+			Assists *int `json:"assists"`
+		} `json:"goals"`
+		Penalty struct {
+			Scored *int `json:"scored"`
+		} `json:"penalty"`
+	} `json:"statistics"`
+}
+
+type apiFootballFixture struct {
+	Fixture struct {
+		ID      int       `json:"id"`
+		Date    time.Time `json:"date"`
+		Referee string    `json:"referee"` // e.g. "Michael Oliver, England", null if not assigned
+		Venue   struct {
+			Name string `json:"name"`
+		} `json:"venue"`
+		Status struct {
+			Short string `json:"short"`
+		} `json:"status"`
+	} `json:"fixture"`
+	League struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		Logo  string `json:"logo"`
+		Round string `json:"round"`
+	} `json:"league"`
+	Teams struct {
+		Home apiFootballTeam `json:"home"`
+		Away apiFootballTeam `json:"away"`
+	} `json:"teams"`
+	Goals models.ScoreResponse `json:"goals"`
+	Score struct {
+		Halftime  models.ScoreResponse `json:"halftime"`
+		Fulltime  models.ScoreResponse `json:"fulltime"`
+		Extratime models.ScoreResponse `json:"extratime"`
+		Penalty   models.ScoreResponse `json:"penalty"`
+	} `json:"score"`
+}
+
+type apiFootballTeamInfo struct {
+	Team struct {
+		ID      int    `json:"id"`
+		Name    string `json:"name"`
+		Code    string `json:"code"`
+		Country string `json:"country"`
+		Founded *int   `json:"founded"`
+		Logo    string `json:"logo"`
+	} `json:"team"`
+	Venue struct {
+		Name string `json:"name"`
+	} `json:"venue"`
+}
+
+type apiFootballSquad struct {
+	Players []struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Number   *int   `json:"number"`
+		Position string `json:"position"`
+	} `json:"players"`
+}
+
+// Competition retrieves a competition with its area and current season
+func (p *APIFootball) Competition(ctx context.Context, code string) (*models.CompetitionDetailResponse, error) {
+	league, err := p.currentSeason(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	competition := &models.CompetitionDetailResponse{
+		ID:     league.League.ID,
+		Name:   league.League.Name,
+		Code:   code,
+		Type:   strings.ToUpper(league.League.Type),
+		Emblem: league.League.Logo,
+		Area: models.AreaResponse{
+			Name: league.Country.Name,
+			Code: league.Country.Code,
+			Flag: league.Country.Flag,
+		},
+	}
+	if len(league.Seasons) > 0 {
+		season := league.Seasons[0]
+		competition.CurrentSeason = models.SeasonResponse{
+			ID:        season.Year,
+			StartDate: season.Start,
+			EndDate:   season.End,
+		}
+	}
+	return competition, nil
+}
+
+// CompetitionSeasons retrieves every season of a competition, most recent first. Season IDs are
+// the years the seasons start in.
+func (p *APIFootball) CompetitionSeasons(ctx context.Context, code string) ([]models.SeasonResponse, error) {
+	leagueID, ok := apiFootballLeagues[code]
+	if !ok {
+		return nil, fmt.Errorf("%w: competition %s", ErrUnsupported, code)
+	}
+
+	var leagues []apiFootballLeague
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(leagueID))
+	if err := p.get(ctx, "/leagues", query, &leagues); err != nil {
+		return nil, err
+	}
+	if len(leagues) == 0 {
+		return nil, &StatusError{StatusCode: http.StatusNotFound, Body: "no seasons for " + code}
+	}
+
+	seasons := make([]models.SeasonResponse, 0, len(leagues[0].Seasons))
+	for _, season := range leagues[0].Seasons {
+		seasons = append(seasons, models.SeasonResponse{ID: season.Year, StartDate: season.Start, EndDate: season.End})
+	}
+	slices.SortFunc(seasons, func(a, b models.SeasonResponse) int {
+		return b.ID - a.ID
+	})
+	return seasons, nil
+}
+
+// CompetitionTeams retrieves the teams of a competition's current season. Squads are left empty
+// since API-Football serves them one team per request; use Team for a squad.
+func (p *APIFootball) CompetitionTeams(ctx context.Context, code string) (*models.CompetitionTeamsResponse, error) {
+	competition, err := p.Competition(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var teams []apiFootballTeamInfo
+	query := url.Values{}
+	query.Set("league", strconv.Itoa(competition.ID))
+	query.Set("season", strconv.Itoa(competition.CurrentSeason.ID))
+	if err := p.get(ctx, "/teams", query, &teams); err != nil {
+		return nil, err
+	}
+
+	result := &models.CompetitionTeamsResponse{
+		Season: competition.CurrentSeason,
+		Teams:  make([]models.TeamDetailResponse, 0, len(teams)),
+	}
+	for _, team := range teams {
+		result.Teams = append(result.Teams, teamDetailFromAPIFootball(team))
+	}
+	return result, nil
+}
+
+// Standings retrieves a competition's standings of a season. API-Football ranks the total table
+// only, so home and away tables are ranked here on points, goal difference and goals scored.
+func (p *APIFootball) Standings(ctx context.Context, code string, season int) (*models.StandingsResponse, error) {
+	league, err := p.currentSeason(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	current := seasonYear(league)
+	if season == 0 {
+		season = current
+	}
+
+	var standings []apiFootballStandings
+	query := url.Values{}
+	query.Set("league", strconv.Itoa(league.League.ID))
+	query.Set("season", strconv.Itoa(season))
+	if err := p.get(ctx, "/standings", query, &standings); err != nil {
+		return nil, err
+	}
+	if len(standings) == 0 {
+		return nil, &StatusError{StatusCode: http.StatusNotFound, Body: "no standings for " + code}
+	}
+
+	data := standings[0].League
+	result := &models.StandingsResponse{
+		Competition: models.CompetitionRefResponse{ID: data.ID, Name: data.Name},
+		Season:      models.SeasonRefResponse{ID: season, Current: season == current},
+		Standings:   make([]models.StandingsGroupResponse, 0, 3*len(data.Standings)),
+	}
+	stage := "REGULAR_SEASON"
+	if len(data.Standings) > 1 {
+		stage = "GROUP_STAGE"
+	}
+	for _, table := range data.Standings {
+		tableStage, groupName := stage, ""
+		// Group tables are named like the group's rounds
+		if len(table) > 0 {
+			if groupStage, name := apiFootballStage(table[0].Group); name != "" {
+				tableStage, groupName = groupStage, name
+			}
+		}
+
+		total := models.StandingsGroupResponse{Stage: tableStage, Type: "TOTAL", Group: groupName}
+		home := models.StandingsGroupResponse{Stage: tableStage, Type: "HOME", Group: groupName}
+		away := models.StandingsGroupResponse{Stage: tableStage, Type: "AWAY", Group: groupName}
+		for _, row := range table {
+			team := teamFromAPIFootball(row.Team)
+			totalRow := standingsRowFromAPIFootball(team, row.All)
+			totalRow.Position = row.Rank
+			totalRow.Points = row.Points
+			totalRow.Form = row.Form
+			total.Table = append(total.Table, totalRow)
+			home.Table = append(home.Table, standingsRowFromAPIFootball(team, row.Home))
+			away.Table = append(away.Table, standingsRowFromAPIFootball(team, row.Away))
+		}
+		rankStandingsRows(home.Table)
+		rankStandingsRows(away.Table)
+		result.Standings = append(result.Standings, total, home, away)
+	}
+	return result, nil
+}
+
+// standingsRowFromAPIFootball maps a team's record onto a standings row, without its position
+func standingsRowFromAPIFootball(team models.TeamResponse, record apiFootballRecord) models.StandingsRowResponse {
+	return models.StandingsRowResponse{
+		Team:           team,
+		PlayedGames:    record.Played,
+		Won:            record.Win,
+		Draw:           record.Draw,
+		Lost:           record.Lose,
+		Points:         3*record.Win + record.Draw,
+		GoalsFor:       record.Goals.For,
+		GoalsAgainst:   record.Goals.Against,
+		GoalDifference: record.Goals.For - record.Goals.Against,
+	}
+}
+
+// rankStandingsRows orders rows on points, goal difference and goals scored, and numbers them
+func rankStandingsRows(rows []models.StandingsRowResponse) {
+	slices.SortStableFunc(rows, func(a, b models.StandingsRowResponse) int {
+		if a.Points != b.Points {
+			return b.Points - a.Points
+		}
+		if a.GoalDifference != b.GoalDifference {
+			return b.GoalDifference - a.GoalDifference
+		}
+		return b.GoalsFor - a.GoalsFor
+	})
+	for i := range rows {
+		rows[i].Position = i + 1
+	}
+}
+
+// Scorers retrieves a competition's top scorers of a season
+func (p *APIFootball) Scorers(ctx context.Context, code string, season int) (*models.ScorersResponse, error) {
+	league, err := p.currentSeason(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	current := seasonYear(league)
+	if season == 0 {
+		season = current
+	}
+
+	var scorers []apiFootballScorer
+	query := url.Values{}
+	query.Set("league", strconv.Itoa(league.League.ID))
+	query.Set("season", strconv.Itoa(season))
+	if err := p.get(ctx, "/players/topscorers", query, &scorers); err != nil {
+		return nil, err
+	}
+
+	result := &models.ScorersResponse{
+		Competition: models.CompetitionRefResponse{ID: league.League.ID, Name: league.League.Name},
+		Season:      models.SeasonRefResponse{ID: season, Current: season == current},
+		Scorers:     make([]models.ScorerResponse, 0, len(scorers)),
+	}
+	for _, scorer := range scorers {
+		entry := models.ScorerResponse{
+			Player: models.PlayerResponse{
+				ID:          scorer.Player.ID,
+				Name:        scorer.Player.Name,
+				Nationality: scorer.Player.Nationality,
+			},
+		}
+		if len(scorer.Statistics) > 0 {
+			stats := scorer.Statistics[0]
+			entry.Player.Position = stats.Games.Position
+			entry.Team = teamFromAPIFootball(stats.Team)
+			entry.Goals = intValue(stats.Goals.Total)
+			entry.Assists = intValue(stats.Goals.Assists)
+			entry.Penalties = intValue(stats.Penalty.Scored)
+		}
+		result.Scorers = append(result.Scorers, entry)
+	}
+	return result, nil
+}
+
+// Matches retrieves the matches passing the query. Fixtures outside the supported competitions
+// are left out.
+func (p *APIFootball) Matches(ctx context.Context, query MatchQuery) (*models.MatchesResponse, error) {
+	var fixtures []apiFootballFixture
+	switch {
+	case len(query.IDs) > 0:
+		for start := 0; start < len(query.IDs); start += apiFootballMaxIDs {
+			end := min(start+apiFootballMaxIDs, len(query.IDs))
+			ids := make([]string, 0, end-start)
+			for _, id := range query.IDs[start:end] {
+				ids = append(ids, strconv.Itoa(id))
+			}
+			values := url.Values{}
+			values.Set("ids", strings.Join(ids, "-"))
+			var batch []apiFootballFixture
+			if err := p.get(ctx, "/fixtures", values, &batch); err != nil {
+				return nil, err
+			}
+			fixtures = append(fixtures, batch...)
+		}
+	case len(query.Competitions) > 0:
+		// API-Football filters by a single league and season per request
+		for _, code := range query.Competitions {
+			league, err := p.currentSeason(ctx, code)
+			if err != nil {
+				return nil, err
+			}
+			values := apiFootballDateParams(query)
+			values.Set("league", strconv.Itoa(league.League.ID))
+			values.Set("season", strconv.Itoa(seasonYear(league)))
+			var batch []apiFootballFixture
+			if err := p.get(ctx, "/fixtures", values, &batch); err != nil {
+				return nil, err
+			}
+			fixtures = append(fixtures, batch...)
+		}
+	case query.Date != "":
+		values := url.Values{}
+		values.Set("date", query.Date)
+		if err := p.get(ctx, "/fixtures", values, &fixtures); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: match listings need IDs, competitions or a date", ErrUnsupported)
+	}
+
+	var statuses []string
+	if query.Status != "" {
+		statuses = strings.Split(query.Status, ",")
+	}
+	result := &models.MatchesResponse{Matches: make([]models.MatchResponse, 0, len(fixtures))}
+	for _, fixture := range fixtures {
+		match, ok := matchFromAPIFootball(fixture)
+		if !ok {
+			continue
+		}
+		if statuses != nil && !slices.Contains(statuses, match.Status) {
+			continue
+		}
+		result.Matches = append(result.Matches, match)
+	}
+	return result, nil
+}
+
+// CompetitionMatches retrieves every match of a competition's current season
+func (p *APIFootball) CompetitionMatches(ctx context.Context, code string) (*models.MatchesResponse, error) {
+	// Without dates, a league's fixtures are those of the whole season
+	return p.Matches(ctx, MatchQuery{Competitions: []string{code}})
+}
+
+// Match retrieves a single match
+func (p *APIFootball) Match(ctx context.Context, id int) (*models.MatchResponse, error) {
+	var fixtures []apiFootballFixture
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(id))
+	if err := p.get(ctx, "/fixtures", query, &fixtures); err != nil {
+		return nil, err
+	}
+	if len(fixtures) == 0 {
+		return nil, &StatusError{StatusCode: http.StatusNotFound, Body: fmt.Sprintf("no fixture %d", id)}
+	}
+	match, ok := matchFromAPIFootball(fixtures[0])
+	if !ok {
+		return nil, fmt.Errorf("%w: fixture %d is outside the supported competitions", ErrUnsupported, id)
+	}
+	return &match, nil
+}
+
+// Team retrieves a team with its venue and squad
+func (p *APIFootball) Team(ctx context.Context, id int) (*models.TeamDetailResponse, error) {
+	var teams []apiFootballTeamInfo
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(id))
+	if err := p.get(ctx, "/teams", query, &teams); err != nil {
+		return nil, err
+	}
+	if len(teams) == 0 {
+		return nil, &StatusError{StatusCode: http.StatusNotFound, Body: fmt.Sprintf("no team %d", id)}
+	}
+	team := teamDetailFromAPIFootball(teams[0])
+
+	var squads []apiFootballSquad
+	query = url.Values{}
+	query.Set("team", strconv.Itoa(id))
+	if err := p.get(ctx, "/players/squads", query, &squads); err != nil {
+		return nil, err
+	}
+	if len(squads) > 0 {
+		for _, player := range squads[0].Players {
+			team.Squad = append(team.Squad, models.SquadMemberResponse{
+				ID:          player.ID,
+				Name:        player.Name,
+				Position:    player.Position,
+				ShirtNumber: player.Number,
+			})
+		}
+	}
+	return &team, nil
+}
+
+// Person retrieves a player. Their current team isn't part of the profile, so it is left nil.
+func (p *APIFootball) Person(ctx context.Context, id int) (*models.PersonResponse, error) {
+	var profiles []struct {
+		Player apiFootballPlayer `json:"player"`
+	}
+	query := url.Values{}
+	query.Set("player", strconv.Itoa(id))
+	if err := p.get(ctx, "/players/profiles", query, &profiles); err != nil {
+		return nil, err
+	}
+	if len(profiles) == 0 {
+		return nil, &StatusError{StatusCode: http.StatusNotFound, Body: fmt.Sprintf("no player %d", id)}
+	}
+
+	player := profiles[0].Player
+	return &models.PersonResponse{
+		ID:          player.ID,
+		Name:        player.Name,
+		FirstName:   player.Firstname,
+		LastName:    player.Lastname,
+		DateOfBirth: player.Birth.Date,
+		Nationality: player.Nationality,
+		Position:    player.Position,
+		ShirtNumber: player.Number,
+	}, nil
+}
+
+// get performs a GET request and decodes the envelope's response into out. API-Football answers
+// most errors, including exhausted quotas, with a 200, so the envelope's errors are mapped onto
+// status errors here.
+func (p *APIFootball) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	var envelope apiFootballEnvelope
+	if err := p.client.Get(ctx, path, query, &envelope); err != nil {
+		return err
+	}
+
+	errs := bytes.TrimSpace(envelope.Errors)
+	if len(errs) > 0 && !bytes.Equal(errs, []byte("[]")) && !bytes.Equal(errs, []byte("{}")) && !bytes.Equal(errs, []byte("null")) {
+		var keyed map[string]string
+		_ = json.Unmarshal(errs, &keyed)
+		if _, ok := keyed["rateLimit"]; ok {
+			p.client.limiter.Backoff(time.Now().Add(defaultRetryAfter))
+			return &StatusError{StatusCode: http.StatusTooManyRequests, Body: string(errs), RetryAfter: defaultRetryAfter}
+		}
+		return &StatusError{StatusCode: http.StatusBadGateway, Body: string(errs)}
+	}
+
+	if err := json.Unmarshal(envelope.Response, out); err != nil {
+		return fmt.Errorf("failed to decode provider response: %w", err)
+	}
+	return nil
+}
+
+// currentSeason returns the league of a competition with only its current season, reusing
+// the last lookup for apiFootballSeasonTTL
+func (p *APIFootball) currentSeason(ctx context.Context, code string) (apiFootballLeague, error) {
+	leagueID, ok := apiFootballLeagues[code]
+	if !ok {
+		return apiFootballLeague{}, fmt.Errorf("%w: competition %s", ErrUnsupported, code)
+	}
+
+	p.mu.Lock()
+	cached, ok := p.seasons[leagueID]
+	p.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < apiFootballSeasonTTL {
+		return cached.league, nil
+	}
+
+	var leagues []apiFootballLeague
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(leagueID))
+	query.Set("current", "true")
+	if err := p.get(ctx, "/leagues", query, &leagues); err != nil {
+		return apiFootballLeague{}, err
+	}
+	if len(leagues) == 0 || len(leagues[0].Seasons) == 0 {
+		return apiFootballLeague{}, &StatusError{StatusCode: http.StatusNotFound, Body: "no current season for " + code}
+	}
+
+	league := leagues[0]
+	for _, season := range league.Seasons {
+		if season.Current {
+			league.Seasons = append(league.Seasons[:0], season)
+			break
+		}
+	}
+	p.mu.Lock()
+	p.seasons[leagueID] = apiFootballSeason{league: league, fetchedAt: time.Now()}
+	p.mu.Unlock()
+	return league, nil
+}
+
+// seasonYear returns the year of a league's current season
+func seasonYear(league apiFootballLeague) int {
+	if len(league.Seasons) == 0 {
+		return 0
+	}
+	return league.Seasons[0].Year
+}
+
+// apiFootballDateParams encodes a match query's dates as /fixtures parameters
+func apiFootballDateParams(query MatchQuery) url.Values {
+	values := url.Values{}
+	if query.Date != "" {
+		values.Set("date", query.Date)
+		return values
+	}
+	if query.DateFrom == "" && query.DateTo == "" {
+		return values
+	}
+
+	from, to := query.DateFrom, query.DateTo
+	if from == "" {
+		from = to
+	}
+	if to == "" {
+		if start, err := time.Parse("2006-01-02", from); err == nil {
+			to = start.Add(apiFootballDefaultRange).Format("2006-01-02")
+		} else {
+			to = from
+		}
+	}
+	values.Set("from", from)
+	values.Set("to", to)
+	return values
+}
+
+// competitionCode returns our code for an API-Football league ID
+func competitionCode(leagueID int) (string, bool) {
+	for code, id := range apiFootballLeagues {
+		if id == leagueID {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// matchFromAPIFootball maps a fixture into a match. It reports false for fixtures outside the
+// supported competitions.
+func matchFromAPIFootball(fixture apiFootballFixture) (models.MatchResponse, bool) {
+	code, ok := competitionCode(fixture.League.ID)
+	if !ok {
+		return models.MatchResponse{}, false
+	}
+
+	status := apiFootballStatus(fixture.Fixture.Status.Short)
+	stage, group := apiFootballStage(fixture.League.Round)
+	match := models.MatchResponse{
+		ID:       fixture.Fixture.ID,
+		UtcDate:  fixture.Fixture.Date.UTC(),
+		Status:   status,
+		Matchday: roundNumber(fixture.League.Round),
+		Stage:    stage,
+		Group:    group,
+		Venue:    fixture.Fixture.Venue.Name,
+		Competition: models.MatchCompetitionResponse{
+			ID:     fixture.League.ID,
+			Name:   fixture.League.Name,
+			Code:   code,
+			Emblem: fixture.League.Logo,
+		},
+		HomeTeam: teamFromAPIFootball(fixture.Teams.Home),
+		AwayTeam: teamFromAPIFootball(fixture.Teams.Away),
+		Score: models.MatchScoreResponse{
+			Duration: "REGULAR",
+			FullTime: fixture.Goals,
+			HalfTime: fixture.Score.Halftime,
+		},
+	}
+
+	switch fixture.Fixture.Status.Short {
+	case "AET", "ET", "BT":
+		match.Score.Duration = "EXTRA_TIME"
+	case "PEN", "P":
+		match.Score.Duration = "PENALTY_SHOOTOUT"
+	}
+	if match.Score.Duration != "REGULAR" {
+		match.Score.RegularTime = fixture.Score.Fulltime
+		match.Score.ExtraTime = fixture.Score.Extratime
+	}
+	if match.Score.Duration == "PENALTY_SHOOTOUT" {
+		// football-data.org counts shootout goals in the full-time score, and so do the response models
+		match.Score.Penalties = fixture.Score.Penalty
+		match.Score.FullTime = addScores(fixture.Goals, fixture.Score.Penalty)
+	}
+	if name, nationality, _ := strings.Cut(fixture.Fixture.Referee, ","); strings.TrimSpace(name) != "" {
+		match.Referees = []models.RefereeResponse{{
+			Name:        strings.TrimSpace(name),
+			Type:        "REFEREE",
+			Nationality: strings.TrimSpace(nationality),
+		}}
+	}
+
+	switch {
+	case fixture.Teams.Home.Winner != nil && *fixture.Teams.Home.Winner:
+		match.Score.Winner = "HOME_TEAM"
+	case fixture.Teams.Away.Winner != nil && *fixture.Teams.Away.Winner:
+		match.Score.Winner = "AWAY_TEAM"
+	case status == "FINISHED":
+		match.Score.Winner = "DRAW"
+	}
+	return match, true
+}
+
+// apiFootballStatus maps an API-Football short status onto football-data.org's statuses
+func apiFootballStatus(short string) string {
+	switch short {
+	case "NS":
+		return "TIMED"
+	case "TBD":
+		return "SCHEDULED"
+	case "1H", "2H", "ET", "BT", "P", "LIVE":
+		return "IN_PLAY"
+	case "HT":
+		return "PAUSED"
+	case "FT", "AET", "PEN":
+		return "FINISHED"
+	case "PST":
+		return "POSTPONED"
+	case "CANC", "ABD":
+		return "CANCELLED"
+	case "SUSP", "INT":
+		return "SUSPENDED"
+	case "AWD", "WO":
+		return "AWARDED"
+	}
+	return "SCHEDULED"
+}
+
+// roundNumber reads the matchday from a round such as "Regular Season - 12", or 0 if it has none
+func roundNumber(round string) int {
+	idx := strings.LastIndex(round, " ")
+	if idx < 0 {
+		return 0
+	}
+	n, err := strconv.Atoi(round[idx+1:])
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// apiFootballStages maps API-Football knockout rounds onto football-data.org's stages
+var apiFootballStages = map[string]string{
+	"Knockout Round Play-offs": "PLAYOFFS",
+	"Round of 32":              "LAST_32",
+	"Round of 16":              "LAST_16",
+	"Quarter-finals":           "QUARTER_FINALS",
+	"Semi-finals":              "SEMI_FINALS",
+	"Final":                    "FINAL",
+}
+
+// apiFootballStage maps a round such as "Regular Season - 12", "Group A - 3" or "Quarter-finals"
+// onto football-data.org's stage and group
+func apiFootballStage(round string) (stage, group string) {
+	name := strings.TrimSpace(round)
+	if idx := strings.LastIndex(name, " - "); idx >= 0 {
+		name = name[:idx]
+	}
+	switch {
+	case name == "Regular Season":
+		return "REGULAR_SEASON", ""
+	case name == "League Stage":
+		return "LEAGUE_STAGE", ""
+	case strings.HasPrefix(name, "Group "):
+		return "GROUP_STAGE", "GROUP_" + strings.ToUpper(strings.TrimPrefix(name, "Group "))
+	case strings.HasSuffix(name, "Qualifying Round"):
+		return "QUALIFICATION_ROUND_" + strconv.Itoa(roundOrdinal(name)), ""
+	case strings.HasPrefix(name, "Play-offs"), strings.HasPrefix(name, "Preliminary Round"):
+		return "PLAYOFF_ROUND", ""
+	}
+	if stage, ok := apiFootballStages[name]; ok {
+		return stage, ""
+	}
+	return strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_").Replace(name)), ""
+}
+
+// roundOrdinal reads the number of a round such as "2nd Qualifying Round", or 1 if it has none
+func roundOrdinal(round string) int {
+	digits := strings.TrimLeftFunc(round, func(r rune) bool { return r < '0' || r > '9' })
+	end := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' })
+	if end > 0 {
+		digits = digits[:end]
+	}
+	if n, err := strconv.Atoi(digits); err == nil && n > 0 {
+		return n
+	}
+	return 1
+}
+
+// addScores adds two score pairs, or returns a unless both are complete
+func addScores(a, b models.ScoreResponse) models.ScoreResponse {
+	if a.Home == nil || a.Away == nil || b.Home == nil || b.Away == nil {
+		return a
+	}
+	home, away := *a.Home+*b.Home, *a.Away+*b.Away
+	return models.ScoreResponse{Home: &home, Away: &away}
+}
+
+// teamFromAPIFootball maps an API-Football team reference into a team
+func teamFromAPIFootball(team apiFootballTeam) models.TeamResponse {
+	return models.TeamResponse{
+		ID:        team.ID,
+		Name:      team.Name,
+		ShortName: team.Name,
+		Crest:     team.Logo,
+	}
+}
+
+// teamDetailFromAPIFootball maps an API-Football team and venue into a team without its squad
+func teamDetailFromAPIFootball(info apiFootballTeamInfo) models.TeamDetailResponse {
+	return models.TeamDetailResponse{
+		ID:        info.Team.ID,
+		Name:      info.Team.Name,
+		ShortName: info.Team.Name,
+		TLA:       info.Team.Code,
+		Crest:     info.Team.Logo,
+		Venue:     info.Venue.Name,
+		Founded:   info.Team.Founded,
+		Area:      models.AreaResponse{Name: info.Team.Country},
+		Squad:     []models.SquadMemberResponse{},
+	}
+}
+
+// intValue dereferences an optional count, treating nil as 0
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
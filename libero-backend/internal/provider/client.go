@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"libero-backend/internal/models"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// requestTimeout bounds a single HTTP request to the provider
+	requestTimeout = 30 * time.Second
+	// maxRetries is how many times a rate-limited request is retried
+	maxRetries = 1
+	// maxRetryWait is the longest Retry-After we wait out; longer waits fail fast so callers can serve cached data
+	maxRetryWait = 30 * time.Second
+	// defaultRetryAfter is used when a 429 response doesn't say how long to wait
+	defaultRetryAfter = 10 * time.Second
+	// maxErrorBody caps how much of an error response is kept in a StatusError
+	maxErrorBody = 512
+)
+
+// ResponseStore keeps provider responses together with their ETag and Last-Modified so later
+// fetches of the same URL can be made conditional. Responses are keyed by URL; the store decides
+// how long they are kept.
+type ResponseStore interface {
+	Get(key string) (*models.CacheItem, error)
+	Set(key string, item models.CacheItem) error
+	Extend(key string) error
+}
+
+// unstoredKey marks a context whose responses aren't kept for revalidation
+type unstoredKey struct{}
+
+// WithoutStore returns a context whose provider responses aren't kept for revalidation, e.g. for
+// live polls that would rewrite a whole body every few seconds
+func WithoutStore(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unstoredKey{}, true)
+}
+
+// Client is the HTTP plumbing shared by the provider adapters. It owns authentication, rate
+// limiting, 429 handling and conditional requests; create one per provider and process so every
+// caller shares the limiter.
+type Client struct {
+	baseURL    string
+	authHeader string
+	apiKey     string
+	httpClient *http.Client
+	limiter    Limiter
+	store      ResponseStore // Optional; without it every fetch is unconditional
+}
+
+// NewClient creates a new provider client that sends the API key in authHeader. With a store,
+// responses carrying an ETag or Last-Modified are kept and revalidated on the next fetch.
+func NewClient(baseURL, authHeader, apiKey string, limiter Limiter, store ResponseStore) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		authHeader: authHeader,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: requestTimeout},
+		limiter:    limiter,
+		store:      store,
+	}
+}
+
+// Get performs a GET request for path and decodes the JSON response into out. When a response
+// for the same URL is stored, the request is sent with If-None-Match/If-Modified-Since and a 304
+// is answered from the stored response, extending how long it is kept. A 304 counts against the
+// rate limit like any other request.
+func (c *Client) Get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		// The provider expects list parameters such as competitions=PL,PD with literal commas
+		endpoint += "?" + strings.ReplaceAll(query.Encode(), "%2C", ",")
+	}
+	store := c.store
+	if unstored, _ := ctx.Value(unstoredKey{}).(bool); unstored {
+		store = nil
+	}
+	var stored *models.CacheItem
+	if store != nil {
+		stored, _ = store.Get(endpoint)
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, endpoint, stored)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && stored != nil {
+		if err := store.Extend(endpoint); err != nil {
+			fmt.Printf("[WARN] Failed to extend stored provider response: %v\n", err)
+		}
+		if err := json.Unmarshal(stored.Value, out); err != nil {
+			return fmt.Errorf("failed to decode stored provider response: %w", err)
+		}
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return statusError(resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read provider response: %w", err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode provider response: %w", err)
+	}
+	if store != nil {
+		storeResponse(store, endpoint, body, resp.Header)
+	}
+	return nil
+}
+
+// storeResponse keeps a response for revalidation if the provider sent validators for it
+func storeResponse(store ResponseStore, key string, body []byte, header http.Header) {
+	etag := header.Get("ETag")
+	lastModified, _ := http.ParseTime(header.Get("Last-Modified"))
+	if etag == "" && lastModified.IsZero() {
+		return
+	}
+
+	item := models.CacheItem{
+		Key:          key,
+		Value:        body,
+		ETag:         etag,
+		LastModified: lastModified,
+	}
+	if err := store.Set(key, item); err != nil {
+		fmt.Printf("[WARN] Failed to store provider response: %v\n", err)
+	}
+}
+
+// do sends a rate-limited request, made conditional on the stored response if there is one. A 429
+// makes the shared limiter back off for every caller and is retried once the provider allows it;
+// the caller must close the response body.
+func (c *Client) do(ctx context.Context, method, endpoint string, stored *models.CacheItem) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create provider request: %w", err)
+		}
+		req.Header.Set(c.authHeader, c.apiKey)
+		req.Header.Set("Accept", "application/json")
+		if stored != nil {
+			if stored.ETag != "" {
+				req.Header.Set("If-None-Match", stored.ETag)
+			}
+			if !stored.LastModified.IsZero() {
+				req.Header.Set("If-Modified-Since", stored.LastModified.UTC().Format(http.TimeFormat))
+			}
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("provider request failed: %w", err)
+		}
+		c.observeQuota(resp.Header)
+
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return resp, nil
+		}
+
+		retryAfter := retryDelay(resp.Header)
+		c.limiter.Backoff(time.Now().Add(retryAfter))
+		err = statusError(resp)
+		resp.Body.Close()
+		if attempt >= maxRetries || retryAfter > maxRetryWait {
+			return nil, err
+		}
+		// The limiter now holds this request back until the provider allows it again
+	}
+}
+
+// observeQuota backs off ahead of time when the provider reports the quota is used up
+func (c *Client) observeQuota(header http.Header) {
+	available, err := strconv.Atoi(header.Get("X-Requests-Available-Minute"))
+	if err != nil || available > 0 {
+		return
+	}
+	if reset, err := strconv.Atoi(header.Get("X-RequestCounter-Reset")); err == nil && reset > 0 {
+		c.limiter.Backoff(time.Now().Add(time.Duration(reset) * time.Second))
+	}
+}
+
+// retryDelay reads how long to wait after a 429 from Retry-After (seconds or HTTP date),
+// falling back to the provider's counter reset
+func retryDelay(header http.Header) time.Duration {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			if delay := time.Until(at); delay > 0 {
+				return delay
+			}
+			return 0
+		}
+	}
+	if seconds, err := strconv.Atoi(header.Get("X-RequestCounter-Reset")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultRetryAfter
+}
+
+// statusError builds a StatusError from a non-OK response
+func statusError(resp *http.Response) *StatusError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	statusErr := &StatusError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		statusErr.RetryAfter = retryDelay(resp.Header)
+	}
+	return statusErr
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"libero-backend/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// memoryResponseStore keeps responses in a map and counts how often each was extended
+type memoryResponseStore struct {
+	items    map[string]models.CacheItem
+	extended map[string]int
+}
+
+func newMemoryResponseStore() *memoryResponseStore {
+	return &memoryResponseStore{items: make(map[string]models.CacheItem), extended: make(map[string]int)}
+}
+
+func (s *memoryResponseStore) Get(key string) (*models.CacheItem, error) {
+	item, ok := s.items[key]
+	if !ok {
+		return nil, errors.New("not stored")
+	}
+	return &item, nil
+}
+
+func (s *memoryResponseStore) Set(key string, item models.CacheItem) error {
+	s.items[key] = item
+	return nil
+}
+
+func (s *memoryResponseStore) Extend(key string) error {
+	s.extended[key]++
+	return nil
+}
+
+// etagServer answers with a fixed body and ETag, or a 304 when the request already has it
+func etagServer(t *testing.T, conditional *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			*conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id":7}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClientRevalidates(t *testing.T) {
+	var conditional int
+	server := etagServer(t, &conditional)
+	store := newMemoryResponseStore()
+	client := NewClient(server.URL, "X-Auth-Token", "key", NewTokenBucket(6000, 10), store)
+
+	for i := 0; i < 3; i++ {
+		var out struct{ ID int }
+		if err := client.Get(context.Background(), "/matches/7", nil, &out); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if out.ID != 7 {
+			t.Errorf("request %d decoded ID %d, want 7", i, out.ID)
+		}
+	}
+	if conditional != 2 {
+		t.Errorf("conditional requests = %d, want 2", conditional)
+	}
+	if extended := store.extended[server.URL+"/matches/7"]; extended != 2 {
+		t.Errorf("stored response extended %d times, want 2", extended)
+	}
+}
+
+func TestClientWithoutStore(t *testing.T) {
+	var conditional int
+	server := etagServer(t, &conditional)
+	store := newMemoryResponseStore()
+	client := NewClient(server.URL, "X-Auth-Token", "key", NewTokenBucket(6000, 10), store)
+
+	ctx := WithoutStore(context.Background())
+	for i := 0; i < 2; i++ {
+		var out struct{ ID int }
+		if err := client.Get(ctx, "/matches", nil, &out); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+	if conditional != 0 || len(store.items) != 0 {
+		t.Errorf("conditional requests = %d, stored responses = %d; want neither", conditional, len(store.items))
+	}
+}
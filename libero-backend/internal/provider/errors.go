@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrNotFound    = errors.New("provider resource not found")
+	ErrRateLimited = errors.New("provider rate limit exceeded")
+)
+
+// StatusError is returned when the provider answers with an unexpected status code.
+// It matches ErrNotFound and ErrRateLimited with errors.Is.
+type StatusError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration // Set for 429 responses
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("provider returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// Is lets errors.Is match status errors against the sentinel errors
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
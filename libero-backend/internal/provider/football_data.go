@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"libero-backend/internal/models"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// FootballDataName identifies the football-data.org provider in routes and logs
+const FootballDataName = "football-data"
+
+// FootballData is the football-data.org v4 adapter. Its payloads are the shape of the
+// response models, so they are decoded as they are.
+type FootballData struct {
+	client *Client
+}
+
+// NewFootballData creates a football-data.org adapter
+func NewFootballData(baseURL, apiKey string, limiter Limiter, store ResponseStore) *FootballData {
+	return &FootballData{
+		client: NewClient(baseURL, "X-Auth-Token", apiKey, limiter, store),
+	}
+}
+
+// Name returns the provider name
+func (p *FootballData) Name() string {
+	return FootballDataName
+}
+
+// Competition retrieves a competition with its area and current season
+func (p *FootballData) Competition(ctx context.Context, code string) (*models.CompetitionDetailResponse, error) {
+	var competition models.CompetitionDetailResponse
+	if err := p.client.Get(ctx, fmt.Sprintf("/competitions/%s", footballDataCode(code)), nil, &competition); err != nil {
+		return nil, err
+	}
+	competition.Code = code
+	return &competition, nil
+}
+
+// CompetitionSeasons retrieves every season of a competition, most recent first, which
+// football-data.org lists with the competition
+func (p *FootballData) CompetitionSeasons(ctx context.Context, code string) ([]models.SeasonResponse, error) {
+	competition, err := p.Competition(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return competition.Seasons, nil
+}
+
+// CompetitionTeams retrieves the teams of a competition's current season, including their squads
+func (p *FootballData) CompetitionTeams(ctx context.Context, code string) (*models.CompetitionTeamsResponse, error) {
+	var teams models.CompetitionTeamsResponse
+	if err := p.client.Get(ctx, fmt.Sprintf("/competitions/%s/teams", footballDataCode(code)), nil, &teams); err != nil {
+		return nil, err
+	}
+	return &teams, nil
+}
+
+// Standings retrieves a competition's standings of a season
+func (p *FootballData) Standings(ctx context.Context, code string, season int) (*models.StandingsResponse, error) {
+	var standings models.StandingsResponse
+	if err := p.client.Get(ctx, fmt.Sprintf("/competitions/%s/standings", footballDataCode(code)), footballDataSeasonParams(season), &standings); err != nil {
+		return nil, err
+	}
+	return &standings, nil
+}
+
+// Scorers retrieves a competition's top scorers of a season
+func (p *FootballData) Scorers(ctx context.Context, code string, season int) (*models.ScorersResponse, error) {
+	var scorers models.ScorersResponse
+	if err := p.client.Get(ctx, fmt.Sprintf("/competitions/%s/scorers", footballDataCode(code)), footballDataSeasonParams(season), &scorers); err != nil {
+		return nil, err
+	}
+	return &scorers, nil
+}
+
+// Matches retrieves the matches passing the query
+func (p *FootballData) Matches(ctx context.Context, query MatchQuery) (*models.MatchesResponse, error) {
+	var matches models.MatchesResponse
+	if err := p.client.Get(ctx, "/matches", footballDataMatchParams(query), &matches); err != nil {
+		return nil, err
+	}
+	for i := range matches.Matches {
+		matches.Matches[i].Competition.Code = ourCode(matches.Matches[i].Competition.Code)
+	}
+	return &matches, nil
+}
+
+// CompetitionMatches retrieves every match of a competition's current season
+func (p *FootballData) CompetitionMatches(ctx context.Context, code string) (*models.MatchesResponse, error) {
+	var matches models.MatchesResponse
+	if err := p.client.Get(ctx, fmt.Sprintf("/competitions/%s/matches", footballDataCode(code)), nil, &matches); err != nil {
+		return nil, err
+	}
+	for i := range matches.Matches {
+		matches.Matches[i].Competition.Code = ourCode(matches.Matches[i].Competition.Code)
+	}
+	return &matches, nil
+}
+
+// Match retrieves a single match
+func (p *FootballData) Match(ctx context.Context, id int) (*models.MatchResponse, error) {
+	var match models.MatchResponse
+	if err := p.client.Get(ctx, fmt.Sprintf("/matches/%d", id), nil, &match); err != nil {
+		return nil, err
+	}
+	match.Competition.Code = ourCode(match.Competition.Code)
+	return &match, nil
+}
+
+// Team retrieves a team with its area, venue and squad
+func (p *FootballData) Team(ctx context.Context, id int) (*models.TeamDetailResponse, error) {
+	var team models.TeamDetailResponse
+	if err := p.client.Get(ctx, fmt.Sprintf("/teams/%d", id), nil, &team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// Person retrieves a player or staff member
+func (p *FootballData) Person(ctx context.Context, id int) (*models.PersonResponse, error) {
+	var person models.PersonResponse
+	if err := p.client.Get(ctx, fmt.Sprintf("/persons/%d", id), nil, &person); err != nil {
+		return nil, err
+	}
+	return &person, nil
+}
+
+// footballDataCode maps our competition codes to football-data.org's where they differ
+func footballDataCode(code string) string {
+	if code == "EL" {
+		return "UEL"
+	}
+	return code
+}
+
+// ourCode maps a football-data.org competition code back to ours
+func ourCode(code string) string {
+	if code == "UEL" {
+		return "EL"
+	}
+	return code
+}
+
+// footballDataSeasonParams selects a season by the year it starts in, or none for the current one
+func footballDataSeasonParams(season int) url.Values {
+	if season == 0 {
+		return nil
+	}
+	return url.Values{"season": {strconv.Itoa(season)}}
+}
+
+// footballDataMatchParams encodes a match query as /matches parameters
+func footballDataMatchParams(query MatchQuery) url.Values {
+	values := url.Values{}
+	if len(query.IDs) > 0 {
+		ids := make([]string, 0, len(query.IDs))
+		for _, id := range query.IDs {
+			ids = append(ids, strconv.Itoa(id))
+		}
+		values.Set("ids", strings.Join(ids, ","))
+	}
+	if len(query.Competitions) > 0 {
+		codes := make([]string, 0, len(query.Competitions))
+		for _, code := range query.Competitions {
+			codes = append(codes, footballDataCode(code))
+		}
+		values.Set("competitions", strings.Join(codes, ","))
+	}
+	if query.Date != "" {
+		values.Set("date", query.Date)
+	}
+	if query.DateFrom != "" {
+		values.Set("dateFrom", query.DateFrom)
+	}
+	if query.DateTo != "" {
+		values.Set("dateTo", query.DateTo)
+	}
+	if query.Status != "" {
+		values.Set("status", query.Status)
+	}
+	return values
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter paces requests to the provider. A single Limiter is shared by every caller in the
+// process so that together they stay within the provider's quota.
+type Limiter interface {
+	// Wait blocks until a request may be made or the context is done
+	Wait(ctx context.Context) error
+	// Backoff holds back all requests until the given time, e.g. after a 429
+	Backoff(until time.Time)
+}
+
+// TokenBucket is an in-process Limiter that allows bursts of up to capacity requests
+// and refills at a steady rate
+type TokenBucket struct {
+	mutex        sync.Mutex
+	tokens       float64
+	capacity     float64
+	interval     time.Duration // Time to refill a single token
+	lastRefill   time.Time
+	blockedUntil time.Time
+}
+
+// NewTokenBucket creates a token bucket allowing requestsPerMinute on average and bursts of up to burst requests
+func NewTokenBucket(requestsPerMinute, burst int) *TokenBucket {
+	if requestsPerMinute <= 0 {
+		requestsPerMinute = 1
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &TokenBucket{
+		tokens:     float64(burst),
+		capacity:   float64(burst),
+		interval:   time.Minute / time.Duration(requestsPerMinute),
+		lastRefill: time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or the context is done
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		delay := b.take(time.Now())
+		if delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Backoff holds back all requests until the given time
+func (b *TokenBucket) Backoff(until time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if until.After(b.blockedUntil) {
+		b.blockedUntil = until
+		// Start from an empty bucket so waiting callers don't all fire at once when the backoff ends
+		b.tokens = 0
+		b.lastRefill = until
+	}
+}
+
+// take consumes a token if one is available and otherwise returns how long to wait before trying again
+func (b *TokenBucket) take(now time.Time) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if now.Before(b.blockedUntil) {
+		return b.blockedUntil.Sub(now)
+	}
+
+	if elapsed := now.Sub(b.lastRefill); elapsed > 0 {
+		b.tokens += float64(elapsed) / float64(b.interval)
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.lastRefill = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) * float64(b.interval))
+}
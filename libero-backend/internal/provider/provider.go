@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"libero-backend/internal/models"
+)
+
+// ErrUnsupported is returned when a provider can't serve a request, e.g. a competition it doesn't cover
+var ErrUnsupported = errors.New("request not supported by provider")
+
+// Capability is a group of provider requests that can be routed to a provider of its own
+type Capability string
+
+// Provider capabilities
+const (
+	CapabilityCompetitions Capability = "competitions"
+	CapabilityTeams        Capability = "teams"
+	CapabilityStandings    Capability = "standings"
+	CapabilityScorers      Capability = "scorers"
+	CapabilityMatches      Capability = "matches"
+	CapabilityPersons      Capability = "persons"
+)
+
+// Capabilities lists every capability
+var Capabilities = []Capability{
+	CapabilityCompetitions,
+	CapabilityTeams,
+	CapabilityStandings,
+	CapabilityScorers,
+	CapabilityMatches,
+	CapabilityPersons,
+}
+
+// FootballDataProvider is a source of football data. Adapters map their provider's payloads
+// into the response models, so nothing outside this package sees a provider's own format.
+// Competitions are identified by our codes (PL, PD, SA, BL1, FL1, CL, EL); teams, matches and
+// persons by the provider's own IDs. Seasons are identified by the year they start in, with 0
+// for the current one.
+type FootballDataProvider interface {
+	Name() string
+	Competition(ctx context.Context, code string) (*models.CompetitionDetailResponse, error)
+	CompetitionSeasons(ctx context.Context, code string) ([]models.SeasonResponse, error)
+	CompetitionTeams(ctx context.Context, code string) (*models.CompetitionTeamsResponse, error)
+	Standings(ctx context.Context, code string, season int) (*models.StandingsResponse, error)
+	Scorers(ctx context.Context, code string, season int) (*models.ScorersResponse, error)
+	Matches(ctx context.Context, query MatchQuery) (*models.MatchesResponse, error)
+	CompetitionMatches(ctx context.Context, code string) (*models.MatchesResponse, error)
+	Match(ctx context.Context, id int) (*models.MatchResponse, error)
+	Team(ctx context.Context, id int) (*models.TeamDetailResponse, error)
+	Person(ctx context.Context, id int) (*models.PersonResponse, error)
+}
+
+// MatchQuery filters a match listing. Zero values are ignored.
+type MatchQuery struct {
+	IDs          []int
+	Competitions []string // Competition codes
+	Date         string   // YYYY-MM-DD
+	DateFrom     string   // YYYY-MM-DD
+	DateTo       string   // YYYY-MM-DD, inclusive
+	Status       string   // e.g. FINISHED
+}
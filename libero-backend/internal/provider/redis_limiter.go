@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisTakeScript is TokenBucket.take run atomically in Redis, on Redis's clock so every process
+// agrees on the time. It returns 0 when a token was taken, or the milliseconds to wait before trying again.
+var redisTakeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'last_refill', 'blocked_until')
+local tokens = tonumber(state[1]) or capacity
+local lastRefill = tonumber(state[2]) or now
+local blockedUntil = tonumber(state[3]) or 0
+if now < blockedUntil then
+	return blockedUntil - now
+end
+
+if now > lastRefill then
+	tokens = math.min(capacity, tokens + (now - lastRefill) / interval)
+	lastRefill = now
+end
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) * interval)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'last_refill', lastRefill)
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity * interval) + 60000)
+return wait
+`)
+
+// redisBackoffScript is TokenBucket.Backoff run atomically in Redis. ARGV[1] is the backoff in
+// milliseconds from now.
+var redisBackoffScript = redis.NewScript(`
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local blockedUntil = now + tonumber(ARGV[1])
+
+local current = tonumber(redis.call('HGET', KEYS[1], 'blocked_until')) or 0
+if blockedUntil > current then
+	redis.call('HSET', KEYS[1], 'blocked_until', blockedUntil, 'tokens', '0', 'last_refill', blockedUntil)
+	redis.call('PEXPIREAT', KEYS[1], blockedUntil + 60000)
+end
+return 0
+`)
+
+// RedisTokenBucket is a Limiter whose bucket lives in Redis, so every backend process shares one
+// quota per provider. While Redis can't be reached it falls back to an in-process TokenBucket.
+type RedisTokenBucket struct {
+	client   *redis.Client
+	key      string
+	capacity int
+	interval time.Duration // Time to refill a single token
+	fallback *TokenBucket
+}
+
+// NewRedisTokenBucket creates a shared token bucket named after the provider, allowing
+// requestsPerMinute on average and bursts of up to burst requests
+func NewRedisTokenBucket(client *redis.Client, name string, requestsPerMinute, burst int) *RedisTokenBucket {
+	if requestsPerMinute <= 0 {
+		requestsPerMinute = 1
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &RedisTokenBucket{
+		client:   client,
+		key:      "libero:ratelimit:" + name,
+		capacity: burst,
+		interval: time.Minute / time.Duration(requestsPerMinute),
+		fallback: NewTokenBucket(requestsPerMinute, burst),
+	}
+}
+
+// Wait blocks until a token is available or the context is done
+func (b *RedisTokenBucket) Wait(ctx context.Context) error {
+	for {
+		waitMillis, err := redisTakeScript.Run(ctx, b.client, []string{b.key}, b.capacity, b.interval.Milliseconds()).Int64()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			fmt.Printf("[WARN] Shared rate limiter unavailable, limiting in-process: %v\n", err)
+			return b.fallback.Wait(ctx)
+		}
+		if waitMillis <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(time.Duration(waitMillis) * time.Millisecond)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Backoff holds back all requests of every process until the given time
+func (b *RedisTokenBucket) Backoff(until time.Time) {
+	b.fallback.Backoff(until)
+
+	backoff := time.Until(until).Milliseconds()
+	if backoff <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := redisBackoffScript.Run(ctx, b.client, []string{b.key}, backoff).Err(); err != nil {
+		fmt.Printf("[WARN] Failed to share rate limit backoff: %v\n", err)
+	}
+}
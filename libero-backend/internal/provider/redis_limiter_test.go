@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestRedis starts an in-memory Redis on a fixed clock and returns a client for it
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	server := miniredis.RunT(t)
+	server.SetTime(time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC))
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+// take runs the token bucket script once and returns how long it says to wait
+func take(t *testing.T, bucket *RedisTokenBucket) time.Duration {
+	t.Helper()
+	waitMillis, err := redisTakeScript.Run(context.Background(), bucket.client, []string{bucket.key}, bucket.capacity, bucket.interval.Milliseconds()).Int64()
+	if err != nil {
+		t.Fatalf("take script error = %v", err)
+	}
+	return time.Duration(waitMillis) * time.Millisecond
+}
+
+func TestRedisTokenBucketTake(t *testing.T) {
+	server, client := newTestRedis(t)
+	bucket := NewRedisTokenBucket(client, "test", 60, 2)
+	start := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	// The burst is served at once, then a token comes every second
+	for i := 0; i < 2; i++ {
+		if wait := take(t, bucket); wait != 0 {
+			t.Fatalf("take %d waits %v, want none within the burst", i, wait)
+		}
+	}
+	if wait := take(t, bucket); wait != time.Second {
+		t.Errorf("take past the burst waits %v, want 1s", wait)
+	}
+
+	server.SetTime(start.Add(500 * time.Millisecond))
+	if wait := take(t, bucket); wait != 500*time.Millisecond {
+		t.Errorf("take half a token later waits %v, want 500ms", wait)
+	}
+	server.SetTime(start.Add(time.Second))
+	if wait := take(t, bucket); wait != 0 {
+		t.Errorf("take a token later waits %v, want none", wait)
+	}
+
+	// Other processes share the same bucket
+	other := NewRedisTokenBucket(client, "test", 60, 2)
+	if wait := take(t, other); wait != time.Second {
+		t.Errorf("take from another process waits %v, want 1s", wait)
+	}
+	if ttl := server.TTL(bucket.key); ttl <= 0 {
+		t.Errorf("bucket TTL = %v, want it to expire", ttl)
+	}
+}
+
+func TestRedisTokenBucketBackoff(t *testing.T) {
+	server, client := newTestRedis(t)
+	bucket := NewRedisTokenBucket(client, "test", 60, 5)
+	start := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	// Backoff is relative to the local clock, the script applies it on Redis's
+	bucket.Backoff(time.Now().Add(10 * time.Second))
+	wait := take(t, bucket)
+	if wait <= 9*time.Second || wait > 10*time.Second {
+		t.Errorf("take during the backoff waits %v, want about 10s", wait)
+	}
+
+	// A shorter backoff doesn't cut the longer one short
+	bucket.Backoff(time.Now().Add(2 * time.Second))
+	if again := take(t, bucket); again != wait {
+		t.Errorf("take after a shorter backoff waits %v, want %v", again, wait)
+	}
+
+	// The bucket starts empty once the backoff is over
+	server.SetTime(start.Add(wait))
+	if wait := take(t, bucket); wait != time.Second {
+		t.Errorf("take as the backoff ends waits %v, want 1s", wait)
+	}
+}
+
+func TestRedisTokenBucketFallback(t *testing.T) {
+	server, client := newTestRedis(t)
+	bucket := NewRedisTokenBucket(client, "test", 60, 1)
+	server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := bucket.Wait(ctx); err != nil {
+		t.Fatalf("Wait() without Redis error = %v, want the in-process bucket to serve it", err)
+	}
+	if delay := bucket.fallback.take(time.Now()); delay == 0 {
+		t.Error("in-process bucket has a token left, want the fallback to have used it")
+	}
+}
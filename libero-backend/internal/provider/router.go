@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"libero-backend/internal/models"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRoutes is the provider order per capability used unless configured otherwise. Only
+// competition metadata fails over: team, match and person IDs are provider-scoped and are
+// persisted (matches, picks, catalogue) or joined against persisted ones (standings forms, the
+// feed's followed teams), so mixing providers there would mix up IDs.
+var DefaultRoutes = map[Capability][]string{
+	CapabilityCompetitions: {FootballDataName, APIFootballName},
+	CapabilityStandings:    {FootballDataName},
+	CapabilityScorers:      {FootballDataName},
+	CapabilityTeams:        {FootballDataName},
+	CapabilityMatches:      {FootballDataName},
+	CapabilityPersons:      {FootballDataName},
+}
+
+// idScopedCapabilities are the capabilities whose team, match or person IDs are persisted or
+// joined against persisted ones, so they are kept to the providers of their default routes
+var idScopedCapabilities = map[Capability]bool{
+	CapabilityStandings: true,
+	CapabilityScorers:   true,
+	CapabilityTeams:     true,
+	CapabilityMatches:   true,
+	CapabilityPersons:   true,
+}
+
+// Router is a FootballDataProvider that sends each capability to an ordered list of providers.
+// When a provider fails or is rate limited the next one is tried; a rate limited provider is
+// skipped until its Retry-After has passed. Not found answers and cancelled contexts are
+// returned as they are.
+type Router struct {
+	routes map[Capability][]FootballDataProvider
+
+	mutex     sync.Mutex
+	cooldowns map[string]time.Time // Rate limited providers by name, until they may be used again
+}
+
+// NewRouter creates a router over providers. Routes name the providers of each capability in
+// order; names without a registered provider are skipped, and every capability must be left
+// with at least one provider.
+func NewRouter(providers []FootballDataProvider, routes map[Capability][]string) (*Router, error) {
+	byName := make(map[string]FootballDataProvider, len(providers))
+	for _, p := range providers {
+		byName[p.Name()] = p
+	}
+
+	r := &Router{
+		routes:    make(map[Capability][]FootballDataProvider, len(Capabilities)),
+		cooldowns: make(map[string]time.Time),
+	}
+	for _, capability := range Capabilities {
+		for _, name := range routes[capability] {
+			if p, ok := byName[name]; ok {
+				r.routes[capability] = append(r.routes[capability], p)
+			}
+		}
+		if len(r.routes[capability]) == 0 {
+			return nil, fmt.Errorf("no provider available for %s", capability)
+		}
+	}
+	return r, nil
+}
+
+// ParseRoutes reads routes in the form "standings=api-football|football-data;matches=football-data"
+// and lays them over DefaultRoutes. Capabilities carrying team, match or person IDs can't be
+// routed to providers outside their default routes, whose IDs are the ones persisted.
+func ParseRoutes(spec string) (map[Capability][]string, error) {
+	routes := make(map[Capability][]string, len(DefaultRoutes))
+	for capability, names := range DefaultRoutes {
+		routes[capability] = names
+	}
+
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		rawCapability, names, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid provider route %q", entry)
+		}
+		capability := Capability(strings.TrimSpace(rawCapability))
+		defaults, known := DefaultRoutes[capability]
+		if !known {
+			return nil, fmt.Errorf("unknown provider capability %q", rawCapability)
+		}
+
+		var order []string
+		for _, name := range strings.Split(names, "|") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			if idScopedCapabilities[capability] && !slices.Contains(defaults, name) {
+				return nil, fmt.Errorf("%s can't be routed to %s: its IDs don't match the stored ones", capability, name)
+			}
+			order = append(order, name)
+		}
+		routes[capability] = order
+	}
+	return routes, nil
+}
+
+// Name returns the names of the providers behind the router
+func (r *Router) Name() string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, capability := range Capabilities {
+		for _, p := range r.routes[capability] {
+			if !seen[p.Name()] {
+				seen[p.Name()] = true
+				names = append(names, p.Name())
+			}
+		}
+	}
+	return strings.Join(names, "+")
+}
+
+// Competition retrieves a competition from the first provider able to serve it
+func (r *Router) Competition(ctx context.Context, code string) (*models.CompetitionDetailResponse, error) {
+	return route(ctx, r, CapabilityCompetitions, func(p FootballDataProvider) (*models.CompetitionDetailResponse, error) {
+		return p.Competition(ctx, code)
+	})
+}
+
+// CompetitionSeasons retrieves a competition's seasons from the first provider able to serve them
+func (r *Router) CompetitionSeasons(ctx context.Context, code string) ([]models.SeasonResponse, error) {
+	return route(ctx, r, CapabilityCompetitions, func(p FootballDataProvider) ([]models.SeasonResponse, error) {
+		return p.CompetitionSeasons(ctx, code)
+	})
+}
+
+// CompetitionTeams retrieves a competition's teams from the first provider able to serve them
+func (r *Router) CompetitionTeams(ctx context.Context, code string) (*models.CompetitionTeamsResponse, error) {
+	return route(ctx, r, CapabilityTeams, func(p FootballDataProvider) (*models.CompetitionTeamsResponse, error) {
+		return p.CompetitionTeams(ctx, code)
+	})
+}
+
+// Standings retrieves a competition's standings from the first provider able to serve them
+func (r *Router) Standings(ctx context.Context, code string, season int) (*models.StandingsResponse, error) {
+	return route(ctx, r, CapabilityStandings, func(p FootballDataProvider) (*models.StandingsResponse, error) {
+		return p.Standings(ctx, code, season)
+	})
+}
+
+// Scorers retrieves a competition's top scorers from the first provider able to serve them
+func (r *Router) Scorers(ctx context.Context, code string, season int) (*models.ScorersResponse, error) {
+	return route(ctx, r, CapabilityScorers, func(p FootballDataProvider) (*models.ScorersResponse, error) {
+		return p.Scorers(ctx, code, season)
+	})
+}
+
+// Matches retrieves matches from the first provider able to serve them
+func (r *Router) Matches(ctx context.Context, query MatchQuery) (*models.MatchesResponse, error) {
+	return route(ctx, r, CapabilityMatches, func(p FootballDataProvider) (*models.MatchesResponse, error) {
+		return p.Matches(ctx, query)
+	})
+}
+
+// CompetitionMatches retrieves a competition's season matches from the first provider able to serve them
+func (r *Router) CompetitionMatches(ctx context.Context, code string) (*models.MatchesResponse, error) {
+	return route(ctx, r, CapabilityMatches, func(p FootballDataProvider) (*models.MatchesResponse, error) {
+		return p.CompetitionMatches(ctx, code)
+	})
+}
+
+// Match retrieves a match from the first provider able to serve it
+func (r *Router) Match(ctx context.Context, id int) (*models.MatchResponse, error) {
+	return route(ctx, r, CapabilityMatches, func(p FootballDataProvider) (*models.MatchResponse, error) {
+		return p.Match(ctx, id)
+	})
+}
+
+// Team retrieves a team from the first provider able to serve it
+func (r *Router) Team(ctx context.Context, id int) (*models.TeamDetailResponse, error) {
+	return route(ctx, r, CapabilityTeams, func(p FootballDataProvider) (*models.TeamDetailResponse, error) {
+		return p.Team(ctx, id)
+	})
+}
+
+// Person retrieves a person from the first provider able to serve them
+func (r *Router) Person(ctx context.Context, id int) (*models.PersonResponse, error) {
+	return route(ctx, r, CapabilityPersons, func(p FootballDataProvider) (*models.PersonResponse, error) {
+		return p.Person(ctx, id)
+	})
+}
+
+// route calls the providers of a capability in order until one succeeds. Providers cooling down
+// after a 429 are only tried when every provider is.
+func route[T any](ctx context.Context, r *Router, capability Capability, call func(p FootballDataProvider) (T, error)) (T, error) {
+	var zero T
+	providers := r.available(capability)
+
+	var lastErr error
+	for i, p := range providers {
+		result, err := call(p)
+		if err == nil {
+			return result, nil
+		}
+		if ctx.Err() != nil || errors.Is(err, ErrNotFound) {
+			return zero, err
+		}
+
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == 429 {
+			r.coolDown(p.Name(), statusErr.RetryAfter)
+		}
+		if i < len(providers)-1 {
+			fmt.Printf("[WARN] %s request to %s failed, failing over to %s: %v\n", capability, p.Name(), providers[i+1].Name(), err)
+		}
+		lastErr = err
+	}
+	return zero, lastErr
+}
+
+// available returns the providers of a capability, leaving out those cooling down unless all are
+func (r *Router) available(capability Capability) []FootballDataProvider {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	providers := make([]FootballDataProvider, 0, len(r.routes[capability]))
+	for _, p := range r.routes[capability] {
+		if until, ok := r.cooldowns[p.Name()]; ok && now.Before(until) {
+			continue
+		}
+		providers = append(providers, p)
+	}
+	if len(providers) == 0 {
+		return r.routes[capability]
+	}
+	return providers
+}
+
+// coolDown skips a rate limited provider for retryAfter
+func (r *Router) coolDown(name string, retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		retryAfter = defaultRetryAfter
+	}
+	r.mutex.Lock()
+	r.cooldowns[name] = time.Now().Add(retryAfter)
+	r.mutex.Unlock()
+}
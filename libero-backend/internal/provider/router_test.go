@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"libero-backend/internal/models"
+	"slices"
+	"testing"
+)
+
+func TestParseRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[Capability][]string
+		wantErr bool
+	}{
+		{name: "defaults", spec: "", want: DefaultRoutes},
+		{
+			name: "competition failover",
+			spec: "competitions=api-football|football-data",
+			want: map[Capability][]string{
+				CapabilityCompetitions: {APIFootballName, FootballDataName},
+				CapabilityStandings:    {FootballDataName},
+				CapabilityMatches:      {FootballDataName},
+			},
+		},
+		{name: "standings failover", spec: "standings=football-data|api-football", wantErr: true},
+		{name: "scorers elsewhere", spec: "scorers=api-football", wantErr: true},
+		{name: "matches stay on their provider", spec: "matches=football-data", want: map[Capability][]string{CapabilityMatches: {FootballDataName}}},
+		{name: "matches failover", spec: "matches=football-data|api-football", wantErr: true},
+		{name: "teams elsewhere", spec: "teams=api-football", wantErr: true},
+		{name: "persons elsewhere", spec: "persons=api-football|football-data", wantErr: true},
+		{name: "unknown capability", spec: "fixtures=football-data", wantErr: true},
+		{name: "malformed", spec: "standings", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes, err := ParseRoutes(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseRoutes(%q) = %v, want an error", tt.spec, routes)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRoutes(%q) error = %v", tt.spec, err)
+			}
+			for capability, want := range tt.want {
+				if got := routes[capability]; !slices.Equal(got, want) {
+					t.Errorf("%s routes = %v, want %v", capability, got, want)
+				}
+			}
+		})
+	}
+}
+
+// tableProvider serves standings whose team IDs are its own, or fails with err
+type tableProvider struct {
+	FootballDataProvider
+	name    string
+	teamIDs []int
+	err     error
+}
+
+func (p *tableProvider) Name() string {
+	return p.name
+}
+
+func (p *tableProvider) Standings(ctx context.Context, code string, season int) (*models.StandingsResponse, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	group := models.StandingsGroupResponse{Type: models.StandingsTypeTotal}
+	for _, id := range p.teamIDs {
+		group.Table = append(group.Table, models.StandingsRowResponse{Team: models.TeamResponse{ID: id}})
+	}
+	return &models.StandingsResponse{Standings: []models.StandingsGroupResponse{group}}, nil
+}
+
+func TestRouterStandingsKeepTeamIDs(t *testing.T) {
+	footballData := &tableProvider{name: FootballDataName, teamIDs: []int{57, 61}}
+	apiFootball := &tableProvider{name: APIFootballName, teamIDs: []int{42, 49}}
+	router, err := NewRouter([]FootballDataProvider{footballData, apiFootball}, DefaultRoutes)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+
+	standings, err := router.Standings(context.Background(), "PL", 0)
+	if err != nil {
+		t.Fatalf("Standings() error = %v", err)
+	}
+	for _, row := range standings.Standings[0].Table {
+		if !slices.Contains(footballData.teamIDs, row.Team.ID) {
+			t.Errorf("table has team %d, want football-data IDs only", row.Team.ID)
+		}
+	}
+
+	// A failing provider isn't replaced by one whose table has other team IDs
+	footballData.err = &StatusError{StatusCode: 500}
+	standings, err = router.Standings(context.Background(), "PL", 0)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Errorf("Standings() while football-data fails = %v, %v; want its error rather than another provider's table", standings, err)
+	}
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// cacheInvalidationChannel carries the keys one process changed to the memory tiers of the others
+const cacheInvalidationChannel = "libero:cache:invalidate"
+
+// ShareCacheInvalidations keeps the memory tiers of several backend processes coherent: keys
+// written or deleted through tier are published over Redis, and keys published by other
+// processes are evicted from tier. It runs until ctx is done.
+func ShareCacheInvalidations(ctx context.Context, client *redis.Client, tier TieredCacheRepository) {
+	origin := make([]byte, 8)
+	_, _ = rand.Read(origin)
+	processID := hex.EncodeToString(origin)
+
+	tier.OnInvalidate(func(key string) {
+		publishCtx, cancel := context.WithTimeout(ctx, redisTimeout)
+		defer cancel()
+		if err := client.Publish(publishCtx, cacheInvalidationChannel, processID+" "+key).Err(); err != nil {
+			fmt.Printf("[WARN] Failed to publish cache invalidation for %s: %v\n", key, err)
+		}
+	})
+
+	subscription := client.Subscribe(ctx, cacheInvalidationChannel)
+	go func() {
+		defer subscription.Close()
+		messages := subscription.Channel()
+		for {
+			select {
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				sender, key, found := strings.Cut(message.Payload, " ")
+				if found && sender != processID {
+					tier.Evict(key)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"libero-backend/internal/models"
+	"testing"
+	"time"
+)
+
+func TestShareCacheInvalidations(t *testing.T) {
+	_, client := newTestRedis(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Two processes with memory tiers over one Redis
+	shared := NewRedisCacheRepository(client)
+	first := NewMemoryCacheRepository(shared, 10, time.Hour)
+	second := NewMemoryCacheRepository(shared, 10, time.Hour)
+	ShareCacheInvalidations(ctx, client, first)
+	ShareCacheInvalidations(ctx, client, second)
+	waitFor(t, func() bool {
+		subscribers, err := client.PubSubNumSub(ctx, cacheInvalidationChannel).Result()
+		return err == nil && subscribers[cacheInvalidationChannel] == 2
+	})
+
+	key := "standings:PL"
+	set := func(repo CacheRepository, value string) {
+		item := models.CacheItem{Key: key, Value: []byte(value), ExpiresAt: time.Now().Add(time.Hour)}
+		if err := repo.SetWithMetadata(key, item); err != nil {
+			t.Fatalf("SetWithMetadata() error = %v", err)
+		}
+	}
+	get := func(repo CacheRepository) string {
+		item, err := repo.Get(key)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		return string(item.Value)
+	}
+
+	set(first, "v1")
+	if got := get(second); got != "v1" {
+		t.Fatalf("second process reads %q, want v1", got)
+	}
+
+	// The second process serves its memory copy until the first one's change evicts it
+	set(first, "v2")
+	waitFor(t, func() bool { return get(second) == "v2" })
+
+	if err := first.Delete(key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	waitFor(t, func() bool {
+		_, err := second.Get(key)
+		return err != nil
+	})
+}
+
+// waitFor polls condition until it holds, failing the test after a few seconds
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
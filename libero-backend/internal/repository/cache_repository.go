@@ -12,20 +12,13 @@ import (
 type CacheRepository interface {
 	// Generic cache operations
 	Get(key string) (*models.CacheItem, error)
+	GetIgnoringExpiry(key string) (*models.CacheItem, error)
 	GetWithVersion(key string, version string) (*models.CacheItem, error)
 	Set(key string, value []byte, ttl time.Duration) error
 	SetWithMetadata(key string, item models.CacheItem) error
 	UpdateVersion(key string, version string) error
-
-	// Fixtures operations
-	GetCachedFixtures(competitionCode, dataType string) (*models.CachedFixtures, error)
-	StoreCachedFixtures(competitionCode, dataType string, data models.JSONB, ttl time.Duration) error
-	GetCachedFixturesIgnoringExpiry(competitionCode, dataType string) (*models.CachedFixtures, error)
-
-	// Today's fixtures operations
-	GetCachedTodayFixtures() (*models.CachedTodayFixtures, error)
-	StoreCachedTodayFixtures(data models.JSONB, ttl time.Duration) error
-	GetCachedTodayFixturesIgnoringExpiry() (*models.CachedTodayFixtures, error)
+	ExtendExpiry(key string, ttl time.Duration) error
+	Delete(key string) error
 
 	// Common operations
 	CleanExpiredCache() error
@@ -41,113 +34,28 @@ func NewCacheRepository(db *gorm.DB) CacheRepository {
 	return &cacheRepository{db: db}
 }
 
-// GetCachedFixtures retrieves cached fixtures data for a competition if not expired
-func (r *cacheRepository) GetCachedFixtures(competitionCode, dataType string) (*models.CachedFixtures, error) {
-	var cache models.CachedFixtures
-
-	err := r.db.Where("competition_code = ? AND data_type = ? AND expires_at > ?",
-		competitionCode, dataType, time.Now()).First(&cache).Error
-
-	if err != nil {
-		return nil, err // Return nil if not found or error
-	}
-
-	return &cache, nil
-}
-
-// GetCachedFixturesIgnoringExpiry retrieves cached fixtures data even if expired
-func (r *cacheRepository) GetCachedFixturesIgnoringExpiry(competitionCode, dataType string) (*models.CachedFixtures, error) {
-	var cache models.CachedFixtures
-
-	err := r.db.Where("competition_code = ? AND data_type = ?",
-		competitionCode, dataType).First(&cache).Error
-
-	if err != nil {
-		return nil, err // Return nil if not found or error
-	}
-
-	return &cache, nil
-}
-
-// StoreCachedFixtures stores fixtures data with an expiration time
-func (r *cacheRepository) StoreCachedFixtures(competitionCode, dataType string, data models.JSONB, ttl time.Duration) error {
-	expiresAt := time.Now().Add(ttl)
-	cache := models.CachedFixtures{
-		CompetitionCode: competitionCode,
-		DataType:        dataType,
-		Data:            data,
-		CachedData: models.CachedData{
-			ExpiresAt: expiresAt,
-		},
-	}
-	// Use upsert to avoid duplicate key errors (only one row per competitionCode+dataType)
-	return r.db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "competition_code"}, {Name: "data_type"}},
-		DoUpdates: clause.AssignmentColumns([]string{"data", "expires_at", "updated_at"}),
-	}).Create(&cache).Error
-}
-
-// GetCachedTodayFixtures retrieves cached today's fixtures data if not expired
-func (r *cacheRepository) GetCachedTodayFixtures() (*models.CachedTodayFixtures, error) {
-	var cache models.CachedTodayFixtures
-
-	err := r.db.Where("expires_at > ?", time.Now()).First(&cache).Error
-
-	if err != nil {
-		return nil, err // Return nil if not found or error
-	}
-
-	return &cache, nil
+// CleanExpiredCache removes all expired cache entries
+func (r *cacheRepository) CleanExpiredCache() error {
+	return r.db.Where("expires_at < ?", time.Now()).Delete(&models.CacheItem{}).Error
 }
 
-// GetCachedTodayFixturesIgnoringExpiry retrieves cached today's fixtures data even if expired
-func (r *cacheRepository) GetCachedTodayFixturesIgnoringExpiry() (*models.CachedTodayFixtures, error) {
-	var cache models.CachedTodayFixtures
-
-	err := r.db.First(&cache).Error
+// Get retrieves a value from cache by key
+func (r *cacheRepository) Get(key string) (*models.CacheItem, error) {
+	var cacheItem models.CacheItem
 
+	err := r.db.Where("key = ? AND expires_at > ?", key, time.Now()).First(&cacheItem).Error
 	if err != nil {
-		return nil, err // Return nil if not found or error
-	}
-
-	return &cache, nil
-}
-
-// StoreCachedFixtures stores fixtures data with an expiration time
-func (r *cacheRepository) StoreCachedTodayFixtures(data models.JSONB, ttl time.Duration) error {
-	expiresAt := time.Now().Add(ttl)
-	cache := models.CachedTodayFixtures{
-		Data: data,
-		CachedData: models.CachedData{
-			ExpiresAt: expiresAt,
-		},
-	}
-	// Use upsert to avoid duplicate key errors (only one row should exist)
-	return r.db.Clauses(clause.OnConflict{
-		DoUpdates: clause.AssignmentColumns([]string{"data", "expires_at", "updated_at"}),
-	}).Create(&cache).Error
-}
-
-// CleanExpiredCache removes all expired cache entries
-func (r *cacheRepository) CleanExpiredCache() error {
-	// Clean fixtures cache
-	if err := r.db.Where("expires_at < ?", time.Now()).Delete(&models.CachedFixtures{}).Error; err != nil {
-		return err
-	}
-
-	// Clean today fixtures cache
-	if err := r.db.Where("expires_at < ?", time.Now()).Delete(&models.CachedTodayFixtures{}).Error; err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return &cacheItem, nil
 }
 
-// Get retrieves a value from cache by key
-func (r *cacheRepository) Get(key string) (*models.CacheItem, error) {
+// GetIgnoringExpiry retrieves a value from cache by key even if expired, e.g. to revalidate it
+func (r *cacheRepository) GetIgnoringExpiry(key string) (*models.CacheItem, error) {
 	var cacheItem models.CacheItem
 
-	err := r.db.Where("key = ? AND expires_at > ?", key, time.Now()).First(&cacheItem).Error
+	err := r.db.Where("key = ?", key).First(&cacheItem).Error
 	if err != nil {
 		return nil, err
 	}
@@ -180,11 +88,14 @@ func (r *cacheRepository) Set(key string, value []byte, ttl time.Duration) error
 	}).Create(&cacheItem).Error
 }
 
-// SetWithMetadata stores a cache item with all metadata
+// SetWithMetadata stores a cache item with all metadata. Its CreatedAt defaults to now.
 func (r *cacheRepository) SetWithMetadata(key string, item models.CacheItem) error {
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now()
+	}
 	return r.db.Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "key"}},
-		DoUpdates: clause.AssignmentColumns([]string{"value", "expires_at", "last_modified", "e_tag"}),
+		DoUpdates: clause.AssignmentColumns([]string{"value", "expires_at", "last_modified", "e_tag", "created_at"}),
 	}).Create(&item).Error
 }
 
@@ -199,3 +110,15 @@ func (r *cacheRepository) UpdateVersion(key string, version string) error {
 		})
 	return result.Error
 }
+
+// ExtendExpiry makes a cached item expire ttl from now, e.g. after it was revalidated
+func (r *cacheRepository) ExtendExpiry(key string, ttl time.Duration) error {
+	return r.db.Model(&models.CacheItem{}).
+		Where("key = ?", key).
+		Update("expires_at", time.Now().Add(ttl)).Error
+}
+
+// Delete removes a cached item
+func (r *cacheRepository) Delete(key string) error {
+	return r.db.Where("key = ?", key).Delete(&models.CacheItem{}).Error
+}
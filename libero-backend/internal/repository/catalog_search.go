@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// prefixSearchQuery turns free text into a Postgres tsquery that matches every word as a prefix,
+// e.g. "man utd" becomes "man:* & utd:*". Punctuation is dropped so user input can't break the query.
+func prefixSearchQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, word+":*")
+	}
+	return strings.Join(terms, " & ")
+}
+
+// textSearch filters rows whose document matches every word of the query as a prefix.
+// Without usable search words it doesn't filter at all.
+func textSearch(query, document string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		tsQuery := prefixSearchQuery(query)
+		if tsQuery == "" {
+			return db
+		}
+		return db.Where("to_tsvector('simple', "+document+") @@ to_tsquery('simple', ?)", tsQuery)
+	}
+}
+
+// searchRanking orders search results by name, ranking names that start with the query first
+func searchRanking(query, nameColumn string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		query = strings.TrimSpace(query)
+		if query == "" {
+			return db.Order(nameColumn + " ASC")
+		}
+		return db.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "(" + nameColumn + " ILIKE ?) DESC, " + nameColumn + " ASC",
+			Vars:               []interface{}{query + "%"},
+			WithoutParentheses: true,
+		}})
+	}
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"libero-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CompetitionRepository defines the interface for competition data operations
+type CompetitionRepository interface {
+	Upsert(competition *models.Competition) error
+	ReplaceTeams(competition *models.Competition, teams []*models.Team) error
+	FindByID(id uint) (*models.Competition, error)
+	Search(filter models.CatalogFilter, page, limit int) ([]models.Competition, int64, error)
+}
+
+// competitionRepository implements the CompetitionRepository interface
+type competitionRepository struct {
+	db *gorm.DB
+}
+
+// NewCompetitionRepository creates a new competition repository instance
+func NewCompetitionRepository(db *gorm.DB) CompetitionRepository {
+	return &competitionRepository{db: db}
+}
+
+// Upsert creates the competition, or refreshes the stored competition with the same code.
+// Competitions are matched on code so hand-seeded rows are adopted by the provider sync.
+func (r *competitionRepository) Upsert(competition *models.Competition) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"provider_id", "name", "type", "country", "logo_url", "sport", "season",
+			"season_start", "season_end", "current_matchday", "updated_at",
+		}),
+	}).Create(competition).Error
+}
+
+// ReplaceTeams sets the teams taking part in the competition, dropping teams that no longer do
+func (r *competitionRepository) ReplaceTeams(competition *models.Competition, teams []*models.Team) error {
+	return r.db.Model(competition).Association("Teams").Replace(teams)
+}
+
+// FindByID retrieves a competition with the teams taking part in it
+func (r *competitionRepository) FindByID(id uint) (*models.Competition, error) {
+	var competition models.Competition
+	err := r.db.Preload("Teams", func(db *gorm.DB) *gorm.DB {
+		return db.Order("teams.name ASC")
+	}).First(&competition, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &competition, nil
+}
+
+// Search retrieves competitions matching the filter with pagination
+func (r *competitionRepository) Search(filter models.CatalogFilter, page, limit int) ([]models.Competition, int64, error) {
+	var competitions []models.Competition
+	var count int64
+
+	offset := (page - 1) * limit
+	search := textSearch(filter.Query, "name || ' ' || COALESCE(code, '')")
+
+	if err := r.db.Model(&models.Competition{}).Scopes(competitionFilterScope(filter), search).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.db.Scopes(competitionFilterScope(filter), search, searchRanking(filter.Query, "name")).
+		Offset(offset).
+		Limit(limit).
+		Find(&competitions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return competitions, count, nil
+}
+
+// competitionFilterScope applies the non-empty competition filters
+func competitionFilterScope(filter models.CatalogFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Country != "" {
+			db = db.Where("LOWER(country) = LOWER(?)", filter.Country)
+		}
+		if filter.Sport != "" {
+			db = db.Where("LOWER(sport) = LOWER(?)", filter.Sport)
+		}
+		return db
+	}
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"errors"
+	"libero-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrSeasonChanged is returned when archiving a league season that was archived in the meantime
+var ErrSeasonChanged = errors.New("league season changed while archiving it")
+
+// LeagueRepository defines the interface for private league data operations
+type LeagueRepository interface {
+	Create(league *models.League) error
+	FindByID(id uint) (*models.League, error)
+	FindByInviteCode(code string) (*models.League, error)
+	FindByUserID(userID uint) ([]models.League, error)
+
+	// Membership operations
+	AddMember(member *models.LeagueMember) error
+	FindMember(leagueID, userID uint) (*models.LeagueMember, error)
+	RemoveMember(leagueID, userID uint) error
+	IsRemoved(leagueID, userID uint) (bool, error)
+
+	// Standings operations
+	GetStandings(leagueID uint, from time.Time, to *time.Time) ([]models.LeaderboardEntry, error)
+	ArchiveSeason(league *models.League, standings []models.LeagueSeasonStanding, endedAt time.Time) error
+	FindSeasonStandings(leagueID uint, season int) ([]models.LeagueSeasonStanding, error)
+}
+
+// leagueRepository implements the LeagueRepository interface
+type leagueRepository struct {
+	db *gorm.DB
+}
+
+// NewLeagueRepository creates a new league repository instance
+func NewLeagueRepository(db *gorm.DB) LeagueRepository {
+	return &leagueRepository{db: db}
+}
+
+// Create adds a new league and makes its owner the first admin member
+func (r *leagueRepository) Create(league *models.League) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Members").Create(league).Error; err != nil {
+			return err
+		}
+		owner := models.LeagueMember{
+			LeagueID: league.ID,
+			UserID:   league.OwnerID,
+			Role:     models.LeagueRoleAdmin,
+		}
+		return tx.Create(&owner).Error
+	})
+}
+
+// FindByID retrieves a league by ID, preloading its members
+func (r *leagueRepository) FindByID(id uint) (*models.League, error) {
+	var league models.League
+	err := r.db.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("joined_at ASC")
+	}).Preload("Members.User").First(&league, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &league, nil
+}
+
+// FindByInviteCode retrieves a league by its invite code
+func (r *leagueRepository) FindByInviteCode(code string) (*models.League, error) {
+	var league models.League
+	err := r.db.Where("invite_code = ?", code).First(&league).Error
+	if err != nil {
+		return nil, err
+	}
+	return &league, nil
+}
+
+// FindByUserID retrieves all leagues the user is a member of
+func (r *leagueRepository) FindByUserID(userID uint) ([]models.League, error) {
+	var leagues []models.League
+	err := r.db.Joins("JOIN league_members ON league_members.league_id = leagues.id").
+		Where("league_members.user_id = ?", userID).
+		Order("leagues.name ASC").
+		Find(&leagues).Error
+	if err != nil {
+		return nil, err
+	}
+	return leagues, nil
+}
+
+// AddMember adds a user to a league
+func (r *leagueRepository) AddMember(member *models.LeagueMember) error {
+	return r.db.Create(member).Error
+}
+
+// FindMember retrieves a user's membership of a league
+func (r *leagueRepository) FindMember(leagueID, userID uint) (*models.LeagueMember, error) {
+	var member models.LeagueMember
+	err := r.db.Where("league_id = ? AND user_id = ?", leagueID, userID).First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// RemoveMember removes a user from a league and records the removal
+func (r *leagueRepository) RemoveMember(leagueID, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("league_id = ? AND user_id = ?", leagueID, userID).Delete(&models.LeagueMember{}).Error; err != nil {
+			return err
+		}
+		removal := &models.LeagueRemoval{LeagueID: leagueID, UserID: userID}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(removal).Error
+	})
+}
+
+// IsRemoved reports whether a user was removed from a league
+func (r *leagueRepository) IsRemoved(leagueID, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.LeagueRemoval{}).Where("league_id = ? AND user_id = ?", leagueID, userID).Count(&count).Error
+	return count > 0, err
+}
+
+// GetStandings ranks league members by the points of their picks settled from the given time (and
+// before the optional end). A pick counts toward the season it settles in, so picks still pending
+// when a season is archived count toward the next one. Members without picks are included with zero points.
+func (r *leagueRepository) GetStandings(leagueID uint, from time.Time, to *time.Time) ([]models.LeaderboardEntry, error) {
+	pickJoin := "LEFT JOIN user_picks ON user_picks.user_id = league_members.user_id AND user_picks.settled_at >= ?"
+	joinArgs := []interface{}{from}
+	if to != nil {
+		pickJoin += " AND user_picks.settled_at < ?"
+		joinArgs = append(joinArgs, *to)
+	}
+
+	var entries []models.LeaderboardEntry
+	err := r.db.Model(&models.LeagueMember{}).
+		Select(`RANK() OVER (ORDER BY COALESCE(SUM(user_picks.points), 0) DESC, COUNT(user_picks.id) FILTER (WHERE user_picks.points = ?) DESC) AS rank,
+			league_members.user_id,
+			users.username,
+			users.name,
+			COALESCE(SUM(user_picks.points), 0) AS points,
+			COUNT(user_picks.id) AS picks,
+			COUNT(user_picks.id) FILTER (WHERE user_picks.points = ?) AS exact_scores,
+			COUNT(user_picks.id) FILTER (WHERE user_picks.points > 0) AS correct_outcomes`,
+			models.PickPointsExactScore, models.PickPointsExactScore).
+		Joins("JOIN users ON users.id = league_members.user_id").
+		Joins(pickJoin, joinArgs...).
+		Where("league_members.league_id = ?", leagueID).
+		Group("league_members.user_id, users.username, users.name").
+		Order("rank, users.username").
+		Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ArchiveSeason stores the final standings of the league's current season and starts the next one.
+// It fails with ErrSeasonChanged if the season was archived since the league was read.
+func (r *leagueRepository) ArchiveSeason(league *models.League, standings []models.LeagueSeasonStanding, endedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Only one of concurrent archives moves the season on; the others wait for it and match nothing
+		result := tx.Model(&models.League{}).
+			Where("id = ? AND season = ?", league.ID, league.Season).
+			Updates(map[string]interface{}{
+				"season":            league.Season + 1,
+				"season_started_at": endedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return ErrSeasonChanged
+		}
+		if len(standings) > 0 {
+			return tx.Create(&standings).Error
+		}
+		return nil
+	})
+}
+
+// FindSeasonStandings retrieves the archived standings of a league season
+func (r *leagueRepository) FindSeasonStandings(leagueID uint, season int) ([]models.LeagueSeasonStanding, error) {
+	var standings []models.LeagueSeasonStanding
+	err := r.db.Where("league_id = ? AND season = ?", leagueID, season).
+		Order("rank ASC, username ASC").
+		Find(&standings).Error
+	if err != nil {
+		return nil, err
+	}
+	return standings, nil
+}
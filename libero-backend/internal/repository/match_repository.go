@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"libero-backend/internal/models"
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MatchRepository defines the interface for stored match data operations
+type MatchRepository interface {
+	UpsertMany(matches []models.Match) error
+	FindByProviderID(providerID int) (*models.Match, error)
+	UpdateLiveState(providerID int, status string, homeScore, awayScore *int) error
+	Find(filter models.MatchFilter, page, limit int) ([]models.Match, int64, error)
+	FindAll(filter models.MatchFilter) ([]models.Match, error)
+}
+
+// matchRepository implements the MatchRepository interface
+type matchRepository struct {
+	db *gorm.DB
+}
+
+// NewMatchRepository creates a new match repository instance
+func NewMatchRepository(db *gorm.DB) MatchRepository {
+	return &matchRepository{db: db}
+}
+
+// UpsertMany inserts the matches, or refreshes the stored copy of matches already known by provider ID
+func (r *matchRepository) UpsertMany(matches []models.Match) error {
+	if len(matches) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "provider_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"competition_code", "competition_name", "competition_emblem", "matchday", "stage", "group_name", "kickoff_at", "status", "venue",
+			"home_team_id", "home_team_name", "home_team_crest", "away_team_id", "away_team_name", "away_team_crest",
+			"home_score", "away_score", "updated_at",
+		}),
+	}).Create(&matches).Error
+}
+
+// FindByProviderID retrieves a match by the provider's match ID
+func (r *matchRepository) FindByProviderID(providerID int) (*models.Match, error) {
+	var match models.Match
+	if err := r.db.Where("provider_id = ?", providerID).First(&match).Error; err != nil {
+		return nil, err
+	}
+	return &match, nil
+}
+
+// UpdateLiveState updates the status and score of a stored match. Matches that haven't been
+// stored yet are left alone; they are picked up by the next fixtures fetch.
+func (r *matchRepository) UpdateLiveState(providerID int, status string, homeScore, awayScore *int) error {
+	return r.db.Model(&models.Match{}).
+		Where("provider_id = ?", providerID).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"home_score": homeScore,
+			"away_score": awayScore,
+		}).Error
+}
+
+// Find retrieves matches matching the filter with pagination, ordered by kickoff
+func (r *matchRepository) Find(filter models.MatchFilter, page, limit int) ([]models.Match, int64, error) {
+	var matches []models.Match
+	var count int64
+
+	offset := (page - 1) * limit
+
+	if err := r.db.Model(&models.Match{}).Scopes(matchFilterScope(filter)).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.db.Scopes(matchFilterScope(filter)).
+		Order("kickoff_at ASC").
+		Order("provider_id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&matches).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return matches, count, nil
+}
+
+// FindAll retrieves every match matching the filter, ordered by kickoff
+func (r *matchRepository) FindAll(filter models.MatchFilter) ([]models.Match, error) {
+	var matches []models.Match
+	if err := r.db.Scopes(matchFilterScope(filter)).
+		Order("kickoff_at ASC").
+		Order("provider_id ASC").
+		Find(&matches).Error; err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// matchFilterScope applies the non-empty parts of a match filter
+func matchFilterScope(filter models.MatchFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Competition != "" {
+			db = db.Where("competition_code = ?", filter.Competition)
+		}
+		if filter.Team != "" {
+			// Numeric values are provider team IDs, anything else matches team names
+			if teamID, err := strconv.Atoi(filter.Team); err == nil {
+				db = db.Where("(home_team_id = ? OR away_team_id = ?)", teamID, teamID)
+			} else {
+				pattern := "%" + filter.Team + "%"
+				db = db.Where("(home_team_name ILIKE ? OR away_team_name ILIKE ?)", pattern, pattern)
+			}
+		}
+		if len(filter.TeamIDs) > 0 {
+			db = db.Where("(home_team_id IN ? OR away_team_id IN ?)", filter.TeamIDs, filter.TeamIDs)
+		}
+		if len(filter.Status) > 0 {
+			db = db.Where("status IN ?", filter.Status)
+		}
+		if filter.From != nil {
+			db = db.Where("kickoff_at >= ?", *filter.From)
+		}
+		if filter.To != nil {
+			db = db.Where("kickoff_at < ?", *filter.To)
+		}
+		return db
+	}
+}
@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"container/list"
+	"libero-backend/internal/models"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TieredCacheRepository is a CacheRepository that keeps recently used items in memory in front of
+// another CacheRepository. Writes go through to the backing repository and update memory.
+type TieredCacheRepository interface {
+	CacheRepository
+	// Evict drops a key from memory only, e.g. when another process changed it in the backing repository
+	Evict(key string)
+	// OnInvalidate registers a hook called with every key written or deleted through this repository
+	OnInvalidate(hook func(key string))
+}
+
+// memoryCacheEntry is an item held in memory
+type memoryCacheEntry struct {
+	item     models.CacheItem
+	loadedAt time.Time
+}
+
+// memoryCacheRepository implements the TieredCacheRepository interface with a bounded LRU
+type memoryCacheRepository struct {
+	next       CacheRepository
+	maxEntries int
+	ttl        time.Duration // How long an item is served from memory before it is read again
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element // Values are *memoryCacheEntry
+	order   *list.List               // Most recently used first
+	hooks   []func(key string)
+}
+
+// NewMemoryCacheRepository creates a memory tier of up to maxEntries items in front of next. Items
+// are served from memory for up to ttl, and never past their own expiry.
+func NewMemoryCacheRepository(next CacheRepository, maxEntries int, ttl time.Duration) TieredCacheRepository {
+	return &memoryCacheRepository{
+		next:       next,
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get retrieves a value from cache by key
+func (r *memoryCacheRepository) Get(key string) (*models.CacheItem, error) {
+	if item, ok := r.load(key); ok {
+		if !time.Now().Before(item.ExpiresAt) {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return item, nil
+	}
+
+	item, err := r.next.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	r.store(*item)
+	return item, nil
+}
+
+// GetIgnoringExpiry retrieves a value from cache by key even if expired
+func (r *memoryCacheRepository) GetIgnoringExpiry(key string) (*models.CacheItem, error) {
+	if item, ok := r.load(key); ok {
+		return item, nil
+	}
+
+	item, err := r.next.GetIgnoringExpiry(key)
+	if err != nil {
+		return nil, err
+	}
+	r.store(*item)
+	return item, nil
+}
+
+// GetWithVersion gets a cache item only if its version matches
+func (r *memoryCacheRepository) GetWithVersion(key string, version string) (*models.CacheItem, error) {
+	item, err := r.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if item.ETag != version {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return item, nil
+}
+
+// Set stores a value in cache with an expiration time
+func (r *memoryCacheRepository) Set(key string, value []byte, ttl time.Duration) error {
+	if err := r.next.Set(key, value, ttl); err != nil {
+		r.invalidate(key)
+		return err
+	}
+	now := time.Now()
+	r.store(models.CacheItem{Key: key, Value: value, ExpiresAt: now.Add(ttl), LastModified: now})
+	r.notify(key)
+	return nil
+}
+
+// SetWithMetadata stores a cache item with all metadata
+func (r *memoryCacheRepository) SetWithMetadata(key string, item models.CacheItem) error {
+	if err := r.next.SetWithMetadata(key, item); err != nil {
+		r.invalidate(key)
+		return err
+	}
+	item.Key = key
+	r.store(item)
+	r.notify(key)
+	return nil
+}
+
+// UpdateVersion updates the version (ETag) of a cached item
+func (r *memoryCacheRepository) UpdateVersion(key string, version string) error {
+	err := r.next.UpdateVersion(key, version)
+	r.invalidate(key)
+	return err
+}
+
+// ExtendExpiry makes a cached item expire ttl from now, e.g. after it was revalidated
+func (r *memoryCacheRepository) ExtendExpiry(key string, ttl time.Duration) error {
+	if err := r.next.ExtendExpiry(key, ttl); err != nil {
+		r.invalidate(key)
+		return err
+	}
+
+	r.mutex.Lock()
+	if element, ok := r.entries[key]; ok {
+		element.Value.(*memoryCacheEntry).item.ExpiresAt = time.Now().Add(ttl)
+	}
+	r.mutex.Unlock()
+	r.notify(key)
+	return nil
+}
+
+// Delete removes a cached item
+func (r *memoryCacheRepository) Delete(key string) error {
+	err := r.next.Delete(key)
+	r.invalidate(key)
+	return err
+}
+
+// CleanExpiredCache removes all expired cache entries
+func (r *memoryCacheRepository) CleanExpiredCache() error {
+	now := time.Now()
+	r.mutex.Lock()
+	for key, element := range r.entries {
+		if !now.Before(element.Value.(*memoryCacheEntry).item.ExpiresAt) {
+			r.order.Remove(element)
+			delete(r.entries, key)
+		}
+	}
+	r.mutex.Unlock()
+
+	return r.next.CleanExpiredCache()
+}
+
+// Evict drops a key from memory only
+func (r *memoryCacheRepository) Evict(key string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if element, ok := r.entries[key]; ok {
+		r.order.Remove(element)
+		delete(r.entries, key)
+	}
+}
+
+// OnInvalidate registers a hook called with every key written or deleted through this repository
+func (r *memoryCacheRepository) OnInvalidate(hook func(key string)) {
+	r.mutex.Lock()
+	r.hooks = append(r.hooks, hook)
+	r.mutex.Unlock()
+}
+
+// load returns a copy of the item held in memory under key, unless it has been there longer than the tier's TTL
+func (r *memoryCacheRepository) load(key string) (*models.CacheItem, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	element, ok := r.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryCacheEntry)
+	if time.Since(entry.loadedAt) >= r.ttl {
+		r.order.Remove(element)
+		delete(r.entries, key)
+		return nil, false
+	}
+	r.order.MoveToFront(element)
+	item := entry.item
+	return &item, true
+}
+
+// store holds an item in memory, evicting the least recently used items beyond maxEntries
+func (r *memoryCacheRepository) store(item models.CacheItem) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry := &memoryCacheEntry{item: item, loadedAt: time.Now()}
+	if element, ok := r.entries[item.Key]; ok {
+		element.Value = entry
+		r.order.MoveToFront(element)
+		return
+	}
+	r.entries[item.Key] = r.order.PushFront(entry)
+	for r.order.Len() > r.maxEntries {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.entries, oldest.Value.(*memoryCacheEntry).item.Key)
+	}
+}
+
+// invalidate drops a key from memory and tells the hooks
+func (r *memoryCacheRepository) invalidate(key string) {
+	r.Evict(key)
+	r.notify(key)
+}
+
+// notify calls the invalidation hooks for key
+func (r *memoryCacheRepository) notify(key string) {
+	r.mutex.Lock()
+	hooks := append([]func(key string){}, r.hooks...)
+	r.mutex.Unlock()
+
+	for _, hook := range hooks {
+		hook(key)
+	}
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"errors"
+	"libero-backend/internal/models"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// mapCacheRepository is a backing repository in a map that counts its reads
+type mapCacheRepository struct {
+	CacheRepository
+	mutex sync.Mutex
+	items map[string]models.CacheItem
+	reads map[string]int
+}
+
+func newMapCacheRepository() *mapCacheRepository {
+	return &mapCacheRepository{items: make(map[string]models.CacheItem), reads: make(map[string]int)}
+}
+
+func (r *mapCacheRepository) Get(key string) (*models.CacheItem, error) {
+	item, err := r.GetIgnoringExpiry(key)
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(item.ExpiresAt) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return item, nil
+}
+
+func (r *mapCacheRepository) GetIgnoringExpiry(key string) (*models.CacheItem, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.reads[key]++
+	item, ok := r.items[key]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &item, nil
+}
+
+func (r *mapCacheRepository) SetWithMetadata(key string, item models.CacheItem) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	item.Key = key
+	r.items[key] = item
+	return nil
+}
+
+func (r *mapCacheRepository) ExtendExpiry(key string, ttl time.Duration) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if item, ok := r.items[key]; ok {
+		item.ExpiresAt = time.Now().Add(ttl)
+		r.items[key] = item
+	}
+	return nil
+}
+
+// readCount returns how often key was read from the backing repository
+func (r *mapCacheRepository) readCount(key string) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.reads[key]
+}
+
+// put stores an item expiring in expiresIn straight into the backing repository
+func (r *mapCacheRepository) put(key string, expiresIn time.Duration) {
+	_ = r.SetWithMetadata(key, models.CacheItem{Value: []byte(key), ExpiresAt: time.Now().Add(expiresIn)})
+}
+
+func TestMemoryCacheRepositoryEvictsLeastRecentlyUsed(t *testing.T) {
+	backing := newMapCacheRepository()
+	for _, key := range []string{"a", "b", "c"} {
+		backing.put(key, time.Hour)
+	}
+	tier := NewMemoryCacheRepository(backing, 2, time.Hour)
+
+	get := func(key string) {
+		if _, err := tier.Get(key); err != nil {
+			t.Fatalf("Get(%q) error = %v", key, err)
+		}
+	}
+	get("a")
+	get("b")
+	get("a") // b is now the least recently used
+	get("c") // and makes way for c
+
+	wantReads := map[string]int{"a": 1, "b": 1, "c": 1}
+	for key, want := range wantReads {
+		if got := backing.readCount(key); got != want {
+			t.Errorf("%s read %d times from the backing repository, want %d", key, got, want)
+		}
+	}
+
+	get("a")
+	get("c")
+	get("b")
+	wantReads = map[string]int{"a": 1, "b": 2, "c": 1}
+	for key, want := range wantReads {
+		if got := backing.readCount(key); got != want {
+			t.Errorf("after evicting b, %s read %d times, want %d", key, got, want)
+		}
+	}
+}
+
+func TestMemoryCacheRepositoryTTL(t *testing.T) {
+	backing := newMapCacheRepository()
+	backing.put("a", time.Hour)
+	tier := NewMemoryCacheRepository(backing, 10, 20*time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		if _, err := tier.Get("a"); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+	if reads := backing.readCount("a"); reads != 1 {
+		t.Errorf("read %d times within the tier TTL, want 1", reads)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if _, err := tier.Get("a"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if reads := backing.readCount("a"); reads != 2 {
+		t.Errorf("read %d times past the tier TTL, want 2", reads)
+	}
+}
+
+func TestMemoryCacheRepositoryItemExpiry(t *testing.T) {
+	backing := newMapCacheRepository()
+	backing.put("a", 20*time.Millisecond)
+	tier := NewMemoryCacheRepository(backing, 10, time.Hour)
+
+	if _, err := tier.Get("a"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	// Memory never serves an item past its own expiry, though it keeps it for revalidation
+	if _, err := tier.Get("a"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Get() of an expired item error = %v, want gorm.ErrRecordNotFound", err)
+	}
+	if _, err := tier.GetIgnoringExpiry("a"); err != nil {
+		t.Errorf("GetIgnoringExpiry() of an expired item error = %v", err)
+	}
+	if reads := backing.readCount("a"); reads != 1 {
+		t.Errorf("read %d times from the backing repository, want 1", reads)
+	}
+
+	// Extending the expiry goes through to the backing repository and the memory copy alike
+	if err := tier.ExtendExpiry("a", time.Hour); err != nil {
+		t.Fatalf("ExtendExpiry() error = %v", err)
+	}
+	if _, err := tier.Get("a"); err != nil {
+		t.Errorf("Get() after ExtendExpiry() error = %v", err)
+	}
+	if item, _ := backing.GetIgnoringExpiry("a"); !item.ExpiresAt.After(time.Now().Add(time.Minute)) {
+		t.Errorf("backing item expires at %v, want it extended", item.ExpiresAt)
+	}
+}
+
+func TestMemoryCacheRepositoryWritesThrough(t *testing.T) {
+	backing := newMapCacheRepository()
+	tier := NewMemoryCacheRepository(backing, 10, time.Hour)
+	var invalidated []string
+	tier.OnInvalidate(func(key string) { invalidated = append(invalidated, key) })
+
+	item := models.CacheItem{Value: []byte("v1"), ExpiresAt: time.Now().Add(time.Hour)}
+	if err := tier.SetWithMetadata("a", item); err != nil {
+		t.Fatalf("SetWithMetadata() error = %v", err)
+	}
+	if stored, err := backing.GetIgnoringExpiry("a"); err != nil || string(stored.Value) != "v1" {
+		t.Errorf("backing item = %v, %v; want v1", stored, err)
+	}
+	if got, err := tier.Get("a"); err != nil || string(got.Value) != "v1" {
+		t.Errorf("Get() = %v, %v; want v1 from memory", got, err)
+	}
+	if reads := backing.readCount("a"); reads != 1 {
+		t.Errorf("read %d times from the backing repository, want only the check above", reads)
+	}
+	if len(invalidated) != 1 || invalidated[0] != "a" {
+		t.Errorf("invalidated keys = %v, want [a]", invalidated)
+	}
+
+	// Evict drops the memory copy only
+	tier.Evict("a")
+	if _, err := tier.Get("a"); err != nil {
+		t.Errorf("Get() after Evict() error = %v", err)
+	}
+	if reads := backing.readCount("a"); reads != 2 {
+		t.Errorf("read %d times after Evict(), want 2", reads)
+	}
+}
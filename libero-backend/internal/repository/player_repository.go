@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"libero-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PlayerRepository defines the interface for player data operations
+type PlayerRepository interface {
+	UpsertMany(players []models.Player) error
+	FindByID(id uint) (*models.Player, error)
+	FindByTeamID(teamID uint) ([]models.Player, error)
+	Search(filter models.CatalogFilter, page, limit int) ([]models.Player, int64, error)
+}
+
+// playerRepository implements the PlayerRepository interface
+type playerRepository struct {
+	db *gorm.DB
+}
+
+// NewPlayerRepository creates a new player repository instance
+func NewPlayerRepository(db *gorm.DB) PlayerRepository {
+	return &playerRepository{db: db}
+}
+
+// UpsertMany inserts the players, or refreshes players already known by provider ID.
+// A player who moved club is reassigned to the new team.
+func (r *playerRepository) UpsertMany(players []models.Player) error {
+	if len(players) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "provider_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"name", "position", "team_id", "country", "date_of_birth", "shirt_number", "updated_at",
+		}),
+	}).Create(&players).Error
+}
+
+// FindByID retrieves a player by ID
+func (r *playerRepository) FindByID(id uint) (*models.Player, error) {
+	var player models.Player
+	if err := r.db.First(&player, id).Error; err != nil {
+		return nil, err
+	}
+	return &player, nil
+}
+
+// FindByTeamID retrieves a team's squad ordered by position and shirt number
+func (r *playerRepository) FindByTeamID(teamID uint) ([]models.Player, error) {
+	var players []models.Player
+	err := r.db.Where("team_id = ?", teamID).
+		Order("position ASC").
+		Order("shirt_number ASC NULLS LAST").
+		Order("name ASC").
+		Find(&players).Error
+	if err != nil {
+		return nil, err
+	}
+	return players, nil
+}
+
+// Search retrieves players matching the filter with pagination
+func (r *playerRepository) Search(filter models.CatalogFilter, page, limit int) ([]models.Player, int64, error) {
+	var players []models.Player
+	var count int64
+
+	offset := (page - 1) * limit
+	search := textSearch(filter.Query, "name")
+
+	if err := r.db.Model(&models.Player{}).Scopes(playerFilterScope(filter), search).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.db.Scopes(playerFilterScope(filter), search, searchRanking(filter.Query, "name")).
+		Offset(offset).
+		Limit(limit).
+		Find(&players).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return players, count, nil
+}
+
+// playerFilterScope applies the non-empty player filters
+func playerFilterScope(filter models.CatalogFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Country != "" {
+			db = db.Where("LOWER(country) = LOWER(?)", filter.Country)
+		}
+		if filter.Position != "" {
+			db = db.Where("LOWER(position) = LOWER(?)", filter.Position)
+		}
+		if filter.TeamID != 0 {
+			db = db.Where("team_id = ?", filter.TeamID)
+		}
+		if filter.Competition != "" {
+			db = db.Where("team_id IN (?)", competitionTeamIDs(db, filter.Competition))
+		}
+		return db
+	}
+}
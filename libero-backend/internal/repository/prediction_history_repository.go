@@ -2,6 +2,7 @@ package repository
 
 import (
 	"libero-backend/internal/models"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -9,11 +10,19 @@ import (
 // PredictionHistoryRepository defines the interface for prediction history data operations
 type PredictionHistoryRepository interface {
 	Create(prediction *models.PredictionHistory) error
-	FindByUserID(userID uint, page, limit int) ([]models.PredictionHistory, int64, error)
+	FindByUserID(userID uint, status string, page, limit int) ([]models.PredictionHistory, int64, error)
 	FindByID(id uint) (*models.PredictionHistory, error)
 	Delete(id uint, userID uint) error
 	DeleteAllByUserID(userID uint) error
 	GetStatistics(userID uint) (*models.PredictionStatistics, error)
+	CountByStatus(userID uint) (settled int64, pending int64, err error)
+	GetAccuracyStatistics(userID uint, filter models.PredictionAccuracyFilter) (*models.PredictionAccuracyStatistics, error)
+
+	// Settlement operations
+	FindUnsettled(kickoffBefore time.Time, limit int) ([]models.PredictionHistory, error)
+	Settle(prediction *models.PredictionHistory) error
+	Void(id uint, voidedAt time.Time) error
+	MarkChecked(ids []uint, checkedAt time.Time) error
 }
 
 // predictionHistoryRepository implements the PredictionHistoryRepository interface
@@ -31,20 +40,33 @@ func (r *predictionHistoryRepository) Create(prediction *models.PredictionHistor
 	return r.db.Create(prediction).Error
 }
 
-// FindByUserID retrieves predictions for a specific user with pagination
-func (r *predictionHistoryRepository) FindByUserID(userID uint, page, limit int) ([]models.PredictionHistory, int64, error) {
+// withStatus narrows a prediction query to settled, pending or voided rows. Any other status returns all rows.
+func withStatus(query *gorm.DB, status string) *gorm.DB {
+	switch status {
+	case models.PredictionStatusSettled:
+		return query.Where("settled_at IS NOT NULL")
+	case models.PredictionStatusPending:
+		return query.Where("settled_at IS NULL AND voided_at IS NULL")
+	case models.PredictionStatusVoid:
+		return query.Where("voided_at IS NOT NULL")
+	}
+	return query
+}
+
+// FindByUserID retrieves predictions for a specific user with pagination, optionally filtered by status
+func (r *predictionHistoryRepository) FindByUserID(userID uint, status string, page, limit int) ([]models.PredictionHistory, int64, error) {
 	var predictions []models.PredictionHistory
 	var count int64
 
 	offset := (page - 1) * limit
 
 	// Get total count for the user
-	if err := r.db.Model(&models.PredictionHistory{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+	if err := withStatus(r.db.Model(&models.PredictionHistory{}).Where("user_id = ?", userID), status).Count(&count).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get predictions for the current page, ordered by creation date (newest first)
-	if err := r.db.Where("user_id = ?", userID).
+	if err := withStatus(r.db.Where("user_id = ?", userID), status).
 		Order("created_at DESC").
 		Offset(offset).
 		Limit(limit).
@@ -123,5 +145,216 @@ func (r *predictionHistoryRepository) GetStatistics(userID uint) (*models.Predic
 	stats.DrawPercentage = (float64(stats.Draws) / totalFloat) * 100
 	stats.AwayWinPercentage = (float64(stats.AwayWins) / totalFloat) * 100
 
+	// Aggregate settlement results
+	var settled struct {
+		Settled         int64
+		CorrectOutcomes int64
+		ExactScores     int64
+		AvgBrier        *float64
+		AvgLogLoss      *float64
+	}
+	if err := r.db.Model(&models.PredictionHistory{}).
+		Select(`COUNT(*) AS settled,
+			COUNT(*) FILTER (WHERE outcome_correct) AS correct_outcomes,
+			COUNT(*) FILTER (WHERE exact_score) AS exact_scores,
+			AVG(brier_score) AS avg_brier,
+			AVG(log_loss) AS avg_log_loss`).
+		Where("user_id = ? AND settled_at IS NOT NULL", userID).
+		Scan(&settled).Error; err != nil {
+		return nil, err
+	}
+	var voided int64
+	if err := r.db.Model(&models.PredictionHistory{}).
+		Where("user_id = ? AND voided_at IS NOT NULL", userID).
+		Count(&voided).Error; err != nil {
+		return nil, err
+	}
+	stats.Settled = int(settled.Settled)
+	stats.Voided = int(voided)
+	stats.Pending = stats.Total - stats.Settled - stats.Voided
+	stats.CorrectOutcomes = int(settled.CorrectOutcomes)
+	stats.ExactScores = int(settled.ExactScores)
+	if stats.Settled > 0 {
+		settledFloat := float64(stats.Settled)
+		stats.OutcomeAccuracy = (float64(stats.CorrectOutcomes) / settledFloat) * 100
+		stats.ExactScoreAccuracy = (float64(stats.ExactScores) / settledFloat) * 100
+	}
+	if settled.AvgBrier != nil {
+		stats.AverageBrierScore = *settled.AvgBrier
+	}
+	if settled.AvgLogLoss != nil {
+		stats.AverageLogLoss = *settled.AvgLogLoss
+	}
+
+	return &stats, nil
+}
+
+// calibrationBuckets is the number of equal-width probability buckets used for calibration
+const calibrationBuckets = 10
+
+// settledScope restricts a query to a user's settled predictions matching the filter
+func settledScope(userID uint, filter models.PredictionAccuracyFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("user_id = ? AND settled_at IS NOT NULL", userID)
+		if filter.League != "" {
+			db = db.Where("home_league = ? OR away_league = ?", filter.League, filter.League)
+		}
+		if filter.Team != "" {
+			db = db.Where("home_team ILIKE ? OR away_team ILIKE ?", filter.Team, filter.Team)
+		}
+		if filter.From != nil {
+			db = db.Where("COALESCE(kickoff_at, created_at) >= ?", *filter.From)
+		}
+		if filter.To != nil {
+			db = db.Where("COALESCE(kickoff_at, created_at) < ?", *filter.To)
+		}
+		return db
+	}
+}
+
+// GetAccuracyStatistics aggregates hit rates, probability scores and calibration buckets
+// for a user's settled predictions
+func (r *predictionHistoryRepository) GetAccuracyStatistics(userID uint, filter models.PredictionAccuracyFilter) (*models.PredictionAccuracyStatistics, error) {
+	stats := models.PredictionAccuracyStatistics{
+		Calibration: []models.CalibrationBucket{},
+	}
+
+	// Headline metrics. The ranked probability score treats home win, draw and away win
+	// as ordered outcomes and averages the squared error of the cumulative probabilities.
+	var totals struct {
+		Settled                int64
+		HitRate                *float64
+		ExactScoreRate         *float64
+		AverageBrierScore      *float64
+		AverageLogLoss         *float64
+		RankedProbabilityScore *float64
+	}
+	if err := r.db.Model(&models.PredictionHistory{}).
+		Scopes(settledScope(userID, filter)).
+		Select(`COUNT(*) AS settled,
+			AVG(CASE WHEN outcome_correct THEN 1.0 ELSE 0.0 END) AS hit_rate,
+			AVG(CASE WHEN exact_score THEN 1.0 ELSE 0.0 END) AS exact_score_rate,
+			AVG(brier_score) AS average_brier_score,
+			AVG(log_loss) AS average_log_loss,
+			AVG((
+				POWER(home_win_probability - CASE WHEN actual_home_score > actual_away_score THEN 1 ELSE 0 END, 2) +
+				POWER(home_win_probability + draw_probability - CASE WHEN actual_home_score >= actual_away_score THEN 1 ELSE 0 END, 2)
+			) / 2) AS ranked_probability_score`).
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	stats.Settled = int(totals.Settled)
+	if stats.Settled == 0 {
+		return &stats, nil
+	}
+	if totals.HitRate != nil {
+		stats.HitRate = *totals.HitRate
+	}
+	if totals.ExactScoreRate != nil {
+		stats.ExactScoreRate = *totals.ExactScoreRate
+	}
+	if totals.AverageBrierScore != nil {
+		stats.AverageBrierScore = *totals.AverageBrierScore
+	}
+	if totals.AverageLogLoss != nil {
+		stats.AverageLogLoss = *totals.AverageLogLoss
+	}
+	if totals.RankedProbabilityScore != nil {
+		stats.RankedProbabilityScore = *totals.RankedProbabilityScore
+	}
+
+	// Calibration: every prediction contributes one (probability, happened) pair per outcome
+	outcome := func(probability, happened string) *gorm.DB {
+		return r.db.Model(&models.PredictionHistory{}).
+			Scopes(settledScope(userID, filter)).
+			Select(probability + " AS probability, CASE WHEN " + happened + " THEN 1.0 ELSE 0.0 END AS happened")
+	}
+	pairs := r.db.Raw("? UNION ALL ? UNION ALL ?",
+		outcome("home_win_probability", "actual_home_score > actual_away_score"),
+		outcome("draw_probability", "actual_home_score = actual_away_score"),
+		outcome("away_win_probability", "actual_home_score < actual_away_score"),
+	)
+
+	var buckets []struct {
+		Bucket            int
+		Count             int64
+		AveragePredicted  float64
+		ObservedFrequency float64
+	}
+	if err := r.db.Table("(?) AS pairs", pairs).
+		Select(`CAST(LEAST(FLOOR(probability * ?), ?) AS INTEGER) AS bucket,
+			COUNT(*) AS count,
+			AVG(probability) AS average_predicted,
+			AVG(happened) AS observed_frequency`, calibrationBuckets, calibrationBuckets-1).
+		Group("bucket").
+		Order("bucket").
+		Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+	width := 1.0 / calibrationBuckets
+	for _, b := range buckets {
+		stats.Calibration = append(stats.Calibration, models.CalibrationBucket{
+			LowerBound:        float64(b.Bucket) * width,
+			UpperBound:        float64(b.Bucket+1) * width,
+			Count:             int(b.Count),
+			AveragePredicted:  b.AveragePredicted,
+			ObservedFrequency: b.ObservedFrequency,
+		})
+	}
+
 	return &stats, nil
 }
+
+// CountByStatus returns how many of a user's predictions are settled and pending
+func (r *predictionHistoryRepository) CountByStatus(userID uint) (int64, int64, error) {
+	var counts struct {
+		Settled int64
+		Pending int64
+	}
+	err := r.db.Model(&models.PredictionHistory{}).
+		Select(`COUNT(*) FILTER (WHERE settled_at IS NOT NULL) AS settled,
+			COUNT(*) FILTER (WHERE settled_at IS NULL AND voided_at IS NULL) AS pending`).
+		Where("user_id = ?", userID).
+		Scan(&counts).Error
+	if err != nil {
+		return 0, 0, err
+	}
+	return counts.Settled, counts.Pending, nil
+}
+
+// FindUnsettled retrieves predictions linked to a match that kicked off before the given time
+// and have been neither settled nor voided yet. Those never checked come first, then those
+// checked longest ago, so predictions that can't be settled yet rotate out of the batch.
+func (r *predictionHistoryRepository) FindUnsettled(kickoffBefore time.Time, limit int) ([]models.PredictionHistory, error) {
+	var predictions []models.PredictionHistory
+	err := r.db.Where("match_id IS NOT NULL AND settled_at IS NULL AND voided_at IS NULL").
+		Where("kickoff_at IS NULL OR kickoff_at < ?", kickoffBefore).
+		Order("last_checked_at ASC NULLS FIRST").
+		Order("kickoff_at ASC").
+		Limit(limit).
+		Find(&predictions).Error
+	if err != nil {
+		return nil, err
+	}
+	return predictions, nil
+}
+
+// Settle persists the settlement results of a prediction
+func (r *predictionHistoryRepository) Settle(prediction *models.PredictionHistory) error {
+	return r.db.Model(prediction).
+		Select("actual_home_score", "actual_away_score", "outcome_correct", "exact_score", "brier_score", "log_loss", "settled_at").
+		Updates(prediction).Error
+}
+
+// Void marks a prediction as void, e.g. because its match was cancelled
+func (r *predictionHistoryRepository) Void(id uint, voidedAt time.Time) error {
+	return r.db.Model(&models.PredictionHistory{}).Where("id = ?", id).Update("voided_at", voidedAt).Error
+}
+
+// MarkChecked records a settlement attempt for the given predictions
+func (r *predictionHistoryRepository) MarkChecked(ids []uint, checkedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.PredictionHistory{}).Where("id IN ?", ids).Update("last_checked_at", checkedAt).Error
+}
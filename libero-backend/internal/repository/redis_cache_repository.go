@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"libero-backend/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	// redisCachePrefix namespaces cache items among other keys in Redis
+	redisCachePrefix = "libero:cache:"
+	// redisExpiredRetention keeps expired items around for revalidation, like the table keeps
+	// them until the next cleanup
+	redisExpiredRetention = time.Hour
+	// redisTimeout bounds a single cache operation
+	redisTimeout = 2 * time.Second
+)
+
+// redisUpdateScript sets fields of an item only if it exists. ARGV[1] is when the item may be
+// dropped in Unix milliseconds, or empty to leave it; the rest are field/value pairs.
+var redisUpdateScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], unpack(ARGV, 2))
+if ARGV[1] ~= '' then
+	redis.call('PEXPIREAT', KEYS[1], ARGV[1])
+end
+return 1
+`)
+
+// redisCacheRepository implements the CacheRepository interface with Redis hashes. Redis drops
+// items by itself once they are past their retention, so there is nothing to clean.
+type redisCacheRepository struct {
+	client *redis.Client
+}
+
+// NewRedisCacheRepository creates a new Redis cache repository instance. Missing items are
+// reported as gorm.ErrRecordNotFound, like the table-backed repository.
+func NewRedisCacheRepository(client *redis.Client) CacheRepository {
+	return &redisCacheRepository{client: client}
+}
+
+// Get retrieves a value from cache by key
+func (r *redisCacheRepository) Get(key string) (*models.CacheItem, error) {
+	item, err := r.GetIgnoringExpiry(key)
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(item.ExpiresAt) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return item, nil
+}
+
+// GetIgnoringExpiry retrieves a value from cache by key even if expired, e.g. to revalidate it
+func (r *redisCacheRepository) GetIgnoringExpiry(key string) (*models.CacheItem, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	fields, err := r.client.HGetAll(ctx, redisCachePrefix+key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	item := &models.CacheItem{
+		Key:   key,
+		Value: []byte(fields["value"]),
+		ETag:  fields["e_tag"],
+	}
+	if expiresAt, err := strconv.ParseInt(fields["expires_at"], 10, 64); err == nil {
+		item.ExpiresAt = time.UnixMilli(expiresAt)
+	}
+	if lastModified, err := strconv.ParseInt(fields["last_modified"], 10, 64); err == nil {
+		item.LastModified = time.UnixMilli(lastModified)
+	}
+	if createdAt, err := strconv.ParseInt(fields["created_at"], 10, 64); err == nil {
+		item.CreatedAt = time.UnixMilli(createdAt)
+	}
+	return item, nil
+}
+
+// GetWithVersion gets a cache item only if its version matches
+func (r *redisCacheRepository) GetWithVersion(key string, version string) (*models.CacheItem, error) {
+	item, err := r.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if item.ETag != version {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return item, nil
+}
+
+// Set stores a value in cache with an expiration time
+func (r *redisCacheRepository) Set(key string, value []byte, ttl time.Duration) error {
+	now := time.Now()
+	return r.SetWithMetadata(key, models.CacheItem{
+		Key:          key,
+		Value:        value,
+		ExpiresAt:    now.Add(ttl),
+		LastModified: now,
+	})
+}
+
+// SetWithMetadata stores a cache item with all metadata. Its CreatedAt defaults to now.
+func (r *redisCacheRepository) SetWithMetadata(key string, item models.CacheItem) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now()
+	}
+
+	redisKey := redisCachePrefix + key
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, redisKey,
+			"value", item.Value,
+			"e_tag", item.ETag,
+			"expires_at", item.ExpiresAt.UnixMilli(),
+			"last_modified", item.LastModified.UnixMilli(),
+			"created_at", item.CreatedAt.UnixMilli(),
+		)
+		pipe.PExpireAt(ctx, redisKey, item.ExpiresAt.Add(redisExpiredRetention))
+		return nil
+	})
+	return err
+}
+
+// UpdateVersion updates the version (ETag) of a cached item
+func (r *redisCacheRepository) UpdateVersion(key string, version string) error {
+	return r.update(key, time.Time{}, "e_tag", version, "last_modified", time.Now().UnixMilli())
+}
+
+// ExtendExpiry makes a cached item expire ttl from now, e.g. after it was revalidated
+func (r *redisCacheRepository) ExtendExpiry(key string, ttl time.Duration) error {
+	expiresAt := time.Now().Add(ttl)
+	return r.update(key, expiresAt.Add(redisExpiredRetention), "expires_at", expiresAt.UnixMilli())
+}
+
+// Delete removes a cached item
+func (r *redisCacheRepository) Delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	return r.client.Del(ctx, redisCachePrefix+key).Err()
+}
+
+// CleanExpiredCache is a no-op; Redis expires items itself
+func (r *redisCacheRepository) CleanExpiredCache() error {
+	return nil
+}
+
+// update sets fields of an existing item atomically, moving when it is dropped unless dropAt is zero
+func (r *redisCacheRepository) update(key string, dropAt time.Time, fieldValues ...interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	drop := ""
+	if !dropAt.IsZero() {
+		drop = strconv.FormatInt(dropAt.UnixMilli(), 10)
+	}
+	args := append([]interface{}{drop}, fieldValues...)
+	return redisUpdateScript.Run(ctx, r.client, []string{redisCachePrefix + key}, args...).Err()
+}
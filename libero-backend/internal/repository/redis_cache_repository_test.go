@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"libero-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// newTestRedis starts an in-memory Redis and returns a client for it
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+func TestRedisCacheRepositoryRoundTrip(t *testing.T) {
+	server, client := newTestRedis(t)
+	repo := NewRedisCacheRepository(client)
+
+	lastModified := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	stored := models.CacheItem{Key: "standings:PL", Value: []byte(`{"table":[]}`), ETag: `"abc"`, LastModified: lastModified, ExpiresAt: expiresAt}
+	if err := repo.SetWithMetadata(stored.Key, stored); err != nil {
+		t.Fatalf("SetWithMetadata() error = %v", err)
+	}
+
+	item, err := repo.Get(stored.Key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if string(item.Value) != string(stored.Value) || item.ETag != stored.ETag || item.Key != stored.Key {
+		t.Errorf("item = %+v, want %+v", item, stored)
+	}
+	if !item.LastModified.Equal(lastModified) || !item.ExpiresAt.Equal(expiresAt) || item.CreatedAt.IsZero() {
+		t.Errorf("times = last modified %v, expires %v, created %v; want %v, %v and set", item.LastModified, item.ExpiresAt, item.CreatedAt, lastModified, expiresAt)
+	}
+
+	// Redis keeps the item past its expiry for revalidation, then drops it
+	ttl := server.TTL(redisCachePrefix + stored.Key)
+	if ttl <= time.Hour+redisExpiredRetention-time.Minute || ttl > time.Hour+redisExpiredRetention {
+		t.Errorf("Redis TTL = %v, want the expiry plus %v", ttl, redisExpiredRetention)
+	}
+}
+
+func TestRedisCacheRepositoryExpiry(t *testing.T) {
+	_, client := newTestRedis(t)
+	repo := NewRedisCacheRepository(client)
+
+	expired := models.CacheItem{Key: "bracket:CL", Value: []byte(`{}`), ExpiresAt: time.Now().Add(-time.Minute)}
+	if err := repo.SetWithMetadata(expired.Key, expired); err != nil {
+		t.Fatalf("SetWithMetadata() error = %v", err)
+	}
+	if _, err := repo.Get(expired.Key); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Get() of an expired item error = %v, want gorm.ErrRecordNotFound", err)
+	}
+	if _, err := repo.GetIgnoringExpiry(expired.Key); err != nil {
+		t.Errorf("GetIgnoringExpiry() of an expired item error = %v", err)
+	}
+
+	if err := repo.ExtendExpiry(expired.Key, time.Hour); err != nil {
+		t.Fatalf("ExtendExpiry() error = %v", err)
+	}
+	if _, err := repo.Get(expired.Key); err != nil {
+		t.Errorf("Get() after ExtendExpiry() error = %v", err)
+	}
+
+	// Updates don't bring back items that are gone
+	if err := repo.Delete(expired.Key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.ExtendExpiry(expired.Key, time.Hour); err != nil {
+		t.Fatalf("ExtendExpiry() of a missing item error = %v", err)
+	}
+	if _, err := repo.GetIgnoringExpiry(expired.Key); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetIgnoringExpiry() after Delete() error = %v, want gorm.ErrRecordNotFound", err)
+	}
+}
@@ -7,6 +7,12 @@ type Repository struct {
 	User              UserRepository
 	Cache             CacheRepository
 	PredictionHistory PredictionHistoryRepository
+	UserPick          UserPickRepository
+	League            LeagueRepository
+	Match             MatchRepository
+	Competition       CompetitionRepository
+	Team              TeamRepository
+	Player            PlayerRepository
 	// Add more repositories here as needed
 }
 
@@ -16,6 +22,12 @@ func New(db *gorm.DB) *Repository {
 		User:              NewUserRepository(db),
 		Cache:             NewCacheRepository(db),
 		PredictionHistory: NewPredictionHistoryRepository(db),
+		UserPick:          NewUserPickRepository(db),
+		League:            NewLeagueRepository(db),
+		Match:             NewMatchRepository(db),
+		Competition:       NewCompetitionRepository(db),
+		Team:              NewTeamRepository(db),
+		Player:            NewPlayerRepository(db),
 		// Initialize other repositories here
 	}
 }
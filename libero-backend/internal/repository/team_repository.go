@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"libero-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TeamRepository defines the interface for team data operations
+type TeamRepository interface {
+	UpsertMany(teams []models.Team) error
+	FindByID(id uint) (*models.Team, error)
+	Search(filter models.CatalogFilter, page, limit int) ([]models.Team, int64, error)
+}
+
+// teamRepository implements the TeamRepository interface
+type teamRepository struct {
+	db *gorm.DB
+}
+
+// NewTeamRepository creates a new team repository instance
+func NewTeamRepository(db *gorm.DB) TeamRepository {
+	return &teamRepository{db: db}
+}
+
+// UpsertMany inserts the teams, or refreshes teams already known by provider ID.
+// The stored IDs are written back into the given slice.
+func (r *teamRepository) UpsertMany(teams []models.Team) error {
+	if len(teams) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "provider_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"name", "short_name", "tla", "logo_url", "country", "venue", "founded", "sport", "updated_at",
+		}),
+	}).Create(&teams).Error
+}
+
+// FindByID retrieves a team with the competitions it plays in
+func (r *teamRepository) FindByID(id uint) (*models.Team, error) {
+	var team models.Team
+	err := r.db.Preload("Competitions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("competitions.name ASC")
+	}).First(&team, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// Search retrieves teams matching the filter with pagination
+func (r *teamRepository) Search(filter models.CatalogFilter, page, limit int) ([]models.Team, int64, error) {
+	var teams []models.Team
+	var count int64
+
+	offset := (page - 1) * limit
+	search := textSearch(filter.Query, "name || ' ' || COALESCE(short_name, '') || ' ' || COALESCE(tla, '')")
+
+	if err := r.db.Model(&models.Team{}).Scopes(teamFilterScope(filter), search).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.db.Scopes(teamFilterScope(filter), search, searchRanking(filter.Query, "name")).
+		Offset(offset).
+		Limit(limit).
+		Find(&teams).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return teams, count, nil
+}
+
+// teamFilterScope applies the non-empty team filters
+func teamFilterScope(filter models.CatalogFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Country != "" {
+			db = db.Where("LOWER(country) = LOWER(?)", filter.Country)
+		}
+		if filter.Sport != "" {
+			db = db.Where("LOWER(sport) = LOWER(?)", filter.Sport)
+		}
+		if filter.Competition != "" {
+			db = db.Where("id IN (?)", competitionTeamIDs(db, filter.Competition))
+		}
+		return db
+	}
+}
+
+// competitionTeamIDs is a subquery selecting the IDs of the teams in a competition
+func competitionTeamIDs(db *gorm.DB, competitionCode string) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).
+		Table("competition_teams").
+		Select("competition_teams.team_id").
+		Joins("JOIN competitions ON competitions.id = competition_teams.competition_id").
+		Where("competitions.code = ?", competitionCode)
+}
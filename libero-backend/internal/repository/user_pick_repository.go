@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"libero-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserPickRepository defines the interface for user pick data operations
+type UserPickRepository interface {
+	Upsert(pick *models.UserPick) error
+	FindByUserAndMatch(userID uint, matchID int) (*models.UserPick, error)
+	FindByUserID(userID uint, status string, page, limit int) ([]models.UserPick, int64, error)
+	FindUnsettled(kickoffBefore time.Time, limit int) ([]models.UserPick, error)
+	Settle(pick *models.UserPick) error
+	Void(id uint, voidedAt time.Time) error
+	MarkChecked(ids []uint, checkedAt time.Time) error
+	GetLeaderboard(from, to time.Time, limit int) ([]models.LeaderboardEntry, error)
+	FindComparisons(userID uint) ([]models.PickComparisonRow, error)
+}
+
+// userPickRepository implements the UserPickRepository interface
+type userPickRepository struct {
+	db *gorm.DB
+}
+
+// NewUserPickRepository creates a new user pick repository instance
+func NewUserPickRepository(db *gorm.DB) UserPickRepository {
+	return &userPickRepository{db: db}
+}
+
+// Upsert creates a pick, or replaces the scoreline of the user's existing pick for the match
+func (r *userPickRepository) Upsert(pick *models.UserPick) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "match_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"home_team", "away_team", "kickoff_at", "predicted_home_score", "predicted_away_score", "updated_at"}),
+	}).Create(pick).Error
+}
+
+// FindByUserAndMatch retrieves a user's pick for a match
+func (r *userPickRepository) FindByUserAndMatch(userID uint, matchID int) (*models.UserPick, error) {
+	var pick models.UserPick
+	err := r.db.Where("user_id = ? AND match_id = ?", userID, matchID).First(&pick).Error
+	if err != nil {
+		return nil, err
+	}
+	return &pick, nil
+}
+
+// FindByUserID retrieves picks for a specific user with pagination, optionally filtered by status
+func (r *userPickRepository) FindByUserID(userID uint, status string, page, limit int) ([]models.UserPick, int64, error) {
+	var picks []models.UserPick
+	var count int64
+
+	offset := (page - 1) * limit
+
+	if err := withStatus(r.db.Model(&models.UserPick{}).Where("user_id = ?", userID), status).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Most recent kickoffs first
+	if err := withStatus(r.db.Where("user_id = ?", userID), status).
+		Order("kickoff_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&picks).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return picks, count, nil
+}
+
+// FindUnsettled retrieves picks for matches that kicked off before the given time and have been
+// neither settled nor voided yet, least recently checked first like predictions
+func (r *userPickRepository) FindUnsettled(kickoffBefore time.Time, limit int) ([]models.UserPick, error) {
+	var picks []models.UserPick
+	err := r.db.Where("settled_at IS NULL AND voided_at IS NULL AND kickoff_at < ?", kickoffBefore).
+		Order("last_checked_at ASC NULLS FIRST").
+		Order("kickoff_at ASC").
+		Limit(limit).
+		Find(&picks).Error
+	if err != nil {
+		return nil, err
+	}
+	return picks, nil
+}
+
+// Settle persists the settlement results of a pick
+func (r *userPickRepository) Settle(pick *models.UserPick) error {
+	return r.db.Model(pick).
+		Select("actual_home_score", "actual_away_score", "points", "settled_at").
+		Updates(pick).Error
+}
+
+// Void marks a pick as void, e.g. because its match was cancelled
+func (r *userPickRepository) Void(id uint, voidedAt time.Time) error {
+	return r.db.Model(&models.UserPick{}).Where("id = ?", id).Update("voided_at", voidedAt).Error
+}
+
+// MarkChecked records a settlement attempt for the given picks
+func (r *userPickRepository) MarkChecked(ids []uint, checkedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.UserPick{}).Where("id IN ?", ids).Update("last_checked_at", checkedAt).Error
+}
+
+// GetLeaderboard ranks users by the points of their picks settled for matches kicking off in [from, to)
+func (r *userPickRepository) GetLeaderboard(from, to time.Time, limit int) ([]models.LeaderboardEntry, error) {
+	var entries []models.LeaderboardEntry
+	err := r.db.Model(&models.UserPick{}).
+		Select(`RANK() OVER (ORDER BY SUM(user_picks.points) DESC, COUNT(*) FILTER (WHERE user_picks.points = ?) DESC) AS rank,
+			user_picks.user_id,
+			users.username,
+			users.name,
+			SUM(user_picks.points) AS points,
+			COUNT(*) AS picks,
+			COUNT(*) FILTER (WHERE user_picks.points = ?) AS exact_scores,
+			COUNT(*) FILTER (WHERE user_picks.points > 0) AS correct_outcomes`,
+			models.PickPointsExactScore, models.PickPointsExactScore).
+		Joins("JOIN users ON users.id = user_picks.user_id").
+		Where("user_picks.settled_at IS NOT NULL AND user_picks.kickoff_at >= ? AND user_picks.kickoff_at < ?", from, to).
+		Group("user_picks.user_id, users.username, users.name").
+		Order("rank, users.username").
+		Limit(limit).
+		Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindComparisons retrieves a user's settled picks together with the model's prediction for the
+// same match. The user's own saved model prediction is preferred, otherwise the latest one saved.
+func (r *userPickRepository) FindComparisons(userID uint) ([]models.PickComparisonRow, error) {
+	var rows []models.PickComparisonRow
+	err := r.db.Model(&models.UserPick{}).
+		Select(`user_picks.match_id, user_picks.home_team, user_picks.away_team, user_picks.kickoff_at,
+			user_picks.predicted_home_score, user_picks.predicted_away_score,
+			user_picks.actual_home_score, user_picks.actual_away_score,
+			model.predicted_home_score AS model_home_score,
+			model.predicted_away_score AS model_away_score`).
+		Joins(`JOIN LATERAL (
+			SELECT ph.predicted_home_score, ph.predicted_away_score
+			FROM prediction_histories ph
+			WHERE ph.match_id = user_picks.match_id
+			ORDER BY (ph.user_id = user_picks.user_id) DESC, ph.created_at DESC
+			LIMIT 1
+		) model ON TRUE`).
+		Where("user_picks.user_id = ? AND user_picks.settled_at IS NOT NULL", userID).
+		Order("user_picks.kickoff_at DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -5,6 +5,7 @@ import (
 	"libero-backend/config"
 	"libero-backend/internal/controllers"
 	"libero-backend/internal/middleware"
+	"libero-backend/internal/models"
 	"libero-backend/internal/repository"
 	"libero-backend/internal/service"
 	"net/http"
@@ -28,6 +29,9 @@ func SetupRoutes(router *mux.Router, service *service.Service, cfg *config.Confi
 	router.Use(middleware.CORSMiddleware)
 	router.Use(middleware.LoggingMiddleware)
 	router.Use(middleware.JSONMiddleware)
+	if cfg.DemoMode {
+		router.Use(middleware.DemoDataMiddleware)
+	}
 
 	// API routes
 	api := router.PathPrefix("/api").Subrouter()
@@ -45,16 +49,38 @@ func SetupRoutes(router *mux.Router, service *service.Service, cfg *config.Confi
 	api.HandleFunc("/sports/fixtures/today", ctrl.SportsData.HandleGetTodaysFixtures).Methods(http.MethodGet, http.MethodOptions)
 	api.HandleFunc("/sports/fixtures/summary", ctrl.SportsData.HandleGetFixturesSummary).Methods(http.MethodGet, http.MethodOptions)
 	api.HandleFunc("/standings", ctrl.SportsData.HandleGetStandings).Methods(http.MethodGet, http.MethodOptions)
+	api.HandleFunc("/standings/history", ctrl.SportsData.HandleGetStandingsHistory).Methods(http.MethodGet, http.MethodOptions)
 	api.HandleFunc("/topscorers", ctrl.SportsData.HandleGetTopScorers).Methods(http.MethodGet, http.MethodOptions)
+	api.HandleFunc("/matches", ctrl.Match.GetMatches).Methods(http.MethodGet, http.MethodOptions)
 	api.HandleFunc("/matches/upcoming", ctrl.SportsData.HandleGetUpcomingMatches).Methods(http.MethodGet, http.MethodOptions)
 	api.HandleFunc("/matches/results", ctrl.SportsData.HandleGetResults).Methods(http.MethodGet, http.MethodOptions)
 	api.HandleFunc("/players/{player_id}/stats", ctrl.SportsData.HandleGetPlayerStats).Methods(http.MethodGet, http.MethodOptions)
 
+	// Live match events (Server-Sent Events)
+	api.HandleFunc("/live/stream", ctrl.Live.Stream).Methods(http.MethodGet, http.MethodOptions)
+
+	// WebSocket topic subscriptions - authenticates itself, private topics need a token
+	api.HandleFunc("/ws", ctrl.Realtime.Connect).Methods(http.MethodGet, http.MethodOptions)
+
 	// Match prediction routes
 	api.HandleFunc("/predict/match", ctrl.Prediction.PredictMatch).Methods(http.MethodPost, http.MethodOptions)
 	api.HandleFunc("/predict/teams", ctrl.Prediction.GetAvailableTeams).Methods(http.MethodGet, http.MethodOptions)
 	api.HandleFunc("/predict/leagues", ctrl.Prediction.GetAvailableLeagues).Methods(http.MethodGet, http.MethodOptions)
 
+	// Catalogue routes - public, with followed state for authenticated users
+	optionalAuth := middleware.OptionalAuthMiddleware(authService)
+	api.Handle("/competitions", optionalAuth(http.HandlerFunc(ctrl.Catalog.GetCompetitions))).Methods(http.MethodGet, http.MethodOptions)
+	api.Handle("/competitions/{id:[0-9]+}", optionalAuth(http.HandlerFunc(ctrl.Catalog.GetCompetition))).Methods(http.MethodGet, http.MethodOptions)
+	api.HandleFunc("/competitions/{code:[A-Za-z0-9]+}/bracket", ctrl.SportsData.HandleGetBracket).Methods(http.MethodGet, http.MethodOptions)
+	api.HandleFunc("/competitions/{code:[A-Za-z0-9]+}/seasons", ctrl.SportsData.HandleGetSeasons).Methods(http.MethodGet, http.MethodOptions)
+	api.Handle("/teams", optionalAuth(http.HandlerFunc(ctrl.Catalog.GetTeams))).Methods(http.MethodGet, http.MethodOptions)
+	api.Handle("/teams/{id:[0-9]+}", optionalAuth(http.HandlerFunc(ctrl.Catalog.GetTeam))).Methods(http.MethodGet, http.MethodOptions)
+	api.Handle("/players", optionalAuth(http.HandlerFunc(ctrl.Catalog.GetPlayers))).Methods(http.MethodGet, http.MethodOptions)
+	api.Handle("/players/{id:[0-9]+}", optionalAuth(http.HandlerFunc(ctrl.Catalog.GetPlayer))).Methods(http.MethodGet, http.MethodOptions)
+
+	// Pick leaderboard
+	api.HandleFunc("/leaderboard", ctrl.Pick.GetLeaderboard).Methods(http.MethodGet, http.MethodOptions)
+
 	// OAuth routes - create subrouter and explicitly apply CORS middleware
 	auth := router.PathPrefix("/auth").Subrouter()
 	auth.Use(middleware.CORSMiddleware) // Explicitly apply CORS to OAuth subrouter
@@ -75,6 +101,7 @@ func SetupRoutes(router *mux.Router, service *service.Service, cfg *config.Confi
 	// User routes
 	protected.HandleFunc("/users/profile", ctrl.User.GetUserProfile).Methods(http.MethodGet, http.MethodOptions)
 	protected.HandleFunc("/users/preferences", ctrl.User.UpdateUserPreferences).Methods(http.MethodPut, http.MethodOptions)
+	protected.HandleFunc("/users/me/feed", ctrl.Feed.GetFeed).Methods(http.MethodGet, http.MethodOptions)
 
 	// Prediction history routes
 	protected.HandleFunc("/predictions", ctrl.PredictionHistory.CreatePrediction).Methods(http.MethodPost, http.MethodOptions)
@@ -82,4 +109,25 @@ func SetupRoutes(router *mux.Router, service *service.Service, cfg *config.Confi
 	protected.HandleFunc("/predictions", ctrl.PredictionHistory.DeleteAllPredictions).Methods(http.MethodDelete, http.MethodOptions)
 	protected.HandleFunc("/predictions/{id}", ctrl.PredictionHistory.DeletePrediction).Methods(http.MethodDelete, http.MethodOptions)
 	protected.HandleFunc("/predictions/statistics", ctrl.PredictionHistory.GetPredictionStatistics).Methods(http.MethodGet, http.MethodOptions)
+	protected.HandleFunc("/predictions/statistics/accuracy", ctrl.PredictionHistory.GetPredictionAccuracy).Methods(http.MethodGet, http.MethodOptions)
+
+	// User pick routes
+	protected.HandleFunc("/picks", ctrl.Pick.SubmitPick).Methods(http.MethodPost, http.MethodOptions)
+	protected.HandleFunc("/picks", ctrl.Pick.GetPicks).Methods(http.MethodGet, http.MethodOptions)
+	protected.HandleFunc("/picks/comparison", ctrl.Pick.GetComparison).Methods(http.MethodGet, http.MethodOptions)
+
+	// Admin routes
+	admin := middleware.RoleMiddleware(models.UserRoleAdmin)
+	protected.Handle("/health/cache", admin(http.HandlerFunc(ctrl.SportsData.HandleGetCacheMetrics))).Methods(http.MethodGet, http.MethodOptions)
+
+	// League routes - league-scoped roles are checked per route
+	leagueMember := middleware.LeagueRoleMiddleware(service.League, models.LeagueRoleAdmin, models.LeagueRoleMember)
+	leagueAdmin := middleware.LeagueRoleMiddleware(service.League, models.LeagueRoleAdmin)
+	protected.HandleFunc("/leagues", ctrl.League.CreateLeague).Methods(http.MethodPost, http.MethodOptions)
+	protected.HandleFunc("/leagues", ctrl.League.GetLeagues).Methods(http.MethodGet, http.MethodOptions)
+	protected.HandleFunc("/leagues/join", ctrl.League.JoinLeague).Methods(http.MethodPost, http.MethodOptions)
+	protected.Handle("/leagues/{id:[0-9]+}", leagueMember(http.HandlerFunc(ctrl.League.GetLeague))).Methods(http.MethodGet, http.MethodOptions)
+	protected.Handle("/leagues/{id:[0-9]+}/standings", leagueMember(http.HandlerFunc(ctrl.League.GetStandings))).Methods(http.MethodGet, http.MethodOptions)
+	protected.Handle("/leagues/{id:[0-9]+}/members/{userId:[0-9]+}", leagueAdmin(http.HandlerFunc(ctrl.League.RemoveMember))).Methods(http.MethodDelete, http.MethodOptions)
+	protected.Handle("/leagues/{id:[0-9]+}/seasons/archive", leagueAdmin(http.HandlerFunc(ctrl.League.ArchiveSeason))).Methods(http.MethodPost, http.MethodOptions)
 }
@@ -9,18 +9,28 @@ import (
 
 // Scheduler manages periodic background tasks.
 type Scheduler struct {
-	fixturesService service.FixturesService
-	ctx             context.Context
-	cancel          context.CancelFunc
+	fixturesService   service.FixturesService
+	cacheService      service.CacheService
+	settlementService service.SettlementService
+	catalogService    service.CatalogService
+	liveService       service.LiveService
+	historyService    service.StandingsHistoryService
+	ctx               context.Context
+	cancel            context.CancelFunc
 }
 
 // New creates a new scheduler.
-func New(fixturesService service.FixturesService) *Scheduler {
+func New(fixturesService service.FixturesService, cacheService service.CacheService, settlementService service.SettlementService, catalogService service.CatalogService, liveService service.LiveService, historyService service.StandingsHistoryService) *Scheduler {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Scheduler{
-		fixturesService: fixturesService,
-		ctx:             ctx,
-		cancel:          cancel,
+		fixturesService:   fixturesService,
+		cacheService:      cacheService,
+		settlementService: settlementService,
+		catalogService:    catalogService,
+		liveService:       liveService,
+		historyService:    historyService,
+		ctx:               ctx,
+		cancel:            cancel,
 	}
 }
 
@@ -31,6 +41,15 @@ func (s *Scheduler) Start() {
 
 	// Start the task to refresh fixtures summary for all major competitions
 	go s.scheduleFixturesSummaries()
+
+	// Start the task to settle predictions against finished matches
+	go s.scheduleSettlement()
+
+	// Start the task to import competitions, teams, squads and season results once a day
+	go s.scheduleCatalogSync()
+
+	// Start the task to poll live scores, adapting its interval to what is in play
+	go s.scheduleLivePolling()
 }
 
 // Stop terminates all scheduled tasks.
@@ -90,10 +109,93 @@ func (s *Scheduler) scheduleFixturesSummaries() {
 	}
 }
 
-// fetchTodayFixtures gets today's fixtures and logs any errors.
+// scheduleSettlement settles pending predictions every 15 minutes.
+func (s *Scheduler) scheduleSettlement() {
+	// Wait a minute before starting so the fixtures refresh gets the API first
+	select {
+	case <-time.After(1 * time.Minute):
+	case <-s.ctx.Done():
+		return
+	}
+
+	// First run immediately
+	s.settlePredictions()
+
+	ticker := time.NewTicker(15 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.settlePredictions()
+		case <-s.ctx.Done():
+			log.Println("Settlement scheduler stopped")
+			return
+		}
+	}
+}
+
+// scheduleCatalogSync imports competitions, their teams and squads, and stores their season's
+// matches for the standings history every 24 hours.
+func (s *Scheduler) scheduleCatalogSync() {
+	// Major competition codes
+	comps := []string{"PL", "PD", "SA", "BL1", "FL1", "CL", "EL"}
+
+	// Wait two minutes before starting so the fixtures refreshes get the API first
+	select {
+	case <-time.After(2 * time.Minute):
+	case <-s.ctx.Done():
+		return
+	}
+
+	// First run immediately
+	for _, comp := range comps {
+		s.syncCompetition(comp)
+		s.backfillSeason(comp)
+	}
+
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, comp := range comps {
+				s.syncCompetition(comp)
+				s.backfillSeason(comp)
+			}
+		case <-s.ctx.Done():
+			log.Println("Catalog sync scheduler stopped")
+			return
+		}
+	}
+}
+
+// scheduleLivePolling polls live scores. The live service decides the interval after each poll,
+// polling tightly while matches are in play and backing off otherwise.
+func (s *Scheduler) scheduleLivePolling() {
+	// Wait 30 seconds before starting to avoid overwhelming the API on startup
+	interval := 30 * time.Second
+
+	for {
+		select {
+		case <-time.After(interval):
+			var err error
+			interval, err = s.liveService.Poll(s.ctx)
+			if err != nil {
+				log.Printf("Scheduler: Error polling live scores: %v", err)
+			}
+		case <-s.ctx.Done():
+			log.Println("Live score poller stopped")
+			return
+		}
+	}
+}
+
+// fetchTodayFixtures refreshes the cached today's fixtures and logs any errors.
 func (s *Scheduler) fetchTodayFixtures() {
 	log.Println("Scheduler: Refreshing today's fixtures")
-	_, err := s.fixturesService.GetTodaysFixtures()
+	_, err := s.cacheService.Refresh(s.ctx, service.CacheTodayFixtures, service.TodaysFixturesCacheKey(), service.TodaysFixturesFetch(s.fixturesService))
 	if err != nil {
 		log.Printf("Scheduler: Error refreshing today's fixtures: %v", err)
 	} else {
@@ -101,13 +203,46 @@ func (s *Scheduler) fetchTodayFixtures() {
 	}
 }
 
-// fetchFixturesSummary gets fixtures summary for a competition and logs any errors.
+// fetchFixturesSummary refreshes the cached fixtures summary for a competition and logs any errors.
 func (s *Scheduler) fetchFixturesSummary(competitionCode string) {
 	log.Printf("Scheduler: Refreshing fixtures summary for %s", competitionCode)
-	_, err := s.fixturesService.GetFixturesSummary(competitionCode)
+	_, err := s.cacheService.Refresh(s.ctx, service.CacheFixturesSummary, competitionCode, service.FixturesSummaryFetch(s.fixturesService, competitionCode))
 	if err != nil {
 		log.Printf("Scheduler: Error refreshing fixtures summary for %s: %v", competitionCode, err)
 	} else {
 		log.Printf("Scheduler: Fixtures summary for %s refreshed successfully", competitionCode)
 	}
 }
+
+// settlePredictions settles pending predictions and logs the outcome.
+func (s *Scheduler) settlePredictions() {
+	settled, err := s.settlementService.SettlePending(s.ctx)
+	if err != nil {
+		log.Printf("Scheduler: Error settling predictions: %v", err)
+		return
+	}
+	if settled > 0 {
+		log.Printf("Scheduler: Settled %d predictions", settled)
+	}
+}
+
+// syncCompetition imports a competition with its teams and squads and logs the outcome.
+func (s *Scheduler) syncCompetition(competitionCode string) {
+	log.Printf("Scheduler: Syncing competition %s", competitionCode)
+	result, err := s.catalogService.SyncCompetition(s.ctx, competitionCode)
+	if err != nil {
+		log.Printf("Scheduler: Error syncing competition %s: %v", competitionCode, err)
+		return
+	}
+	log.Printf("Scheduler: Synced %s with %d teams and %d players", competitionCode, result.Teams, result.Players)
+}
+
+// backfillSeason stores a competition's season matches and logs the outcome.
+func (s *Scheduler) backfillSeason(competitionCode string) {
+	stored, err := s.historyService.BackfillSeason(s.ctx, competitionCode)
+	if err != nil {
+		log.Printf("Scheduler: Error backfilling season matches for %s: %v", competitionCode, err)
+		return
+	}
+	log.Printf("Scheduler: Stored %d season matches for %s", stored, competitionCode)
+}
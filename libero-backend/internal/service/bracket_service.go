@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"libero-backend/internal/models"
+	"libero-backend/internal/provider"
+	"sort"
+	"time"
+)
+
+// knockoutStages are the knockout stages of a cup in the order they are played, as
+// football-data.org names them. Qualifying rounds aren't part of the bracket.
+var knockoutStages = []string{"PLAYOFFS", "LAST_32", "LAST_16", "QUARTER_FINALS", "SEMI_FINALS", "THIRD_PLACE", "FINAL"}
+
+// singleLegStages are the knockout stages decided in a single match
+var singleLegStages = map[string]bool{"THIRD_PLACE": true, "FINAL": true}
+
+// awayGoalsAbolished is when UEFA stopped deciding level ties on away goals
+var awayGoalsAbolished = time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+// How a tie was decided
+const (
+	decidedByAggregate = "AGGREGATE"
+	decidedByAwayGoals = "AWAY_GOALS"
+	decidedByExtraTime = "EXTRA_TIME"
+	decidedByPenalties = "PENALTIES"
+)
+
+// BracketService defines the interface for knockout bracket operations
+type BracketService interface {
+	GetBracket(ctx context.Context, competitionCode string) (*models.BracketDTO, error)
+}
+
+// bracketService implements the BracketService interface on top of the football service
+type bracketService struct {
+	footballService *FootballService
+}
+
+// NewBracketService creates a new bracket service instance
+func NewBracketService(footballService *FootballService) BracketService {
+	return &bracketService{footballService: footballService}
+}
+
+// GetBracket builds a competition's knockout bracket from its matches by stage, with the tables of
+// its league or group phase if they can be fetched. Competitions without knockout rounds, like
+// leagues, have no rounds.
+// Callers cache the result through the cache service.
+func (s *bracketService) GetBracket(ctx context.Context, competitionCode string) (*models.BracketDTO, error) {
+	bracket := &models.BracketDTO{
+		CompetitionCode: competitionCode,
+		Groups:          make([]models.StandingsGroupDTO, 0),
+		Rounds:          make([]models.BracketRoundDTO, 0),
+		Demo:            s.footballService.demo,
+	}
+
+	matches, err := s.footballService.GetCompetitionMatches(ctx, competitionCode)
+	if errors.Is(err, provider.ErrNotFound) {
+		// Return an empty bracket for unsupported competitions
+		return bracket, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) > 0 {
+		bracket.CompetitionName = matches[0].Competition.Name
+	}
+
+	// Cups played as knockouts throughout come without tables, and a bracket is still worth
+	// showing when the tables can't be fetched
+	standings, err := s.footballService.GetStandings(ctx, competitionCode, models.StandingsTypeTotal, 0)
+	if err != nil {
+		fmt.Printf("[WARN] Building %s bracket without tables: %v\n", competitionCode, err)
+	} else {
+		if standings.CompetitionName != "" {
+			bracket.CompetitionName = standings.CompetitionName
+		}
+		bracket.Season = standings.Season
+		bracket.Groups = standings.Groups
+	}
+
+	byStage := make(map[string][]models.MatchResponse)
+	for _, m := range matches {
+		byStage[m.Stage] = append(byStage[m.Stage], m)
+	}
+	for _, stage := range knockoutStages {
+		if len(byStage[stage]) == 0 {
+			continue
+		}
+		round := models.BracketRoundDTO{Stage: stage, Ties: make([]models.BracketTieDTO, 0)}
+		for i, legs := range tieLegs(stage, byStage[stage]) {
+			round.Ties = append(round.Ties, bracketTie(fmt.Sprintf("%s-%d", stage, i+1), legs))
+		}
+		bracket.Rounds = append(bracket.Rounds, round)
+	}
+	linkRounds(bracket.Rounds)
+	return bracket, nil
+}
+
+// tieLegs groups a round's matches into ties, each with its legs by kickoff. Matches are paired by
+// their teams; matches whose teams aren't known yet are paired first legs with second legs in
+// kickoff order.
+func tieLegs(stage string, matches []models.MatchResponse) [][]models.MatchResponse {
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].UtcDate.Equal(matches[j].UtcDate) {
+			return matches[i].ID < matches[j].ID
+		}
+		return matches[i].UtcDate.Before(matches[j].UtcDate)
+	})
+
+	var ties [][]models.MatchResponse
+	byPair := make(map[[2]int]int) // Index into ties by team IDs, lower first
+	var undecided []models.MatchResponse
+	for _, m := range matches {
+		if m.HomeTeam.ID == 0 || m.AwayTeam.ID == 0 {
+			undecided = append(undecided, m)
+			continue
+		}
+		pair := [2]int{m.HomeTeam.ID, m.AwayTeam.ID}
+		if pair[0] > pair[1] {
+			pair[0], pair[1] = pair[1], pair[0]
+		}
+		if i, ok := byPair[pair]; ok && !singleLegStages[stage] {
+			ties[i] = append(ties[i], m)
+			continue
+		}
+		byPair[pair] = len(ties)
+		ties = append(ties, []models.MatchResponse{m})
+	}
+
+	if singleLegStages[stage] || len(undecided)%2 != 0 {
+		for _, m := range undecided {
+			ties = append(ties, []models.MatchResponse{m})
+		}
+		return ties
+	}
+	half := len(undecided) / 2
+	for i := 0; i < half; i++ {
+		ties = append(ties, []models.MatchResponse{undecided[i], undecided[half+i]})
+	}
+	return ties
+}
+
+// bracketTie sums up a tie over its legs. Scores count the team hosting the first leg first, and
+// only goals scored in play go into the aggregate.
+func bracketTie(id string, legs []models.MatchResponse) models.BracketTieDTO {
+	first := legs[0]
+	tie := models.BracketTieDTO{
+		ID:       id,
+		HomeTeam: bracketTeam(first.HomeTeam),
+		AwayTeam: bracketTeam(first.AwayTeam),
+		Legs:     make([]models.FixtureMatchDTO, 0, len(legs)),
+	}
+
+	var aggregate, awayGoals models.ScoreDTO
+	played, finished := false, true
+	for i, leg := range legs {
+		tie.Legs = append(tie.Legs, fixtureFromMatch(leg))
+		if leg.Status != "FINISHED" && leg.Status != "AWARDED" {
+			finished = false
+		}
+
+		// Second legs swap home and away, which the teams confirm once they are known
+		swapped := i%2 == 1
+		if leg.HomeTeam.ID != 0 && first.HomeTeam.ID != 0 {
+			swapped = leg.HomeTeam.ID != first.HomeTeam.ID
+		}
+		result := leg.Score.Result()
+		if result.Home == nil || result.Away == nil {
+			continue
+		}
+		played = true
+		home, away := *result.Home, *result.Away
+		if swapped {
+			home, away = away, home
+			awayGoals.Home += home
+		} else {
+			awayGoals.Away += away
+		}
+		aggregate.Home += home
+		aggregate.Away += away
+
+		if leg.Score.Duration == "EXTRA_TIME" || leg.Score.Duration == "PENALTY_SHOOTOUT" {
+			tie.ExtraTime = true
+		}
+		if penalties := scoreDTO(leg.Score.Penalties); penalties != nil {
+			if swapped {
+				penalties.Home, penalties.Away = penalties.Away, penalties.Home
+			}
+			tie.Penalties = penalties
+		}
+	}
+	if !played {
+		return tie
+	}
+
+	tie.Aggregate = &aggregate
+	if len(legs) == 2 {
+		tie.AwayGoals = &awayGoals
+	}
+	if finished && tie.HomeTeam.ID != 0 && tie.AwayTeam.ID != 0 {
+		tie.Winner, tie.DecidedBy = tieWinner(tie, first.UtcDate)
+	}
+	return tie
+}
+
+// tieWinner decides a finished tie on penalties or on aggregate, and for ties played before their
+// abolition on away goals. Both are empty for a tie left level.
+func tieWinner(tie models.BracketTieDTO, kickoff time.Time) (winner, decidedBy string) {
+	if p := tie.Penalties; p != nil && p.Home != p.Away {
+		return sideAhead(p.Home, p.Away), decidedByPenalties
+	}
+	if a := tie.Aggregate; a.Home != a.Away {
+		// Extra time is only played when the teams are level
+		if tie.ExtraTime {
+			return sideAhead(a.Home, a.Away), decidedByExtraTime
+		}
+		return sideAhead(a.Home, a.Away), decidedByAggregate
+	}
+	if g := tie.AwayGoals; g != nil && g.Home != g.Away && kickoff.Before(awayGoalsAbolished) {
+		return sideAhead(g.Home, g.Away), decidedByAwayGoals
+	}
+	return "", ""
+}
+
+// sideAhead names the side with more of something, in the terms of match winners
+func sideAhead(home, away int) string {
+	if home > away {
+		return "HOME_TEAM"
+	}
+	return "AWAY_TEAM"
+}
+
+// bracketTeam converts a provider team into a bracket team, named TBD if it isn't known yet
+func bracketTeam(team models.TeamResponse) models.BracketTeamDTO {
+	return models.BracketTeamDTO{ID: team.ID, Name: teamNameOrTBD(team), Crest: team.Crest}
+}
+
+// linkRounds points every decided tie at the tie its winner plays next, then puts each round in
+// bracket order, following the ties of the round after it. The third-place play-off isn't
+// anybody's next tie.
+func linkRounds(rounds []models.BracketRoundDTO) {
+	for r := range rounds {
+		next := nextBracketRound(rounds, r)
+		if next < 0 {
+			continue
+		}
+		for i := range rounds[r].Ties {
+			tie := &rounds[r].Ties[i]
+			winner := tie.HomeTeam.ID
+			switch tie.Winner {
+			case "HOME_TEAM":
+			case "AWAY_TEAM":
+				winner = tie.AwayTeam.ID
+			default:
+				continue
+			}
+			for _, candidate := range rounds[next].Ties {
+				if candidate.HomeTeam.ID == winner || candidate.AwayTeam.ID == winner {
+					tie.NextTie = candidate.ID
+					break
+				}
+			}
+		}
+	}
+
+	for r := len(rounds) - 1; r >= 0; r-- {
+		next := nextBracketRound(rounds, r)
+		if next < 0 {
+			continue
+		}
+		positions := make(map[string]int, len(rounds[next].Ties))
+		for i, tie := range rounds[next].Ties {
+			positions[tie.ID] = i
+		}
+		ties := rounds[r].Ties
+		sort.SliceStable(ties, func(i, j int) bool {
+			pi, iLinked := positions[ties[i].NextTie]
+			pj, jLinked := positions[ties[j].NextTie]
+			if iLinked != jLinked {
+				return iLinked
+			}
+			return iLinked && pi < pj
+		})
+	}
+}
+
+// nextBracketRound returns the index of the round the winners of round r go on to, or -1
+func nextBracketRound(rounds []models.BracketRoundDTO, r int) int {
+	for next := r + 1; next < len(rounds); next++ {
+		if rounds[next].Stage != "THIRD_PLACE" {
+			return next
+		}
+	}
+	return -1
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"libero-backend/internal/models"
+	"slices"
+	"testing"
+	"time"
+)
+
+// knockoutLeg is a knockout match between two teams, 0 standing for a team not known yet
+func knockoutLeg(id int, stage string, kickoff time.Time, homeID, awayID int) models.MatchResponse {
+	return models.MatchResponse{
+		ID:       id,
+		UtcDate:  kickoff,
+		Status:   "SCHEDULED",
+		Stage:    stage,
+		HomeTeam: models.TeamResponse{ID: homeID, Name: historyTeams[homeID]},
+		AwayTeam: models.TeamResponse{ID: awayID, Name: historyTeams[awayID]},
+	}
+}
+
+// played finishes a leg with the given score after duration. Penalties, if any, are included in the
+// full-time score like the provider does.
+func played(m models.MatchResponse, duration string, homeScore, awayScore int, penalties ...int) models.MatchResponse {
+	m.Status = "FINISHED"
+	m.Score = models.MatchScoreResponse{Duration: duration, FullTime: models.ScoreResponse{Home: intPtr(homeScore), Away: intPtr(awayScore)}}
+	if len(penalties) == 2 {
+		m.Score.Penalties = models.ScoreResponse{Home: intPtr(penalties[0]), Away: intPtr(penalties[1])}
+		m.Score.FullTime = models.ScoreResponse{Home: intPtr(homeScore + penalties[0]), Away: intPtr(awayScore + penalties[1])}
+	}
+	return m
+}
+
+func TestBracketTie(t *testing.T) {
+	before := time.Date(2019, time.March, 5, 20, 0, 0, 0, time.UTC)
+	after := time.Date(2022, time.March, 8, 20, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+	tests := []struct {
+		name          string
+		legs          []models.MatchResponse
+		wantAggregate *models.ScoreDTO
+		wantAwayGoals *models.ScoreDTO
+		wantWinner    string
+		wantDecidedBy string
+	}{
+		{
+			name: "aggregate",
+			legs: []models.MatchResponse{
+				played(knockoutLeg(1, "LAST_16", after, 1, 2), "REGULAR", 3, 0),
+				played(knockoutLeg(2, "LAST_16", after.Add(week), 2, 1), "REGULAR", 1, 1),
+			},
+			wantAggregate: &models.ScoreDTO{Home: 4, Away: 1},
+			wantAwayGoals: &models.ScoreDTO{Home: 1, Away: 0},
+			wantWinner:    "HOME_TEAM",
+			wantDecidedBy: decidedByAggregate,
+		},
+		{
+			name: "away goals before their abolition",
+			legs: []models.MatchResponse{
+				played(knockoutLeg(1, "LAST_16", before, 1, 2), "REGULAR", 2, 1),
+				played(knockoutLeg(2, "LAST_16", before.Add(week), 2, 1), "REGULAR", 1, 0),
+			},
+			wantAggregate: &models.ScoreDTO{Home: 2, Away: 2},
+			wantAwayGoals: &models.ScoreDTO{Home: 0, Away: 1},
+			wantWinner:    "AWAY_TEAM",
+			wantDecidedBy: decidedByAwayGoals,
+		},
+		{
+			name: "extra time once away goals were abolished",
+			legs: []models.MatchResponse{
+				played(knockoutLeg(1, "LAST_16", after, 1, 2), "REGULAR", 1, 0),
+				played(knockoutLeg(2, "LAST_16", after.Add(week), 2, 1), "EXTRA_TIME", 2, 0),
+			},
+			wantAggregate: &models.ScoreDTO{Home: 1, Away: 2},
+			wantAwayGoals: &models.ScoreDTO{Home: 0, Away: 0},
+			wantWinner:    "AWAY_TEAM",
+			wantDecidedBy: decidedByExtraTime,
+		},
+		{
+			name: "shootout in the second leg",
+			legs: []models.MatchResponse{
+				played(knockoutLeg(1, "SEMI_FINALS", after, 1, 2), "REGULAR", 1, 1),
+				played(knockoutLeg(2, "SEMI_FINALS", after.Add(week), 2, 1), "PENALTY_SHOOTOUT", 0, 0, 5, 4),
+			},
+			wantAggregate: &models.ScoreDTO{Home: 1, Away: 1},
+			wantAwayGoals: &models.ScoreDTO{Home: 0, Away: 1},
+			wantWinner:    "AWAY_TEAM",
+			wantDecidedBy: decidedByPenalties,
+		},
+		{
+			name:          "shootout in a final",
+			legs:          []models.MatchResponse{played(knockoutLeg(1, "FINAL", after, 3, 4), "PENALTY_SHOOTOUT", 1, 1, 4, 3)},
+			wantAggregate: &models.ScoreDTO{Home: 1, Away: 1},
+			wantWinner:    "HOME_TEAM",
+			wantDecidedBy: decidedByPenalties,
+		},
+		{
+			name: "second leg to play",
+			legs: []models.MatchResponse{
+				played(knockoutLeg(1, "LAST_16", after, 1, 2), "REGULAR", 0, 2),
+				knockoutLeg(2, "LAST_16", after.Add(week), 2, 1),
+			},
+			wantAggregate: &models.ScoreDTO{Home: 0, Away: 2},
+			wantAwayGoals: &models.ScoreDTO{Home: 0, Away: 2},
+		},
+		{
+			name: "not started",
+			legs: []models.MatchResponse{knockoutLeg(1, "LAST_16", after, 0, 0), knockoutLeg(2, "LAST_16", after.Add(week), 0, 0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tie := bracketTie("TIE-1", tt.legs)
+			if !equalScore(tie.Aggregate, tt.wantAggregate) {
+				t.Errorf("aggregate = %v, want %v", tie.Aggregate, tt.wantAggregate)
+			}
+			if !equalScore(tie.AwayGoals, tt.wantAwayGoals) {
+				t.Errorf("away goals = %v, want %v", tie.AwayGoals, tt.wantAwayGoals)
+			}
+			if tie.Winner != tt.wantWinner || tie.DecidedBy != tt.wantDecidedBy {
+				t.Errorf("winner = %q by %q, want %q by %q", tie.Winner, tie.DecidedBy, tt.wantWinner, tt.wantDecidedBy)
+			}
+			if len(tie.Legs) != len(tt.legs) {
+				t.Errorf("got %d legs, want %d", len(tie.Legs), len(tt.legs))
+			}
+		})
+	}
+}
+
+// equalScore reports whether two optional score pairs are the same
+func equalScore(a, b *models.ScoreDTO) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func TestTieLegs(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, time.April, d, 20, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name     string
+		stage    string
+		matches  []models.MatchResponse
+		wantLegs [][]int // Match IDs of each tie's legs
+	}{
+		{
+			name:  "legs paired by teams",
+			stage: "QUARTER_FINALS",
+			matches: []models.MatchResponse{
+				knockoutLeg(3, "QUARTER_FINALS", day(15), 2, 1),
+				knockoutLeg(1, "QUARTER_FINALS", day(8), 1, 2),
+				knockoutLeg(4, "QUARTER_FINALS", day(16), 4, 3),
+				knockoutLeg(2, "QUARTER_FINALS", day(9), 3, 4),
+			},
+			wantLegs: [][]int{{1, 3}, {2, 4}},
+		},
+		{
+			name:  "TBD legs paired first legs with second legs in kickoff order",
+			stage: "SEMI_FINALS",
+			matches: []models.MatchResponse{
+				knockoutLeg(11, "SEMI_FINALS", day(29), 0, 0),
+				knockoutLeg(12, "SEMI_FINALS", day(30), 0, 0),
+				knockoutLeg(13, "SEMI_FINALS", day(6), 0, 0),
+				knockoutLeg(14, "SEMI_FINALS", day(7), 0, 0),
+			},
+			wantLegs: [][]int{{13, 11}, {14, 12}},
+		},
+		{
+			name:  "odd number of TBD legs left unpaired",
+			stage: "SEMI_FINALS",
+			matches: []models.MatchResponse{
+				knockoutLeg(11, "SEMI_FINALS", day(29), 1, 2),
+				knockoutLeg(12, "SEMI_FINALS", day(30), 0, 0),
+				knockoutLeg(13, "SEMI_FINALS", day(6), 2, 1),
+				knockoutLeg(14, "SEMI_FINALS", day(7), 0, 0),
+				knockoutLeg(15, "SEMI_FINALS", day(8), 0, 0),
+			},
+			wantLegs: [][]int{{13, 11}, {14}, {15}, {12}},
+		},
+		{
+			name:  "single-leg stage",
+			stage: "FINAL",
+			matches: []models.MatchResponse{
+				knockoutLeg(21, "FINAL", day(30), 0, 0),
+				knockoutLeg(22, "FINAL", day(31), 0, 0),
+			},
+			wantLegs: [][]int{{21}, {22}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ties := tieLegs(tt.stage, tt.matches)
+			got := make([][]int, 0, len(ties))
+			for _, legs := range ties {
+				ids := make([]int, 0, len(legs))
+				for _, leg := range legs {
+					ids = append(ids, leg.ID)
+				}
+				got = append(got, ids)
+			}
+			if !slices.EqualFunc(got, tt.wantLegs, slices.Equal[[]int]) {
+				t.Errorf("ties = %v, want %v", got, tt.wantLegs)
+			}
+		})
+	}
+}
+
+func TestLinkRounds(t *testing.T) {
+	tie := func(id string, homeID, awayID int, winner string) models.BracketTieDTO {
+		return models.BracketTieDTO{ID: id, HomeTeam: models.BracketTeamDTO{ID: homeID}, AwayTeam: models.BracketTeamDTO{ID: awayID}, Winner: winner}
+	}
+	rounds := []models.BracketRoundDTO{
+		{Stage: "QUARTER_FINALS", Ties: []models.BracketTieDTO{
+			tie("QF-1", 1, 2, "HOME_TEAM"),
+			tie("QF-2", 3, 4, "HOME_TEAM"),
+			tie("QF-3", 5, 6, "AWAY_TEAM"),
+			tie("QF-4", 7, 8, ""),
+		}},
+		{Stage: "SEMI_FINALS", Ties: []models.BracketTieDTO{
+			tie("SF-1", 3, 0, ""),
+			tie("SF-2", 6, 1, "AWAY_TEAM"),
+		}},
+		{Stage: "THIRD_PLACE", Ties: []models.BracketTieDTO{tie("THIRD_PLACE-1", 6, 0, "")}},
+		{Stage: "FINAL", Ties: []models.BracketTieDTO{tie("FINAL-1", 1, 0, "")}},
+	}
+	linkRounds(rounds)
+
+	wantNext := map[string]string{"QF-1": "SF-2", "QF-2": "SF-1", "QF-3": "SF-2", "QF-4": "", "SF-1": "", "SF-2": "FINAL-1", "THIRD_PLACE-1": ""}
+	// Decided ties come first, following the ties they feed
+	wantOrder := [][]string{{"QF-1", "QF-3", "QF-2", "QF-4"}, {"SF-2", "SF-1"}, {"THIRD_PLACE-1"}, {"FINAL-1"}}
+	for r, round := range rounds {
+		order := make([]string, 0, len(round.Ties))
+		for _, tie := range round.Ties {
+			order = append(order, tie.ID)
+			if next, ok := wantNext[tie.ID]; ok && tie.NextTie != next {
+				t.Errorf("%s goes on to %q, want %q", tie.ID, tie.NextTie, next)
+			}
+		}
+		if !slices.Equal(order, wantOrder[r]) {
+			t.Errorf("%s order = %v, want %v", round.Stage, order, wantOrder[r])
+		}
+	}
+}
+
+func TestGetBracketWithoutStandings(t *testing.T) {
+	kickoff := time.Date(2025, time.May, 31, 19, 0, 0, 0, time.UTC)
+	final := played(knockoutLeg(1, "FINAL", kickoff, 1, 2), "REGULAR", 5, 0)
+	final.Competition = models.MatchCompetitionResponse{Name: "Champions League", Code: "CL"}
+	fd := &stubProvider{matches: []models.MatchResponse{final}, standingsErr: errors.New("provider unavailable")}
+
+	bracket, err := NewBracketService(NewFootballService(fd, nil)).GetBracket(context.Background(), "CL")
+	if err != nil {
+		t.Fatalf("GetBracket() error = %v", err)
+	}
+	if len(bracket.Groups) != 0 || bracket.Groups == nil {
+		t.Errorf("groups = %v, want none", bracket.Groups)
+	}
+	if len(bracket.Rounds) != 1 || bracket.Rounds[0].Ties[0].Winner != "HOME_TEAM" {
+		t.Errorf("rounds = %+v, want the final won by the home team", bracket.Rounds)
+	}
+	if bracket.CompetitionName != "Champions League" {
+		t.Errorf("competition name = %q, want it from the matches", bracket.CompetitionName)
+	}
+}
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"libero-backend/internal/models"
+	"libero-backend/internal/provider"
+	"libero-backend/internal/repository"
+	"libero-backend/internal/utils"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+// CacheNamespace is a kind of cached data. Keys are stored as "<namespace>:<key>" and each
+// namespace has its own TTL policy and metrics.
+type CacheNamespace string
+
+const (
+	CacheStandings        CacheNamespace = "standings"         // Keyed by competition code, season and table type
+	CacheStandingsArchive CacheNamespace = "standings_archive" // Standings of completed seasons, keyed like CacheStandings
+	CacheScorers          CacheNamespace = "scorers"           // Keyed by competition code and season
+	CacheScorersArchive   CacheNamespace = "scorers_archive"   // Top scorers of completed seasons, keyed like CacheScorers
+	CacheSeasons          CacheNamespace = "seasons"           // Keyed by competition code
+	CacheTodayFixtures    CacheNamespace = "today_fixtures"    // Keyed by UTC date
+	CacheFixturesSummary  CacheNamespace = "fixtures_summary"  // Keyed by competition code
+	CacheBracket          CacheNamespace = "bracket"           // Keyed by competition code
+	CacheUpcomingMatches  CacheNamespace = "upcoming_matches"  // Single entry from the ML service
+	CacheFeed             CacheNamespace = "feed"              // Keyed by user and what they follow
+	CacheProvider         CacheNamespace = "provider"          // Provider responses kept for revalidation, keyed by URL
+)
+
+// CachePolicy is how long entries of a namespace are fresh, and how long after that they are
+// still served while they are refreshed in the background, or in place of data the provider
+// fails to deliver
+type CachePolicy struct {
+	TTL      time.Duration
+	StaleFor time.Duration
+}
+
+// cachePolicies are the policies per namespace. Provider responses are only kept as long as the
+// longest refresh interval of the data built from them, so URLs that aren't requested again, like
+// those of past dates, don't pile up.
+var cachePolicies = map[CacheNamespace]CachePolicy{
+	CacheStandings:        {TTL: 6 * time.Hour, StaleFor: 7 * 24 * time.Hour},
+	CacheStandingsArchive: {TTL: cacheForever},
+	CacheScorers:          {TTL: 6 * time.Hour, StaleFor: 7 * 24 * time.Hour},
+	CacheScorersArchive:   {TTL: cacheForever},
+	CacheSeasons:          {TTL: 24 * time.Hour, StaleFor: 7 * 24 * time.Hour},
+	CacheTodayFixtures:    {TTL: 10 * time.Minute, StaleFor: 24 * time.Hour},
+	CacheFixturesSummary:  {TTL: 30 * time.Minute, StaleFor: 2 * 24 * time.Hour},
+	CacheBracket:          {TTL: 30 * time.Minute, StaleFor: 2 * 24 * time.Hour},
+	CacheUpcomingMatches:  {TTL: 15 * time.Minute, StaleFor: 24 * time.Hour},
+	CacheFeed:             {TTL: 5 * time.Minute},
+	CacheProvider:         {TTL: 6 * time.Hour},
+}
+
+// cacheForever is the TTL of data that never changes, like the tables of completed seasons
+const cacheForever = 100 * 365 * 24 * time.Hour
+
+// cacheFetchTimeout bounds a fetch shared by concurrent callers or running in the background
+const cacheFetchTimeout = 30 * time.Second
+
+// ErrCacheMiss is returned when nothing usable is cached under a key
+var ErrCacheMiss = errors.New("cache miss")
+
+// CacheFetch produces the value to cache and reports whether it is worth caching, e.g. false
+// when it is empty
+type CacheFetch func(ctx context.Context) (interface{}, bool, error)
+
+// CacheEntry is a cached JSON value with its validators
+type CacheEntry struct {
+	Value        []byte
+	ETag         string // Content hash of Value
+	LastModified time.Time
+	StoredAt     time.Time // When the value was fetched
+	FreshUntil   time.Time
+	Stale        bool // Past FreshUntil, or served because a fetch failed
+}
+
+// Age returns how long ago the entry's value was fetched
+func (e *CacheEntry) Age() time.Duration {
+	if e.StoredAt.IsZero() {
+		return 0
+	}
+	return time.Since(e.StoredAt)
+}
+
+// CacheService is the single cache for sports data, feeds and provider responses
+type CacheService interface {
+	// Get returns a fresh or stale entry, or ErrCacheMiss
+	Get(ns CacheNamespace, key string) (*CacheEntry, error)
+	// Fetch returns the cached entry, or fetches, stores and returns a new one. A stale entry is
+	// returned as it is and refreshed in the background. When fetching fails or comes back empty,
+	// the last value stored is returned as stale if there is one.
+	Fetch(ctx context.Context, ns CacheNamespace, key string, fetch CacheFetch) (*CacheEntry, error)
+	// Refresh fetches and stores a new entry whatever is cached
+	Refresh(ctx context.Context, ns CacheNamespace, key string, fetch CacheFetch) (*CacheEntry, error)
+	// Store caches value under the namespace's policy
+	Store(ns CacheNamespace, key string, value interface{}) (*CacheEntry, error)
+	Invalidate(ns CacheNamespace, key string) error
+	Metrics() map[CacheNamespace]models.CacheMetrics
+	// ResponseStore keeps provider responses in the provider namespace
+	ResponseStore() provider.ResponseStore
+}
+
+// cacheService implements the CacheService interface on top of the cache repository
+type cacheService struct {
+	repo      repository.CacheRepository
+	keyPrefix string             // Put in front of every storage key, e.g. to keep demo data apart
+	flight    singleflight.Group // Fetches in progress by storage key
+
+	mutex      sync.Mutex
+	metrics    map[CacheNamespace]*models.CacheMetrics
+	refreshing map[string]bool // Storage keys with a background refresh running
+}
+
+// NewCacheService creates a new cache service instance storing every entry under keyPrefix
+func NewCacheService(repo repository.CacheRepository, keyPrefix string) CacheService {
+	return &cacheService{
+		repo:       repo,
+		keyPrefix:  keyPrefix,
+		metrics:    make(map[CacheNamespace]*models.CacheMetrics),
+		refreshing: make(map[string]bool),
+	}
+}
+
+// Cached returns the value cached under key decoded into T, fetching it on a miss. See
+// CacheService.Fetch for how stale entries are handled.
+func Cached[T any](ctx context.Context, cache CacheService, ns CacheNamespace, key string, fetch func(ctx context.Context) (T, bool, error)) (T, error) {
+	var value T
+	entry, err := cache.Fetch(ctx, ns, key, func(ctx context.Context) (interface{}, bool, error) {
+		return fetch(ctx)
+	})
+	if err != nil {
+		return value, err
+	}
+	if err := json.Unmarshal(entry.Value, &value); err != nil {
+		return value, fmt.Errorf("failed to decode cached %s: %w", ns, err)
+	}
+	return value, nil
+}
+
+// Get returns a fresh or stale entry, or ErrCacheMiss
+func (s *cacheService) Get(ns CacheNamespace, key string) (*CacheEntry, error) {
+	item, err := s.repo.Get(s.cacheKey(ns, key))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.count(ns, func(m *models.CacheMetrics) { m.Misses++ })
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		s.count(ns, func(m *models.CacheMetrics) { m.Errors++ })
+		return nil, err
+	}
+
+	entry := newCacheEntry(ns, item)
+	if entry.Stale {
+		s.count(ns, func(m *models.CacheMetrics) { m.StaleHits++ })
+	} else {
+		s.count(ns, func(m *models.CacheMetrics) { m.Hits++ })
+	}
+	return entry, nil
+}
+
+// Fetch returns the cached entry, or fetches, stores and returns a new one. A stale entry is
+// returned as it is and refreshed in the background. When fetching fails or comes back empty, the
+// last value stored is returned as stale if there is one.
+func (s *cacheService) Fetch(ctx context.Context, ns CacheNamespace, key string, fetch CacheFetch) (*CacheEntry, error) {
+	entry, err := s.Get(ns, key)
+	if err == nil {
+		if entry.Stale {
+			s.refreshInBackground(ctx, ns, key, fetch)
+		}
+		return entry, nil
+	}
+	if !errors.Is(err, ErrCacheMiss) {
+		fmt.Printf("[WARN] Failed to read %s from cache: %v\n", s.cacheKey(ns, key), err)
+	}
+
+	entry, err = s.Refresh(ctx, ns, key, fetch)
+	if err != nil {
+		if lastGood, ok := s.lastKnownGood(ns, key); ok {
+			fmt.Printf("[WARN] Serving last known %s after fetching failed: %v\n", s.cacheKey(ns, key), err)
+			return lastGood, nil
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Refresh fetches and stores a new entry whatever is cached. Concurrent refreshes of a key share
+// one fetch, which isn't cancelled when the caller that started it goes away.
+func (s *cacheService) Refresh(ctx context.Context, ns CacheNamespace, key string, fetch CacheFetch) (*CacheEntry, error) {
+	result, err, _ := s.flight.Do(s.cacheKey(ns, key), func() (interface{}, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheFetchTimeout)
+		defer cancel()
+		return s.fetchAndStore(fetchCtx, ns, key, fetch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*CacheEntry), nil
+}
+
+// fetchAndStore fetches and stores a new entry. Values that aren't worth caching, such as empty
+// tables, are returned without being stored. An empty answer is more likely a provider hiccup than
+// the data going away, so the last value stored is returned as stale instead while it is within
+// its namespace's stale window. Past that window it is out of date, e.g. fixtures since played,
+// and is dropped.
+func (s *cacheService) fetchAndStore(ctx context.Context, ns CacheNamespace, key string, fetch CacheFetch) (*CacheEntry, error) {
+	value, cacheable, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !cacheable {
+		storageKey := s.cacheKey(ns, key)
+		if item, err := s.repo.GetIgnoringExpiry(storageKey); err == nil {
+			if time.Now().Before(item.ExpiresAt) {
+				fmt.Printf("[WARN] Keeping last known %s over an empty response\n", storageKey)
+				s.count(ns, func(m *models.CacheMetrics) { m.StaleHits++ })
+				entry := newCacheEntry(ns, item)
+				entry.Stale = true
+				return entry, nil
+			}
+			if err := s.repo.Delete(storageKey); err != nil {
+				fmt.Printf("[WARN] Failed to drop out of date %s: %v\n", storageKey, err)
+			}
+		}
+		body, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		return &CacheEntry{Value: body, ETag: utils.ContentETag(body), LastModified: now, StoredAt: now, FreshUntil: now}, nil
+	}
+	return s.Store(ns, key, value)
+}
+
+// Store caches value under the namespace's policy, recording when it was stored. When the content
+// is what was cached before, e.g. because the provider answered 304, its Last-Modified stays put.
+func (s *cacheService) Store(ns CacheNamespace, key string, value interface{}) (*CacheEntry, error) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	storageKey := s.cacheKey(ns, key)
+	etag := utils.ContentETag(body)
+	now := time.Now()
+	lastModified := now
+	if previous, err := s.repo.GetIgnoringExpiry(storageKey); err == nil && utils.ContentETag(previous.Value) == etag {
+		lastModified = previous.LastModified
+	}
+
+	policy := cachePolicies[ns]
+	item := models.CacheItem{
+		Key:          storageKey,
+		Value:        body,
+		ETag:         etag,
+		LastModified: lastModified,
+		ExpiresAt:    now.Add(policy.TTL + policy.StaleFor),
+		CreatedAt:    now,
+	}
+	if err := s.repo.SetWithMetadata(storageKey, item); err != nil {
+		s.count(ns, func(m *models.CacheMetrics) { m.Errors++ })
+		fmt.Printf("[ERROR] Failed to cache %s: %v\n", storageKey, err)
+	} else {
+		s.count(ns, func(m *models.CacheMetrics) { m.Writes++ })
+	}
+	return &CacheEntry{Value: body, ETag: etag, LastModified: lastModified, StoredAt: now, FreshUntil: now.Add(policy.TTL)}, nil
+}
+
+// Invalidate removes the entry under key
+func (s *cacheService) Invalidate(ns CacheNamespace, key string) error {
+	return s.repo.Delete(s.cacheKey(ns, key))
+}
+
+// Metrics returns a snapshot of the counters of every namespace used so far
+func (s *cacheService) Metrics() map[CacheNamespace]models.CacheMetrics {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := make(map[CacheNamespace]models.CacheMetrics, len(s.metrics))
+	for ns, m := range s.metrics {
+		snapshot[ns] = *m
+	}
+	return snapshot
+}
+
+// ResponseStore keeps provider responses in the provider namespace
+func (s *cacheService) ResponseStore() provider.ResponseStore {
+	return providerResponseStore{cache: s}
+}
+
+// refreshInBackground refreshes a stale entry unless a refresh of it is already running
+func (s *cacheService) refreshInBackground(ctx context.Context, ns CacheNamespace, key string, fetch CacheFetch) {
+	storageKey := s.cacheKey(ns, key)
+	s.mutex.Lock()
+	if s.refreshing[storageKey] {
+		s.mutex.Unlock()
+		return
+	}
+	s.refreshing[storageKey] = true
+	s.mutex.Unlock()
+
+	go func() {
+		defer func() {
+			s.mutex.Lock()
+			delete(s.refreshing, storageKey)
+			s.mutex.Unlock()
+		}()
+
+		s.count(ns, func(m *models.CacheMetrics) { m.Refreshes++ })
+		if _, err := s.Refresh(ctx, ns, key, fetch); err != nil {
+			s.count(ns, func(m *models.CacheMetrics) { m.Errors++ })
+			fmt.Printf("[WARN] Failed to refresh stale %s, serving it until it expires: %v\n", storageKey, err)
+		}
+	}()
+}
+
+// count updates the counters of a namespace
+func (s *cacheService) count(ns CacheNamespace, update func(m *models.CacheMetrics)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	m, ok := s.metrics[ns]
+	if !ok {
+		m = &models.CacheMetrics{}
+		s.metrics[ns] = m
+	}
+	update(m)
+}
+
+// lastKnownGood returns the last value stored under key as a stale entry, even past its
+// expiry, as long as it hasn't been cleaned up
+func (s *cacheService) lastKnownGood(ns CacheNamespace, key string) (*CacheEntry, bool) {
+	item, err := s.repo.GetIgnoringExpiry(s.cacheKey(ns, key))
+	if err != nil {
+		return nil, false
+	}
+	s.count(ns, func(m *models.CacheMetrics) { m.StaleHits++ })
+	entry := newCacheEntry(ns, item)
+	entry.Stale = true
+	return entry, true
+}
+
+// newCacheEntry converts a stored item into an entry, fresh for the namespace's TTL from when it
+// was stored
+func newCacheEntry(ns CacheNamespace, item *models.CacheItem) *CacheEntry {
+	freshUntil := item.CreatedAt.Add(cachePolicies[ns].TTL)
+	if freshUntil.After(item.ExpiresAt) {
+		freshUntil = item.ExpiresAt
+	}
+	return &CacheEntry{
+		Value:        item.Value,
+		ETag:         utils.ContentETag(item.Value),
+		LastModified: item.LastModified,
+		StoredAt:     item.CreatedAt,
+		FreshUntil:   freshUntil,
+		Stale:        time.Now().After(freshUntil),
+	}
+}
+
+// cacheKey is the key an entry is stored under
+func (s *cacheService) cacheKey(ns CacheNamespace, key string) string {
+	return s.keyPrefix + string(ns) + ":" + key
+}
+
+// providerResponseStore is a provider.ResponseStore over the provider namespace. Stored responses
+// keep the provider's own validators rather than a content hash.
+type providerResponseStore struct {
+	cache *cacheService
+}
+
+// Get returns a stored provider response
+func (p providerResponseStore) Get(key string) (*models.CacheItem, error) {
+	item, err := p.cache.repo.Get(p.cache.cacheKey(CacheProvider, key))
+	if err != nil {
+		p.cache.count(CacheProvider, func(m *models.CacheMetrics) { m.Misses++ })
+		return nil, err
+	}
+	p.cache.count(CacheProvider, func(m *models.CacheMetrics) { m.Hits++ })
+	return item, nil
+}
+
+// Set stores a provider response under the provider namespace's policy
+func (p providerResponseStore) Set(key string, item models.CacheItem) error {
+	item.Key = p.cache.cacheKey(CacheProvider, key)
+	item.ExpiresAt = time.Now().Add(cachePolicies[CacheProvider].TTL)
+	if err := p.cache.repo.SetWithMetadata(item.Key, item); err != nil {
+		p.cache.count(CacheProvider, func(m *models.CacheMetrics) { m.Errors++ })
+		return err
+	}
+	p.cache.count(CacheProvider, func(m *models.CacheMetrics) { m.Writes++ })
+	return nil
+}
+
+// Extend keeps a revalidated provider response for the provider namespace's TTL from now
+func (p providerResponseStore) Extend(key string) error {
+	return p.cache.repo.ExtendExpiry(p.cache.cacheKey(CacheProvider, key), cachePolicies[CacheProvider].TTL)
+}
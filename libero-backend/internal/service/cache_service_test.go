@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"libero-backend/internal/models"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheServiceSharesConcurrentFetches(t *testing.T) {
+	cache := NewCacheService(newFakeCacheRepo(), "")
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) (interface{}, bool, error) {
+		fetches.Add(1)
+		<-release
+		return map[string]string{"competition": "PL"}, true, nil
+	}
+
+	const callers = 10
+	var wg sync.WaitGroup
+	values := make([]string, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			entry, err := cache.Fetch(context.Background(), CacheStandings, "PL", fetch)
+			errs[i] = err
+			if err == nil {
+				values[i] = string(entry.Value)
+			}
+		}(i)
+	}
+	// Give every caller time to miss and join the fetch in progress
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("fetched %d times for %d concurrent misses, want once", n, callers)
+	}
+	for i := range values {
+		if errs[i] != nil || values[i] != `{"competition":"PL"}` {
+			t.Errorf("caller %d got %q, %v", i, values[i], errs[i])
+		}
+	}
+
+	// The value is cached for the callers that come after
+	if _, err := cache.Fetch(context.Background(), CacheStandings, "PL", fetch); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("fetched %d times after the value was cached, want once", n)
+	}
+}
+
+func TestCacheServiceStoredAt(t *testing.T) {
+	repo := newFakeCacheRepo()
+	cache := NewCacheService(repo, "")
+
+	// An entry stored an hour ago into the standings' 6h TTL is fresh and an hour old
+	storedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	repo.items["standings:PL"] = models.CacheItem{Key: "standings:PL", Value: []byte(`{}`), CreatedAt: storedAt, ExpiresAt: time.Now().Add(time.Minute)}
+	entry, err := cache.Get(CacheStandings, "PL")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !entry.StoredAt.Equal(storedAt) || entry.Stale {
+		t.Errorf("entry stored at %v, stale %v; want %v and fresh", entry.StoredAt, entry.Stale, storedAt)
+	}
+
+	// Storing the same content again resets its age but not its Last-Modified
+	lastModified := entry.LastModified
+	if _, err := cache.Store(CacheStandings, "PL", struct{}{}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if entry, _ = cache.Get(CacheStandings, "PL"); !entry.StoredAt.After(storedAt) || !entry.LastModified.Equal(lastModified) {
+		t.Errorf("restored entry stored at %v, last modified %v; want now and %v", entry.StoredAt, entry.LastModified, lastModified)
+	}
+}
+
+func TestCacheServiceServesLastValue(t *testing.T) {
+	repo := newFakeCacheRepo()
+	cache := NewCacheService(repo, "")
+	table := map[string][]string{"table": {"Arsenal", "Chelsea"}}
+	if _, err := cache.Store(CacheStandings, "PL", table); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	stored := string(repo.items["standings:PL"].Value)
+	age := func(storedAgo, expiresIn time.Duration) {
+		item := repo.items["standings:PL"]
+		item.CreatedAt = time.Now().Add(-storedAgo)
+		item.ExpiresAt = time.Now().Add(expiresIn)
+		repo.items["standings:PL"] = item
+	}
+	empty := func(ctx context.Context) (interface{}, bool, error) {
+		return map[string][]string{"table": {}}, false, nil
+	}
+
+	// An empty answer while the table is stale keeps serving the table
+	age(7*time.Hour, time.Hour)
+	entry, err := cache.Refresh(context.Background(), CacheStandings, "PL", empty)
+	if err != nil || string(entry.Value) != stored || !entry.Stale {
+		t.Fatalf("Refresh() with an empty answer = %v, %v; want the stored table as stale", entry, err)
+	}
+	if _, err := repo.GetIgnoringExpiry("standings:PL"); err != nil {
+		t.Fatalf("stored table dropped after an empty answer: %v", err)
+	}
+
+	// So does a failure, even past the stale window
+	age(30*24*time.Hour, -time.Minute)
+	failing := func(ctx context.Context) (interface{}, bool, error) { return nil, false, errors.New("provider down") }
+	entry, err = cache.Fetch(context.Background(), CacheStandings, "PL", failing)
+	if err != nil || string(entry.Value) != stored || !entry.Stale {
+		t.Fatalf("Fetch() after an error = %v, %v; want the stored table as stale", entry, err)
+	}
+
+	// Past the stale window the table is out of date, so an empty answer replaces it
+	entry, err = cache.Fetch(context.Background(), CacheStandings, "PL", empty)
+	if err != nil || string(entry.Value) != `{"table":[]}` {
+		t.Fatalf("Fetch() with an empty answer past the stale window = %v, %v; want the empty answer", entry, err)
+	}
+	if _, err := repo.GetIgnoringExpiry("standings:PL"); err == nil {
+		t.Error("out of date table still cached after an empty answer, want it dropped")
+	}
+}
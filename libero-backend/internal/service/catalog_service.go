@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"libero-backend/internal/models"
+	"libero-backend/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Error definitions for catalog service
+var (
+	ErrCompetitionNotFound = errors.New("competition not found")
+	ErrTeamNotFound        = errors.New("team not found")
+	ErrPlayerNotFound      = errors.New("player not found")
+)
+
+// providerDateLayout is the date format the provider uses for seasons and birth dates
+const providerDateLayout = "2006-01-02"
+
+// CatalogSyncResult summarizes what a competition sync stored
+type CatalogSyncResult struct {
+	CompetitionCode string
+	Teams           int
+	Players         int
+}
+
+// CatalogService defines the interface for the competition, team and player catalog.
+// A userID of 0 means the caller is anonymous, in which case no followed state is returned.
+type CatalogService interface {
+	SyncCompetition(ctx context.Context, competitionCode string) (*CatalogSyncResult, error)
+	SearchCompetitions(filter models.CatalogFilter, page, limit int, userID uint) ([]models.CompetitionSummary, int64, error)
+	GetCompetition(id uint, userID uint) (*models.CompetitionDetail, error)
+	SearchTeams(filter models.CatalogFilter, page, limit int, userID uint) ([]models.TeamSummary, int64, error)
+	GetTeam(id uint, userID uint) (*models.TeamDetail, error)
+	SearchPlayers(filter models.CatalogFilter, page, limit int, userID uint) ([]models.PlayerSummary, int64, error)
+	GetPlayer(id uint, userID uint) (*models.PlayerDetail, error)
+}
+
+// catalogService implements the CatalogService interface
+type catalogService struct {
+	competitionRepo repository.CompetitionRepository
+	teamRepo        repository.TeamRepository
+	playerRepo      repository.PlayerRepository
+	userRepo        repository.UserRepository
+	footballService *FootballService
+}
+
+// followedIDs holds the IDs of everything a user follows
+type followedIDs struct {
+	competitions map[uint]bool
+	teams        map[uint]bool
+	players      map[uint]bool
+}
+
+// NewCatalogService creates a new catalog service instance
+func NewCatalogService(competitionRepo repository.CompetitionRepository, teamRepo repository.TeamRepository, playerRepo repository.PlayerRepository, userRepo repository.UserRepository, footballService *FootballService) CatalogService {
+	return &catalogService{
+		competitionRepo: competitionRepo,
+		teamRepo:        teamRepo,
+		playerRepo:      playerRepo,
+		userRepo:        userRepo,
+		footballService: footballService,
+	}
+}
+
+// SyncCompetition imports a competition, the teams of its current season and their squads
+// from the provider. Everything is upserted by provider ID, so reruns are idempotent.
+func (s *catalogService) SyncCompetition(ctx context.Context, competitionCode string) (*CatalogSyncResult, error) {
+	rawCompetition, err := s.footballService.GetCompetition(ctx, competitionCode)
+	if err != nil {
+		return nil, err
+	}
+	rawTeams, err := s.footballService.GetCompetitionTeams(ctx, competitionCode)
+	if err != nil {
+		return nil, err
+	}
+
+	competition := competitionFromProvider(rawCompetition)
+	if err := s.competitionRepo.Upsert(competition); err != nil {
+		return nil, fmt.Errorf("failed to store competition %s: %w", competition.Code, err)
+	}
+
+	teams := make([]models.Team, 0, len(rawTeams.Teams))
+	for _, t := range rawTeams.Teams {
+		teams = append(teams, teamFromProvider(t))
+	}
+	if err := s.teamRepo.UpsertMany(teams); err != nil {
+		return nil, fmt.Errorf("failed to store teams of %s: %w", competition.Code, err)
+	}
+
+	// Link the teams to the competition and collect their squads, now that team IDs are known
+	teamRefs := make([]*models.Team, 0, len(teams))
+	players := make([]models.Player, 0)
+	seenPlayers := make(map[int]bool)
+	for i := range teams {
+		teamRefs = append(teamRefs, &teams[i])
+		for _, member := range rawTeams.Teams[i].Squad {
+			// A single upsert statement can't touch the same row twice
+			if seenPlayers[member.ID] {
+				continue
+			}
+			seenPlayers[member.ID] = true
+			players = append(players, playerFromProvider(member, teams[i].ID))
+		}
+	}
+	if err := s.competitionRepo.ReplaceTeams(competition, teamRefs); err != nil {
+		return nil, fmt.Errorf("failed to link teams to %s: %w", competition.Code, err)
+	}
+	if err := s.playerRepo.UpsertMany(players); err != nil {
+		return nil, fmt.Errorf("failed to store squads of %s: %w", competition.Code, err)
+	}
+
+	return &CatalogSyncResult{
+		CompetitionCode: competition.Code,
+		Teams:           len(teams),
+		Players:         len(players),
+	}, nil
+}
+
+// SearchCompetitions retrieves competitions matching the filter with pagination
+func (s *catalogService) SearchCompetitions(filter models.CatalogFilter, page, limit int, userID uint) ([]models.CompetitionSummary, int64, error) {
+	competitions, total, err := s.competitionRepo.Search(filter, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	followed, err := s.loadFollowed(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	summaries := make([]models.CompetitionSummary, 0, len(competitions))
+	for i := range competitions {
+		summaries = append(summaries, followed.competition(&competitions[i]))
+	}
+	return summaries, total, nil
+}
+
+// GetCompetition retrieves a competition with its teams
+func (s *catalogService) GetCompetition(id uint, userID uint) (*models.CompetitionDetail, error) {
+	competition, err := s.competitionRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCompetitionNotFound
+		}
+		return nil, err
+	}
+	followed, err := s.loadFollowed(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &models.CompetitionDetail{
+		CompetitionSummary: followed.competition(competition),
+		Teams:              make([]models.TeamSummary, 0, len(competition.Teams)),
+	}
+	for _, team := range competition.Teams {
+		detail.Teams = append(detail.Teams, followed.team(team))
+	}
+	return detail, nil
+}
+
+// SearchTeams retrieves teams matching the filter with pagination
+func (s *catalogService) SearchTeams(filter models.CatalogFilter, page, limit int, userID uint) ([]models.TeamSummary, int64, error) {
+	teams, total, err := s.teamRepo.Search(filter, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	followed, err := s.loadFollowed(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	summaries := make([]models.TeamSummary, 0, len(teams))
+	for i := range teams {
+		summaries = append(summaries, followed.team(&teams[i]))
+	}
+	return summaries, total, nil
+}
+
+// GetTeam retrieves a team with its competitions and squad
+func (s *catalogService) GetTeam(id uint, userID uint) (*models.TeamDetail, error) {
+	team, err := s.teamRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+	squad, err := s.playerRepo.FindByTeamID(team.ID)
+	if err != nil {
+		return nil, err
+	}
+	followed, err := s.loadFollowed(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &models.TeamDetail{
+		TeamSummary:  followed.team(team),
+		Competitions: make([]models.CompetitionSummary, 0, len(team.Competitions)),
+		Squad:        make([]models.PlayerSummary, 0, len(squad)),
+	}
+	for _, competition := range team.Competitions {
+		detail.Competitions = append(detail.Competitions, followed.competition(competition))
+	}
+	for i := range squad {
+		detail.Squad = append(detail.Squad, followed.player(&squad[i]))
+	}
+	return detail, nil
+}
+
+// SearchPlayers retrieves players matching the filter with pagination
+func (s *catalogService) SearchPlayers(filter models.CatalogFilter, page, limit int, userID uint) ([]models.PlayerSummary, int64, error) {
+	players, total, err := s.playerRepo.Search(filter, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	followed, err := s.loadFollowed(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	summaries := make([]models.PlayerSummary, 0, len(players))
+	for i := range players {
+		summaries = append(summaries, followed.player(&players[i]))
+	}
+	return summaries, total, nil
+}
+
+// GetPlayer retrieves a player with their team
+func (s *catalogService) GetPlayer(id uint, userID uint) (*models.PlayerDetail, error) {
+	player, err := s.playerRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPlayerNotFound
+		}
+		return nil, err
+	}
+	followed, err := s.loadFollowed(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &models.PlayerDetail{PlayerSummary: followed.player(player)}
+	if player.TeamID != 0 {
+		team, err := s.teamRepo.FindByID(player.TeamID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if team != nil {
+			summary := followed.team(team)
+			detail.Team = &summary
+		}
+	}
+	return detail, nil
+}
+
+// loadFollowed loads what the user follows. Anonymous callers get nil, which leaves followed state unset.
+func (s *catalogService) loadFollowed(userID uint) (*followedIDs, error) {
+	if userID == 0 {
+		return nil, nil
+	}
+	user, err := s.userRepo.FindByIDWithPreferences(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load followed items: %w", err)
+	}
+
+	followed := &followedIDs{
+		competitions: make(map[uint]bool, len(user.FollowedCompetitions)),
+		teams:        make(map[uint]bool, len(user.FollowedTeams)),
+		players:      make(map[uint]bool, len(user.FollowedPlayers)),
+	}
+	for _, c := range user.FollowedCompetitions {
+		followed.competitions[c.ID] = true
+	}
+	for _, t := range user.FollowedTeams {
+		followed.teams[t.ID] = true
+	}
+	for _, p := range user.FollowedPlayers {
+		followed.players[p.ID] = true
+	}
+	return followed, nil
+}
+
+// competition converts a competition to its summary, with followed state when known
+func (f *followedIDs) competition(c *models.Competition) models.CompetitionSummary {
+	summary := c.ToSummary()
+	if f != nil {
+		isFollowed := f.competitions[c.ID]
+		summary.Followed = &isFollowed
+	}
+	return summary
+}
+
+// team converts a team to its summary, with followed state when known
+func (f *followedIDs) team(t *models.Team) models.TeamSummary {
+	summary := t.ToSummary()
+	if f != nil {
+		isFollowed := f.teams[t.ID]
+		summary.Followed = &isFollowed
+	}
+	return summary
+}
+
+// player converts a player to its summary, with followed state when known
+func (f *followedIDs) player(p *models.Player) models.PlayerSummary {
+	summary := p.ToSummary()
+	if f != nil {
+		isFollowed := f.players[p.ID]
+		summary.Followed = &isFollowed
+	}
+	return summary
+}
+
+// competitionFromProvider maps a provider competition onto our Competition model
+func competitionFromProvider(c *models.CompetitionDetailResponse) *models.Competition {
+	providerID := c.ID
+	competition := &models.Competition{
+		ProviderID:      &providerID,
+		Name:            c.Name,
+		Code:            c.Code,
+		Type:            c.Type,
+		Country:         c.Area.Name,
+		LogoURL:         c.Emblem,
+		Sport:           "football",
+		SeasonStart:     parseProviderDate(c.CurrentSeason.StartDate),
+		SeasonEnd:       parseProviderDate(c.CurrentSeason.EndDate),
+		CurrentMatchday: c.CurrentSeason.CurrentMatchday,
+	}
+	// Seasons are named after the year they start in, as in the provider's season filter
+	if competition.SeasonStart != nil {
+		competition.Season = fmt.Sprintf("%d", competition.SeasonStart.Year())
+	}
+	return competition
+}
+
+// teamFromProvider maps a provider team onto our Team model
+func teamFromProvider(t models.TeamDetailResponse) models.Team {
+	providerID := t.ID
+	return models.Team{
+		ProviderID: &providerID,
+		Name:       t.Name,
+		ShortName:  t.ShortName,
+		TLA:        t.TLA,
+		LogoURL:    t.Crest,
+		Country:    t.Area.Name,
+		Venue:      t.Venue,
+		Founded:    t.Founded,
+		Sport:      "football",
+	}
+}
+
+// playerFromProvider maps a provider squad member onto our Player model
+func playerFromProvider(p models.SquadMemberResponse, teamID uint) models.Player {
+	providerID := p.ID
+	return models.Player{
+		ProviderID:  &providerID,
+		Name:        p.Name,
+		Position:    p.Position,
+		TeamID:      teamID,
+		Country:     p.Nationality,
+		DateOfBirth: parseProviderDate(p.DateOfBirth),
+		ShirtNumber: p.ShirtNumber,
+	}
+}
+
+// parseProviderDate parses a provider date, returning nil when it is missing or malformed
+func parseProviderDate(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(providerDateLayout, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
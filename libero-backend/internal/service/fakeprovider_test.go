@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"libero-backend/internal/fakeprovider"
+	"libero-backend/internal/provider"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newFakeFootballService serves a football service from a fake provider playing scenario, through
+// the provider client and router the backend uses
+func newFakeFootballService(t *testing.T, scenario fakeprovider.Scenario) *FootballService {
+	t.Helper()
+	fake, err := fakeprovider.New(fakeprovider.Options{Scenario: scenario})
+	if err != nil {
+		t.Fatalf("fakeprovider.New() error = %v", err)
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	footballData := provider.NewFootballData(server.URL+"/v4", "test-key", provider.NewTokenBucket(6000, 10), nil)
+	router, err := provider.NewRouter([]provider.FootballDataProvider{footballData}, provider.DefaultRoutes)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+	return NewFootballService(router, &fakeMatchRepo{})
+}
+
+func TestFakeProviderReplay(t *testing.T) {
+	s := newFakeFootballService(t, fakeprovider.Scenarios["replay"])
+
+	standings, err := s.GetStandings(context.Background(), "PL", "TOTAL", 0)
+	if err != nil {
+		t.Fatalf("GetStandings() error = %v", err)
+	}
+	if standings.CompetitionName != "Premier League" || len(standings.Standings) == 0 {
+		t.Errorf("standings = %s with %d rows, want the recorded Premier League table", standings.CompetitionName, len(standings.Standings))
+	}
+	scorers, err := s.GetTopScorers(context.Background(), "PL", 0)
+	if err != nil || len(scorers.Scorers) == 0 {
+		t.Errorf("GetTopScorers() = %v, %v; want the recorded scorers", scorers, err)
+	}
+}
+
+func TestFakeProviderMissingCompetition(t *testing.T) {
+	scenario, err := fakeprovider.LoadScenario("missing-competition")
+	if err != nil {
+		t.Fatalf("LoadScenario() error = %v", err)
+	}
+	s := newFakeFootballService(t, scenario)
+
+	// A competition outside the plan is empty rather than an error, so it isn't retried or cached
+	standings, err := s.GetStandings(context.Background(), "PL", "TOTAL", 0)
+	if err != nil {
+		t.Fatalf("GetStandings() error = %v", err)
+	}
+	if len(standings.Standings) != 0 {
+		t.Errorf("standings have %d rows, want none", len(standings.Standings))
+	}
+	if _, err := s.GetCompetitionMatches(context.Background(), "PL"); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("GetCompetitionMatches() error = %v, want provider.ErrNotFound", err)
+	}
+}
+
+func TestFakeProviderRateLimited(t *testing.T) {
+	// Every request that succeeds is followed by length 429s asking to retry after a second
+	burst := func(length int) fakeprovider.Scenario {
+		return fakeprovider.Scenario{
+			Name:            "rate-limited",
+			RateLimitBursts: &fakeprovider.RateLimitBurst{Every: 1, Length: length, RetryAfter: 1},
+		}
+	}
+
+	t.Run("retried", func(t *testing.T) {
+		s := newFakeFootballService(t, burst(1))
+		if _, err := s.GetStandings(context.Background(), "PL", "TOTAL", 0); err != nil {
+			t.Fatalf("first GetStandings() error = %v", err)
+		}
+		// The client waits out the 429 and retries once
+		start := time.Now()
+		standings, err := s.GetStandings(context.Background(), "PL", "TOTAL", 0)
+		if err != nil || len(standings.Standings) == 0 {
+			t.Fatalf("rate limited GetStandings() = %v, %v; want the table after a retry", standings, err)
+		}
+		if waited := time.Since(start); waited < 900*time.Millisecond {
+			t.Errorf("rate limited GetStandings() took %v, want the Retry-After waited out", waited)
+		}
+	})
+
+	t.Run("cache serves the last table", func(t *testing.T) {
+		s := newFakeFootballService(t, burst(2))
+		repo := newFakeCacheRepo()
+		cache := NewCacheService(repo, "")
+		fetch := func(ctx context.Context) (interface{}, bool, error) {
+			standings, err := s.GetStandings(ctx, "PL", "TOTAL", 0)
+			if err != nil {
+				return nil, false, err
+			}
+			return standings, len(standings.Standings) > 0, nil
+		}
+		if _, err := cache.Fetch(context.Background(), CacheStandings, "PL", fetch); err != nil {
+			t.Fatalf("first Fetch() error = %v", err)
+		}
+		item := repo.items["standings:PL"]
+		item.ExpiresAt = time.Now().Add(-time.Minute)
+		repo.items["standings:PL"] = item
+
+		// The retry is rate limited too, so the last table is served as stale
+		entry, err := cache.Fetch(context.Background(), CacheStandings, "PL", fetch)
+		if err != nil || !entry.Stale || string(entry.Value) != string(item.Value) {
+			t.Fatalf("rate limited Fetch() = %v, %v; want the last table as stale", entry, err)
+		}
+	})
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"libero-backend/internal/models"
+	"libero-backend/internal/provider"
+	"libero-backend/internal/repository"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// intPtr returns a pointer to v
+func intPtr(v int) *int {
+	return &v
+}
+
+// stubProvider serves canned matches and standings. Calls it doesn't stub panic on the embedded
+// nil interface.
+type stubProvider struct {
+	provider.FootballDataProvider
+	matches      []models.MatchResponse
+	standings    *models.StandingsResponse
+	standingsErr error
+	calls        map[string]int
+}
+
+func (p *stubProvider) Name() string {
+	return "stub"
+}
+
+func (p *stubProvider) count(call string) {
+	if p.calls == nil {
+		p.calls = make(map[string]int)
+	}
+	p.calls[call]++
+}
+
+func (p *stubProvider) Matches(ctx context.Context, query provider.MatchQuery) (*models.MatchesResponse, error) {
+	p.count("Matches")
+	wanted := make(map[int]bool, len(query.IDs))
+	for _, id := range query.IDs {
+		wanted[id] = true
+	}
+	matches := make([]models.MatchResponse, 0)
+	for _, m := range p.matches {
+		if len(wanted) == 0 || wanted[m.ID] {
+			matches = append(matches, m)
+		}
+	}
+	return &models.MatchesResponse{Matches: matches}, nil
+}
+
+func (p *stubProvider) CompetitionMatches(ctx context.Context, code string) (*models.MatchesResponse, error) {
+	p.count("CompetitionMatches")
+	return &models.MatchesResponse{Matches: p.matches}, nil
+}
+
+func (p *stubProvider) Standings(ctx context.Context, code string, season int) (*models.StandingsResponse, error) {
+	p.count("Standings")
+	if p.standingsErr != nil {
+		return nil, p.standingsErr
+	}
+	if p.standings == nil {
+		return nil, provider.ErrNotFound
+	}
+	return p.standings, nil
+}
+
+// fakePredictionRepo keeps predictions in memory for the settlement operations
+type fakePredictionRepo struct {
+	repository.PredictionHistoryRepository
+	unsettled []models.PredictionHistory
+	settled   map[uint]models.PredictionHistory
+	voided    map[uint]time.Time
+	checked   map[uint]time.Time
+}
+
+func (r *fakePredictionRepo) FindUnsettled(kickoffBefore time.Time, limit int) ([]models.PredictionHistory, error) {
+	return r.unsettled, nil
+}
+
+func (r *fakePredictionRepo) Settle(p *models.PredictionHistory) error {
+	r.settled[p.ID] = *p
+	return nil
+}
+
+func (r *fakePredictionRepo) Void(id uint, voidedAt time.Time) error {
+	r.voided[id] = voidedAt
+	return nil
+}
+
+func (r *fakePredictionRepo) MarkChecked(ids []uint, checkedAt time.Time) error {
+	for _, id := range ids {
+		r.checked[id] = checkedAt
+	}
+	return nil
+}
+
+// fakePickRepo keeps picks in memory for the settlement operations
+type fakePickRepo struct {
+	repository.UserPickRepository
+	unsettled []models.UserPick
+	settled   map[uint]models.UserPick
+	voided    map[uint]time.Time
+	checked   map[uint]time.Time
+}
+
+func (r *fakePickRepo) FindUnsettled(kickoffBefore time.Time, limit int) ([]models.UserPick, error) {
+	return r.unsettled, nil
+}
+
+func (r *fakePickRepo) Settle(p *models.UserPick) error {
+	r.settled[p.ID] = *p
+	return nil
+}
+
+func (r *fakePickRepo) Void(id uint, voidedAt time.Time) error {
+	r.voided[id] = voidedAt
+	return nil
+}
+
+func (r *fakePickRepo) MarkChecked(ids []uint, checkedAt time.Time) error {
+	for _, id := range ids {
+		r.checked[id] = checkedAt
+	}
+	return nil
+}
+
+// fakeMatchRepo keeps stored matches in memory, in kickoff order like the real one
+type fakeMatchRepo struct {
+	repository.MatchRepository
+	matches []models.Match
+}
+
+func (r *fakeMatchRepo) UpsertMany(matches []models.Match) error {
+	for _, m := range matches {
+		replaced := false
+		for i := range r.matches {
+			if r.matches[i].ProviderID == m.ProviderID {
+				r.matches[i], replaced = m, true
+			}
+		}
+		if !replaced {
+			r.matches = append(r.matches, m)
+		}
+	}
+	sort.SliceStable(r.matches, func(i, j int) bool {
+		return r.matches[i].KickoffAt.Before(r.matches[j].KickoffAt)
+	})
+	return nil
+}
+
+func (r *fakeMatchRepo) FindAll(filter models.MatchFilter) ([]models.Match, error) {
+	matches := make([]models.Match, 0)
+	for _, m := range r.matches {
+		if filter.Competition != "" && m.CompetitionCode != filter.Competition {
+			continue
+		}
+		if len(filter.Status) > 0 && !slices.Contains(filter.Status, m.Status) {
+			continue
+		}
+		if (filter.From != nil && m.KickoffAt.Before(*filter.From)) || (filter.To != nil && !m.KickoffAt.Before(*filter.To)) {
+			continue
+		}
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+// fakeCacheRepo keeps cache items in a map
+type fakeCacheRepo struct {
+	repository.CacheRepository
+	mutex sync.Mutex
+	items map[string]models.CacheItem
+}
+
+func newFakeCacheRepo() *fakeCacheRepo {
+	return &fakeCacheRepo{items: make(map[string]models.CacheItem)}
+}
+
+func (r *fakeCacheRepo) Get(key string) (*models.CacheItem, error) {
+	item, err := r.GetIgnoringExpiry(key)
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(item.ExpiresAt) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return item, nil
+}
+
+func (r *fakeCacheRepo) GetIgnoringExpiry(key string) (*models.CacheItem, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	item, ok := r.items[key]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &item, nil
+}
+
+func (r *fakeCacheRepo) SetWithMetadata(key string, item models.CacheItem) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	item.Key = key
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now()
+	}
+	r.items[key] = item
+	return nil
+}
+
+func (r *fakeCacheRepo) ExtendExpiry(key string, ttl time.Duration) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if item, ok := r.items[key]; ok {
+		item.ExpiresAt = time.Now().Add(ttl)
+		r.items[key] = item
+	}
+	return nil
+}
+
+func (r *fakeCacheRepo) Delete(key string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.items, key)
+	return nil
+}
+
+// fakeLeagueRepo holds one league without members. AddMember and ArchiveSeason fail with
+// addMember and archive, e.g. to act like a concurrent request that got there first.
+type fakeLeagueRepo struct {
+	repository.LeagueRepository
+	league    models.League
+	removed   bool  // Whether every user was removed from the league
+	addMember error // Returned by AddMember
+	archive   error // Returned by ArchiveSeason
+}
+
+func (r *fakeLeagueRepo) FindByInviteCode(code string) (*models.League, error) {
+	if code != r.league.InviteCode {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &r.league, nil
+}
+
+func (r *fakeLeagueRepo) FindByID(id uint) (*models.League, error) {
+	return &r.league, nil
+}
+
+func (r *fakeLeagueRepo) FindMember(leagueID, userID uint) (*models.LeagueMember, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeLeagueRepo) AddMember(member *models.LeagueMember) error {
+	return r.addMember
+}
+
+func (r *fakeLeagueRepo) IsRemoved(leagueID, userID uint) (bool, error) {
+	return r.removed, nil
+}
+
+func (r *fakeLeagueRepo) GetStandings(leagueID uint, from time.Time, to *time.Time) ([]models.LeaderboardEntry, error) {
+	return []models.LeaderboardEntry{{Rank: 1, UserID: 7, Points: 3}}, nil
+}
+
+func (r *fakeLeagueRepo) ArchiveSeason(league *models.League, standings []models.LeagueSeasonStanding, endedAt time.Time) error {
+	return r.archive
+}
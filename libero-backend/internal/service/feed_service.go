@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"libero-backend/internal/models"
+	"libero-backend/internal/repository"
+	"sort"
+	"time"
+)
+
+const (
+	// feedFixturesLimit caps the number of fixtures in a feed
+	feedFixturesLimit = 20
+	// feedFixturesWindow is how far ahead the feed looks for fixtures
+	feedFixturesWindow = 14 * 24 * time.Hour
+	// feedLiveLookback keeps matches that kicked off recently in the feed while they are live
+	feedLiveLookback = 3 * time.Hour
+	// feedStandingsTop is the number of leading rows shown in a standings snippet
+	feedStandingsTop = 3
+	// feedRecentPredictions is the number of settled predictions shown in a feed
+	feedRecentPredictions = 5
+)
+
+// feedFixtureStatuses are the statuses of matches that are upcoming or live
+var feedFixtureStatuses = []string{"SCHEDULED", "TIMED", "IN_PLAY", "PAUSED"}
+
+// FeedService defines the interface for the personalized user feed
+type FeedService interface {
+	GetFeed(ctx context.Context, userID uint) (*models.UserFeed, error)
+}
+
+// feedService implements the FeedService interface
+type feedService struct {
+	userRepo        repository.UserRepository
+	teamRepo        repository.TeamRepository
+	matchRepo       repository.MatchRepository
+	predictionRepo  repository.PredictionHistoryRepository
+	cache           CacheService
+	footballService *FootballService
+}
+
+// NewFeedService creates a new feed service instance
+func NewFeedService(userRepo repository.UserRepository, teamRepo repository.TeamRepository, matchRepo repository.MatchRepository, predictionRepo repository.PredictionHistoryRepository, cache CacheService, footballService *FootballService) FeedService {
+	return &feedService{
+		userRepo:        userRepo,
+		teamRepo:        teamRepo,
+		matchRepo:       matchRepo,
+		predictionRepo:  predictionRepo,
+		cache:           cache,
+		footballService: footballService,
+	}
+}
+
+// GetFeed builds the user's feed from followed teams, competitions and players.
+// Feeds are cached per user; the cache key includes what the user follows, so
+// changing preferences produces a fresh feed right away.
+func (s *feedService) GetFeed(ctx context.Context, userID uint) (*models.UserFeed, error) {
+	user, err := s.userRepo.FindByIDWithPreferences(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user preferences: %w", err)
+	}
+
+	cacheKey := fmt.Sprintf("user_%d_%s", userID, preferencesFingerprint(user))
+	feed, err := Cached(ctx, s.cache, CacheFeed, cacheKey, func(ctx context.Context) (models.UserFeed, bool, error) {
+		fixtures, err := s.followedFixtures(user)
+		if err != nil {
+			return models.UserFeed{}, false, err
+		}
+		recent, err := s.recentPredictions(userID)
+		if err != nil {
+			return models.UserFeed{}, false, err
+		}
+
+		return models.UserFeed{
+			GeneratedAt:       time.Now().UTC(),
+			Fixtures:          fixtures,
+			Standings:         s.standingsSnippets(ctx, user),
+			Scorers:           s.scorerPositions(ctx, user),
+			RecentPredictions: recent,
+		}, true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// followedFixtures returns upcoming and live stored matches involving the user's followed teams
+func (s *feedService) followedFixtures(user *models.User) ([]models.Match, error) {
+	teamIDs := followedTeamProviderIDs(user)
+	if len(teamIDs) == 0 {
+		return []models.Match{}, nil
+	}
+
+	now := time.Now().UTC()
+	from := now.Add(-feedLiveLookback)
+	to := now.Add(feedFixturesWindow)
+	filter := models.MatchFilter{
+		TeamIDs: teamIDs,
+		Status:  feedFixtureStatuses,
+		From:    &from,
+		To:      &to,
+	}
+	matches, _, err := s.matchRepo.Find(filter, 1, feedFixturesLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fixtures: %w", err)
+	}
+	if matches == nil {
+		matches = []models.Match{}
+	}
+	return matches, nil
+}
+
+// standingsSnippets returns the top of each followed competition's table plus the rows of
+// followed teams. Competitions whose standings can't be loaded are left out.
+func (s *feedService) standingsSnippets(ctx context.Context, user *models.User) []models.FeedStandingsSnippet {
+	followedIDs := make(map[int]bool)
+	followedNames := make(map[string]bool)
+	for _, team := range user.FollowedTeams {
+		if team.ProviderID != nil {
+			followedIDs[*team.ProviderID] = true
+		}
+		followedNames[team.Name] = true
+	}
+
+	snippets := make([]models.FeedStandingsSnippet, 0, len(user.FollowedCompetitions))
+	for _, competition := range user.FollowedCompetitions {
+		if competition.Code == "" {
+			continue
+		}
+		standings, err := s.standings(ctx, competition.Code)
+		if err != nil {
+			fmt.Printf("[ERROR] Failed to load standings for %s: %v\n", competition.Code, err)
+			continue
+		}
+		if len(standings.Standings) == 0 {
+			continue
+		}
+
+		rows := make([]models.StandingsTableDTO, 0, feedStandingsTop)
+		for i, row := range standings.Standings {
+			if i < feedStandingsTop || followedIDs[row.TeamID] || followedNames[row.TeamName] {
+				rows = append(rows, row)
+			}
+		}
+		snippets = append(snippets, models.FeedStandingsSnippet{
+			CompetitionID:   competition.ID,
+			CompetitionCode: competition.Code,
+			CompetitionName: competition.Name,
+			Rows:            rows,
+		})
+	}
+	return snippets
+}
+
+// scorerPositions returns the scorer-table positions of followed players in the competitions
+// their teams play in. Players who aren't on a scorer table are left out.
+func (s *feedService) scorerPositions(ctx context.Context, user *models.User) []models.FeedScorerPosition {
+	positions := make([]models.FeedScorerPosition, 0)
+	scorersByCode := make(map[string]*models.CompetitionScorersDTO)
+
+	for _, player := range user.FollowedPlayers {
+		if player.TeamID == 0 {
+			continue
+		}
+		team, err := s.teamRepo.FindByID(player.TeamID)
+		if err != nil {
+			continue
+		}
+
+		for _, competition := range team.Competitions {
+			scorers, ok := scorersByCode[competition.Code]
+			if !ok {
+				scorers, err = s.scorers(ctx, competition.Code)
+				if err != nil {
+					fmt.Printf("[ERROR] Failed to load scorers for %s: %v\n", competition.Code, err)
+				}
+				scorersByCode[competition.Code] = scorers
+			}
+			if scorers == nil {
+				continue
+			}
+
+			for i, scorer := range scorers.Scorers {
+				matchesID := player.ProviderID != nil && scorer.PlayerID == *player.ProviderID
+				if !matchesID && (scorer.PlayerID != 0 || scorer.PlayerName != player.Name) {
+					continue
+				}
+				positions = append(positions, models.FeedScorerPosition{
+					PlayerID:        player.ID,
+					PlayerName:      player.Name,
+					TeamName:        scorer.TeamName,
+					CompetitionCode: competition.Code,
+					Position:        i + 1,
+					Goals:           scorer.Goals,
+					Assists:         scorer.Assists,
+				})
+				break
+			}
+		}
+	}
+	return positions
+}
+
+// recentPredictions returns the user's latest settled predictions
+func (s *feedService) recentPredictions(userID uint) ([]models.PredictionHistoryResponse, error) {
+	predictions, _, err := s.predictionRepo.FindByUserID(userID, models.PredictionStatusSettled, 1, feedRecentPredictions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recent predictions: %w", err)
+	}
+	responses := make([]models.PredictionHistoryResponse, 0, len(predictions))
+	for _, prediction := range predictions {
+		responses = append(responses, prediction.ToResponse())
+	}
+	return responses, nil
+}
+
+// standings returns a competition's standings, sharing the cache of the standings endpoint
+func (s *feedService) standings(ctx context.Context, competitionCode string) (*models.CompetitionStandingsDTO, error) {
+	return Cached(ctx, s.cache, CacheStandings, StandingsCacheKey(competitionCode, models.StandingsTypeTotal, 0), func(ctx context.Context) (*models.CompetitionStandingsDTO, bool, error) {
+		standings, err := s.footballService.GetStandings(ctx, competitionCode, models.StandingsTypeTotal, 0)
+		if err != nil {
+			return nil, false, err
+		}
+		return standings, len(standings.Standings) > 0, nil
+	})
+}
+
+// scorers returns a competition's top scorers, sharing the cache of the top scorers endpoint
+func (s *feedService) scorers(ctx context.Context, competitionCode string) (*models.CompetitionScorersDTO, error) {
+	return Cached(ctx, s.cache, CacheScorers, ScorersCacheKey(competitionCode, 0), func(ctx context.Context) (*models.CompetitionScorersDTO, bool, error) {
+		scorers, err := s.footballService.GetTopScorers(ctx, competitionCode, 0)
+		if err != nil {
+			return nil, false, err
+		}
+		return scorers, len(scorers.Scorers) > 0, nil
+	})
+}
+
+// followedTeamProviderIDs returns the provider IDs of the user's followed teams
+func followedTeamProviderIDs(user *models.User) []int {
+	ids := make([]int, 0, len(user.FollowedTeams))
+	for _, team := range user.FollowedTeams {
+		if team.ProviderID != nil {
+			ids = append(ids, *team.ProviderID)
+		}
+	}
+	return ids
+}
+
+// preferencesFingerprint is a short hash of everything the user follows
+func preferencesFingerprint(user *models.User) string {
+	ids := make([]string, 0, len(user.FollowedTeams)+len(user.FollowedPlayers)+len(user.FollowedCompetitions))
+	for _, team := range user.FollowedTeams {
+		ids = append(ids, fmt.Sprintf("t%d", team.ID))
+	}
+	for _, player := range user.FollowedPlayers {
+		ids = append(ids, fmt.Sprintf("p%d", player.ID))
+	}
+	for _, competition := range user.FollowedCompetitions {
+		ids = append(ids, fmt.Sprintf("c%d", competition.ID))
+	}
+	sort.Strings(ids)
+
+	hash := sha256.New()
+	for _, id := range ids {
+		hash.Write([]byte(id + ","))
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
@@ -1,216 +1,75 @@
 package service
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
 
+	"libero-backend/internal/demodata"
 	"libero-backend/internal/models"
+	"libero-backend/internal/provider"
 	"libero-backend/internal/repository"
-	"net/http"
 	"strings"
-	"sync"
 	"time"
 )
 
 // FixturesService defines the interface for fixture-related operations.
 type FixturesService interface {
-	GetTodaysFixtures() ([]models.CompetitionFixturesDTO, error)
-	GetFixturesSummary(competitionCode string) (models.FixturesSummaryDTO, error)
+	GetTodaysFixtures(ctx context.Context) ([]models.CompetitionFixturesDTO, error)
+	GetFixturesSummary(ctx context.Context, competitionCode string) (models.FixturesSummaryDTO, error)
+	GetMatch(ctx context.Context, matchID int) (*models.FixtureMatchDTO, error)
 }
 
-// fixturesService implements the FixturesService interface.
-type fixturesService struct {
-	apiKey      string
-	baseURL     string
-	cacheRepo   repository.CacheRepository
-	rateLimiter *rateLimiter
-}
-
-// rateLimiter provides basic rate limiting functionality
-type rateLimiter struct {
-	mutex           sync.Mutex
-	lastRequestTime time.Time
-	minInterval     time.Duration // Minimum time between API requests
-}
-
-// newRateLimiter creates a new rate limiter with specified interval
-func newRateLimiter(interval time.Duration) *rateLimiter {
-	return &rateLimiter{
-		minInterval:     interval,
-		lastRequestTime: time.Now().Add(-interval), // Allow immediate first request
-	}
-}
+// tbdTeamName stands in for a team that isn't known yet, e.g. the winner of an earlier round
+const tbdTeamName = "TBD"
 
-// Wait blocks until rate limit allows a new request
-func (r *rateLimiter) Wait() {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
-	now := time.Now()
-	// If we've made a request recently, wait until we can make another
-	if !r.lastRequestTime.IsZero() {
-		elapsed := now.Sub(r.lastRequestTime)
-		if elapsed < r.minInterval {
-			waitTime := r.minInterval - elapsed
-			time.Sleep(waitTime)
-			now = time.Now() // Update now after sleeping
-		}
-	}
+// ErrMatchNotFound is returned when the provider does not know the requested match
+var ErrMatchNotFound = errors.New("match not found")
 
-	r.lastRequestTime = now
+// fixturesService implements the FixturesService interface.
+type fixturesService struct {
+	dataProvider provider.FootballDataProvider
+	matchRepo    repository.MatchRepository
+	demo         bool // Data comes from the demo provider and is flagged as such
 }
 
-// NewFixturesService creates a new instance of fixturesService with API configuration.
-func NewFixturesService(apiKey, baseURL string, cacheRepo repository.CacheRepository) FixturesService {
-	// Create rate limiter with 1 request per 1.1 seconds (to be safe with API's limit)
-	limiter := newRateLimiter(1100 * time.Millisecond)
-
+// NewFixturesService creates a new instance of fixturesService using the shared data provider.
+func NewFixturesService(dataProvider provider.FootballDataProvider, matchRepo repository.MatchRepository) FixturesService {
 	return &fixturesService{
-		apiKey:      apiKey,
-		baseURL:     baseURL,
-		cacheRepo:   cacheRepo,
-		rateLimiter: limiter,
+		dataProvider: dataProvider,
+		matchRepo:    matchRepo,
+		demo:         dataProvider.Name() == demodata.ProviderName,
 	}
 }
 
-// GetTodaysFixtures fetches and filters fixtures for the current date and specified leagues,
-// using cache when available.
-func (s *fixturesService) GetTodaysFixtures() ([]models.CompetitionFixturesDTO, error) {
-	// Try to get data from cache first
-	cachedData, err := s.cacheRepo.GetCachedTodayFixtures()
-	if err == nil && cachedData != nil {
-		// Cache hit - convert JSONB to our DTO
-		var fixtures []models.CompetitionFixturesDTO
-		dataBytes, err := json.Marshal(cachedData.Data)
-		if err == nil {
-			if err := json.Unmarshal(dataBytes, &fixtures); err == nil {
-				return fixtures, nil
-			}
-		}
-	}
-
-	// Cache miss or error - fetch from API
+// GetTodaysFixtures fetches and filters fixtures for the current date and specified leagues.
+// Callers cache the result through the cache service.
+func (s *fixturesService) GetTodaysFixtures(ctx context.Context) ([]models.CompetitionFixturesDTO, error) {
 	today := time.Now().UTC().Format("2006-01-02")
 	// Filter by relevant competition codes (PL, PD, SA, BL1, FL1, CL, EL)
 	comps := []string{"PL", "PD", "SA", "BL1", "FL1", "CL", "EL"}
-	compParam := strings.Join(comps, ",")
-
-	// Wait for rate limiter before making request
-	s.rateLimiter.Wait()
-	// Build endpoint URL using date parameter
-	url := fmt.Sprintf("%s/matches?dateFrom=%s&dateTo=%s&competitions=%s",
-		s.baseURL,
-		today,
-		today,
-		compParam,
-	)
-
-	// Debug URL (remove in production)
-	fmt.Printf("Fetching matches from: %s\n", url)
-
-	// Create HTTP request
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
 
-	// Set required headers
-	req.Header.Set("X-Auth-Token", s.apiKey)
-	req.Header.Set("Accept", "application/json")
-
-	// Perform request with proper timeout
-	client := http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	query := provider.MatchQuery{DateFrom: today, DateTo: today, Competitions: comps}
+	raw, err := s.dataProvider.Matches(ctx, query)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusTooManyRequests {
-		// Rate limited - try to get from cache even if it's expired
-		cachedData, err = s.cacheRepo.GetCachedTodayFixturesIgnoringExpiry()
-		if err == nil && cachedData != nil {
-			var fixtures []models.CompetitionFixturesDTO
-			dataBytes, err := json.Marshal(cachedData.Data)
-			if err == nil {
-				if err := json.Unmarshal(dataBytes, &fixtures); err == nil {
-					return fixtures, nil
-				}
-			}
-		}
-
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("rate limited: %s", string(bodyBytes))
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
-	}
 
-	// Decode provider response (assumes JSON of shape {matches: [...]})
-	var raw struct {
-		Matches []json.RawMessage `json:"matches"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
-		return nil, err
-	}
 	// Use a map from competition code to DTOs
 	grouped := make(map[string][]models.FixtureMatchDTO)
 	// Track competition metadata: code and emblem
 	compMeta := make(map[string]struct{ Name, Code, Emblem string })
-	for _, rawMatch := range raw.Matches {
-		// Unmarshal into a generic map to extract required fields
-		var m map[string]interface{}
-		if err := json.Unmarshal(rawMatch, &m); err != nil {
-			continue
-		}
-		// Extract competition
-		comp, _ := m["competition"].(map[string]interface{})
-		compName, _ := comp["name"].(string)
-		compCode, _ := comp["code"].(string)
-		emblem, _ := comp["emblem"].(string)
-		// Extract teams
-		home, _ := m["homeTeam"].(map[string]interface{})
-		away, _ := m["awayTeam"].(map[string]interface{})
+	records := make([]models.Match, 0, len(raw.Matches))
+	for _, m := range raw.Matches {
+		compCode := m.Competition.Code
 		// Record competition metadata for later
 		if _, ok := compMeta[compCode]; !ok {
-			compMeta[compCode] = struct{ Name, Code, Emblem string }{Name: compName, Code: compCode, Emblem: emblem}
-		}
-		// Extract score
-		scoreObj, _ := m["score"].(map[string]interface{})
-		ft, _ := scoreObj["fullTime"].(map[string]interface{})
-		var homeScore *int
-		var awayScore *int
-		if v, ok := ft["homeTeam"].(float64); ok {
-			iv := int(v)
-			homeScore = &iv
+			compMeta[compCode] = struct{ Name, Code, Emblem string }{Name: m.Competition.Name, Code: compCode, Emblem: m.Competition.Emblem}
 		}
-		if v, ok := ft["awayTeam"].(float64); ok {
-			iv := int(v)
-			awayScore = &iv
-		}
-		// Extract date and status
-		dateStr, _ := m["utcDate"].(string)
-		matchTime, _ := time.Parse(time.RFC3339, dateStr)
-		status, _ := m["status"].(string)
-		// Build DTO with additional fields
-		venue, _ := m["venue"].(string)
-		homeCrest, _ := home["crest"].(string)
-		awayCrest, _ := away["crest"].(string)
-		matchDTO := models.FixtureMatchDTO{
-			MatchDate:    matchTime, // UTC timestamp
-			HomeTeamName: home["name"].(string),
-			AwayTeamName: away["name"].(string),
-			HomeScore:    homeScore,
-			AwayScore:    awayScore,
-			MatchStatus:  status,
-			Venue:        venue,
-			HomeLogoURL:  homeCrest,
-			AwayLogoURL:  awayCrest,
-		}
-		grouped[compCode] = append(grouped[compCode], matchDTO)
+		grouped[compCode] = append(grouped[compCode], fixtureFromMatch(m))
+		records = append(records, matchRecordFromResponse(m))
 	}
+	s.storeMatches(records)
 	// Build final DTO array
 	result := make([]models.CompetitionFixturesDTO, 0, len(grouped))
 	for code, matches := range grouped {
@@ -233,58 +92,17 @@ func (s *fixturesService) GetTodaysFixtures() ([]models.CompetitionFixturesDTO,
 		}
 	}
 
-	// Store in cache for future use (with 24 hour TTL)
-	if len(result) > 0 {
-		resultJSON, err := json.Marshal(result)
-		if err == nil {
-			var jsonData models.JSONB
-			err = json.Unmarshal(resultJSON, &jsonData)
-			if err == nil {
-				s.cacheRepo.StoreCachedTodayFixtures(jsonData, 24*time.Hour)
-			}
-		}
-	}
-
 	return result, nil
 }
 
-// Implement the fixtures summary: today, tomorrow, upcoming
-func (s *fixturesService) GetFixturesSummary(competitionCode string) (models.FixturesSummaryDTO, error) {
-	compCode := mapCompetitionCode(strings.ToUpper(competitionCode))
-
-	// Try to get data from cache first
-	cachedData, err := s.cacheRepo.GetCachedFixtures(compCode, "fixtures_summary")
-	if err == nil && cachedData != nil {
-		// Cache hit - convert JSONB to our DTO
-		var summary models.FixturesSummaryDTO
-		dataBytes, err := json.Marshal(cachedData.Data)
-		if err == nil {
-			if err := json.Unmarshal(dataBytes, &summary); err == nil {
-				return summary, nil
-			}
-		}
-	}
-
-	// Cache miss or error - fetch from API
-	base := strings.TrimRight(s.baseURL, "/")
-
-	// Wait for rate limiter before making first request
-	s.rateLimiter.Wait()
+// GetFixturesSummary fetches a competition's fixtures for today, tomorrow and the days after.
+// Callers cache the result through the cache service.
+func (s *fixturesService) GetFixturesSummary(ctx context.Context, competitionCode string) (models.FixturesSummaryDTO, error) {
+	compCode := strings.ToUpper(competitionCode)
 
 	// 1. Fetch competition metadata via /competitions/{code}
-	compURL := fmt.Sprintf("%s/competitions/%s", base, compCode)
-	reqComp, err := http.NewRequest(http.MethodGet, compURL, nil)
-	if err != nil {
-		return models.FixturesSummaryDTO{}, err
-	}
-	reqComp.Header.Set("X-Auth-Token", s.apiKey)
-	respComp, err := http.DefaultClient.Do(reqComp)
-	if err != nil {
-		return models.FixturesSummaryDTO{}, err
-	}
-	defer respComp.Body.Close()
-
-	if respComp.StatusCode == http.StatusNotFound {
+	compRaw, err := s.dataProvider.Competition(ctx, compCode)
+	if errors.Is(err, provider.ErrNotFound) {
 		// Return empty DTO for unsupported competitions
 		return models.FixturesSummaryDTO{
 			CompetitionName: "",
@@ -295,93 +113,30 @@ func (s *fixturesService) GetFixturesSummary(competitionCode string) (models.Fix
 			Upcoming:        []models.FixtureMatchDTO{},
 		}, nil
 	}
-	if respComp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(respComp.Body)
-		return models.FixturesSummaryDTO{}, fmt.Errorf("competition fetch failed: status %d, body: %s", respComp.StatusCode, string(bodyBytes))
-	}
-
-	var compRaw struct {
-		Name   string `json:"name"`
-		Emblem string `json:"emblem"`
-	}
-	if err := json.NewDecoder(respComp.Body).Decode(&compRaw); err != nil {
-		return models.FixturesSummaryDTO{}, err
+	if err != nil {
+		return models.FixturesSummaryDTO{}, fmt.Errorf("competition fetch failed: %w", err)
 	}
 
 	// Helper to fetch match list by date or dateFrom
 	fetch := func(param string, useDateFrom bool) ([]models.FixtureMatchDTO, error) {
-		// Wait for rate limit before each request
-		s.rateLimiter.Wait()
-
-		var url string
+		query := provider.MatchQuery{Competitions: []string{compCode}}
 		if useDateFrom {
-			url = fmt.Sprintf("%s/matches?dateFrom=%s&competitions=%s", base, param, compCode)
+			query.DateFrom = param
 		} else {
-			url = fmt.Sprintf("%s/matches?date=%s&competitions=%s", base, param, compCode)
-		}
-		req, err := http.NewRequest(http.MethodGet, url, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("X-Auth-Token", s.apiKey)
-		// Perform request
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return nil, err
+			query.Date = param
 		}
-		defer resp.Body.Close()
 
-		// Handle rate limit response
-		if resp.StatusCode == http.StatusTooManyRequests {
-			bodyBytes, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("match fetch failed: status %d, body: %s", resp.StatusCode, string(bodyBytes))
-		}
-
-		if resp.StatusCode != http.StatusOK {
-			bodyBytes, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("match fetch failed: status %d, body: %s", resp.StatusCode, string(bodyBytes))
-		}
-
-		var raw struct {
-			Matches []json.RawMessage `json:"matches"`
-		}
-		if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
-			return nil, err
+		raw, err := s.dataProvider.Matches(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("match fetch failed: %w", err)
 		}
 		var out []models.FixtureMatchDTO
-		for _, rm := range raw.Matches {
-			var m map[string]interface{}
-			if err := json.Unmarshal(rm, &m); err != nil {
-				continue
-			}
-			// parse date, status, teams, scores, venue, crests
-			dateStr, _ := m["utcDate"].(string)
-			mt, _ := time.Parse(time.RFC3339, dateStr)
-			venue, _ := m["venue"].(string)
-			status, _ := m["status"].(string)
-			scoreObj, _ := m["score"].(map[string]interface{})
-			ft, _ := scoreObj["fullTime"].(map[string]interface{})
-			var hs, as *int
-			if v, ok := ft["homeTeam"].(float64); ok {
-				i := int(v)
-				hs = &i
-			}
-			if v, ok := ft["awayTeam"].(float64); ok {
-				i := int(v)
-				as = &i
-			}
-			home, _ := m["homeTeam"].(map[string]interface{})
-			away, _ := m["awayTeam"].(map[string]interface{})
-			hcrest, _ := home["crest"].(string)
-			acrest, _ := away["crest"].(string)
-			out = append(out, models.FixtureMatchDTO{
-				MatchDate:    mt,
-				HomeTeamName: home["name"].(string), AwayTeamName: away["name"].(string),
-				HomeScore: hs, AwayScore: as,
-				MatchStatus: status, Venue: venue,
-				HomeLogoURL: hcrest, AwayLogoURL: acrest,
-			})
+		records := make([]models.Match, 0, len(raw.Matches))
+		for _, m := range raw.Matches {
+			out = append(out, fixtureFromMatch(m))
+			records = append(records, matchRecordFromResponse(m))
 		}
+		s.storeMatches(records)
 		return out, nil
 	}
 
@@ -391,23 +146,21 @@ func (s *fixturesService) GetFixturesSummary(competitionCode string) (models.Fix
 	tomorrow := now.Add(24 * time.Hour).Format("2006-01-02")
 	dayAfter := now.Add(48 * time.Hour).Format("2006-01-02")
 
-	// Fetch each bucket with error handling
-	var todayList, tomorrowList, upcomingList []models.FixtureMatchDTO
-
-	todayList, err = fetch(today, false)
+	// Fetch each bucket. A failed bucket fails the summary rather than showing up empty, so
+	// callers keep serving the last complete summary.
+	todayList, err := fetch(today, false)
 	if err != nil {
-		// Log error but continue with other buckets
-		fmt.Printf("Error fetching today's fixtures: %v\n", err)
+		return models.FixturesSummaryDTO{}, fmt.Errorf("today's fixtures: %w", err)
 	}
 
-	tomorrowList, err = fetch(tomorrow, false)
+	tomorrowList, err := fetch(tomorrow, false)
 	if err != nil {
-		fmt.Printf("Error fetching tomorrow's fixtures: %v\n", err)
+		return models.FixturesSummaryDTO{}, fmt.Errorf("tomorrow's fixtures: %w", err)
 	}
 
-	upcomingList, err = fetch(dayAfter, true)
+	upcomingList, err := fetch(dayAfter, true)
 	if err != nil {
-		fmt.Printf("Error fetching upcoming fixtures: %v\n", err)
+		return models.FixturesSummaryDTO{}, fmt.Errorf("upcoming fixtures: %w", err)
 	}
 
 	if len(upcomingList) > 4 {
@@ -433,24 +186,161 @@ func (s *fixturesService) GetFixturesSummary(competitionCode string) (models.Fix
 		Today:           todayList,
 		Tomorrow:        tomorrowList,
 		Upcoming:        upcomingList,
+		Demo:            s.demo,
 	}
 
-	// Store in cache for future use (with 24 hour TTL)
-	summaryJSON, err := json.Marshal(summary)
-	if err == nil {
-		var jsonData models.JSONB
-		err = json.Unmarshal(summaryJSON, &jsonData)
-		if err == nil {
-			s.cacheRepo.StoreCachedFixtures(compCode, "fixtures_summary", jsonData, 24*time.Hour)
+	return summary, nil
+}
+
+// GetMatch fetches a single match by its provider ID. It always hits the API so the
+// kickoff time and status are current.
+func (s *fixturesService) GetMatch(ctx context.Context, matchID int) (*models.FixtureMatchDTO, error) {
+	m, err := s.dataProvider.Match(ctx, matchID)
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return nil, ErrMatchNotFound
 		}
+		return nil, fmt.Errorf("match fetch failed: %w", err)
 	}
+	match := fixtureFromMatch(*m)
+	s.storeMatches([]models.Match{matchRecordFromResponse(*m)})
+	return &match, nil
+}
 
-	return summary, nil
+// TodaysFixturesCacheKey is the today_fixtures cache key of the current UTC day
+func TodaysFixturesCacheKey() string {
+	return time.Now().UTC().Format("2006-01-02")
+}
+
+// TodaysFixturesFetch fetches today's fixtures for the cache, which keeps them only if there are any
+func TodaysFixturesFetch(fixturesService FixturesService) CacheFetch {
+	return func(ctx context.Context) (interface{}, bool, error) {
+		fixtures, err := fixturesService.GetTodaysFixtures(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		return fixtures, len(fixtures) > 0, nil
+	}
+}
+
+// FixturesSummaryFetch fetches a competition's fixtures summary for the cache, which keeps it only
+// if at least one of its buckets has matches
+func FixturesSummaryFetch(fixturesService FixturesService, competitionCode string) CacheFetch {
+	return func(ctx context.Context) (interface{}, bool, error) {
+		summary, err := fixturesService.GetFixturesSummary(ctx, competitionCode)
+		if err != nil {
+			return nil, false, err
+		}
+		hasData := len(summary.Today) > 0 || len(summary.Tomorrow) > 0 || len(summary.Upcoming) > 0
+		return summary, hasData, nil
+	}
+}
+
+// storeMatches upserts fetched matches into the matches table. Failures are logged and
+// don't fail the request, since the provider data is still returned to the caller.
+func (s *fixturesService) storeMatches(records []models.Match) {
+	valid := make([]models.Match, 0, len(records))
+	for _, record := range records {
+		if record.ProviderID != 0 {
+			valid = append(valid, record)
+		}
+	}
+	if err := s.matchRepo.UpsertMany(valid); err != nil {
+		fmt.Printf("[ERROR] Failed to store %d matches: %v\n", len(valid), err)
+	}
+}
+
+// fixtureFromMatch converts a provider match into a FixtureMatchDTO. Teams that aren't known
+// yet are named TBD.
+func fixtureFromMatch(m models.MatchResponse) models.FixtureMatchDTO {
+	result := m.Score.Result()
+	fullTime := m.Score.FullTime
+	if m.Score.Duration != "" && m.Score.Duration != "REGULAR" {
+		fullTime = m.Score.RegularTime
+	}
+
+	fixture := models.FixtureMatchDTO{
+		MatchID:      m.ID,
+		MatchDate:    m.UtcDate, // UTC timestamp
+		HomeTeamID:   m.HomeTeam.ID,
+		HomeTeamName: teamNameOrTBD(m.HomeTeam),
+		AwayTeamID:   m.AwayTeam.ID,
+		AwayTeamName: teamNameOrTBD(m.AwayTeam),
+		HomeScore:    result.Home,
+		AwayScore:    result.Away,
+		MatchStatus:  m.Status,
+		Venue:        m.Venue,
+		HomeLogoURL:  m.HomeTeam.Crest,
+		AwayLogoURL:  m.AwayTeam.Crest,
+		Stage:        m.Stage,
+		Group:        m.Group,
+		Winner:       m.Score.Winner,
+		Duration:     m.Score.Duration,
+		HalfTime:     scoreDTO(m.Score.HalfTime),
+		FullTime:     scoreDTO(fullTime),
+		ExtraTime:    scoreDTO(m.Score.ExtraTime),
+		Penalties:    scoreDTO(m.Score.Penalties),
+	}
+	if m.Matchday > 0 {
+		matchday := m.Matchday
+		fixture.Matchday = &matchday
+	}
+	for _, referee := range m.Referees {
+		if referee.Name == "" {
+			continue
+		}
+		fixture.Referees = append(fixture.Referees, models.RefereeDTO{
+			Name:        referee.Name,
+			Type:        referee.Type,
+			Nationality: referee.Nationality,
+		})
+	}
+	return fixture
+}
+
+// teamNameOrTBD returns a team's name, or TBD for a team that isn't known yet
+func teamNameOrTBD(team models.TeamResponse) string {
+	if team.Name == "" {
+		return tbdTeamName
+	}
+	return team.Name
+}
+
+// scoreDTO converts a score pair, or returns nil if the period hasn't been played
+func scoreDTO(score models.ScoreResponse) *models.ScoreDTO {
+	if score.Home == nil || score.Away == nil {
+		return nil
+	}
+	return &models.ScoreDTO{Home: *score.Home, Away: *score.Away}
 }
 
-func mapCompetitionCode(code string) string {
-	if code == "EL" {
-		return "UEL"
+// matchRecordFromResponse converts a provider match into a Match record for storage
+func matchRecordFromResponse(m models.MatchResponse) models.Match {
+	var matchday *int
+	if m.Matchday > 0 {
+		md := m.Matchday
+		matchday = &md
+	}
+
+	result := m.Score.Result()
+	return models.Match{
+		ProviderID:        m.ID,
+		CompetitionCode:   m.Competition.Code,
+		CompetitionName:   m.Competition.Name,
+		CompetitionEmblem: m.Competition.Emblem,
+		Matchday:          matchday,
+		Stage:             m.Stage,
+		GroupName:         m.Group,
+		KickoffAt:         m.UtcDate,
+		Status:            m.Status,
+		Venue:             m.Venue,
+		HomeTeamID:        m.HomeTeam.ID,
+		HomeTeamName:      m.HomeTeam.Name,
+		HomeTeamCrest:     m.HomeTeam.Crest,
+		AwayTeamID:        m.AwayTeam.ID,
+		AwayTeamName:      m.AwayTeam.Name,
+		AwayTeamCrest:     m.AwayTeam.Crest,
+		HomeScore:         result.Home,
+		AwayScore:         result.Away,
 	}
-	return code
 }
@@ -1,210 +1,253 @@
 package service
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
+	"libero-backend/internal/demodata"
 	"libero-backend/internal/models"
-	"net/http"
+	"libero-backend/internal/provider"
+	"libero-backend/internal/repository"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type FootballService struct {
-	baseURL     string
-	apiKey      string
-	rateLimiter *time.Ticker
-	client      *http.Client
+	dataProvider provider.FootballDataProvider
+	matchRepo    repository.MatchRepository // Stored matches, which team forms are computed from
+	demo         bool                       // Data comes from the demo provider and is flagged as such
 }
 
-func NewFootballService(baseURL, apiKey string) *FootballService {
-	// Initialize with rate limiting (10 requests per minute as per API limits)
+func NewFootballService(dataProvider provider.FootballDataProvider, matchRepo repository.MatchRepository) *FootballService {
 	return &FootballService{
-		baseURL:     strings.TrimSuffix(baseURL, "/"), // Remove trailing slash if present
-		apiKey:      apiKey,
-		rateLimiter: time.NewTicker(6 * time.Second), // 10 requests per minute = 1 request per 6 seconds
-		client:      &http.Client{Timeout: 30 * time.Second},
+		dataProvider: dataProvider,
+		matchRepo:    matchRepo,
+		demo:         dataProvider.Name() == demodata.ProviderName,
 	}
 }
 
-// GetStandingsVersion gets the latest version/etag of standings data
-func (s *FootballService) GetStandingsVersion(competitionCode string) (string, error) {
-	<-s.rateLimiter.C
+// formLength is the number of results in a team's form
+const formLength = 5
 
-	url := fmt.Sprintf("%s/competitions/%s/standings", s.baseURL, competitionCode)
-
-	// Debug URL (remove in production)
-	fmt.Printf("Fetching standings from: %s\n", url)
-
-	req, err := http.NewRequest("HEAD", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+// StandingsCacheKey is the standings cache key of a competition's season and table type. The
+// current season's total standings are keyed by the competition code alone.
+func StandingsCacheKey(competitionCode, standingsType string, season int) string {
+	key := ScorersCacheKey(competitionCode, season)
+	if standingsType != models.StandingsTypeTotal {
+		key += ":" + standingsType
 	}
+	return key
+}
 
-	// Set required headers
-	req.Header.Set("X-Auth-Token", s.apiKey)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return "", err
+// ScorersCacheKey is the top scorers cache key of a competition's season, 0 being the current one
+func ScorersCacheKey(competitionCode string, season int) string {
+	if season == 0 {
+		return competitionCode
 	}
-	defer resp.Body.Close()
+	return competitionCode + ":" + strconv.Itoa(season)
+}
 
-	// Check for ETag header
-	etag := resp.Header.Get("ETag")
-	if etag != "" {
-		return etag, nil
+// StandingsCacheNamespace is where standings of a season are cached: completed seasons never
+// change, so they are kept for good
+func StandingsCacheNamespace(completed bool) CacheNamespace {
+	if completed {
+		return CacheStandingsArchive
 	}
+	return CacheStandings
+}
 
-	// Fallback to Last-Modified if no ETag
-	lastMod := resp.Header.Get("Last-Modified")
-	if lastMod != "" {
-		return lastMod, nil
+// ScorersCacheNamespace is where top scorers of a season are cached, like StandingsCacheNamespace
+func ScorersCacheNamespace(completed bool) CacheNamespace {
+	if completed {
+		return CacheScorersArchive
 	}
+	return CacheScorers
+}
 
-	// If no version info available, return current timestamp
-	return fmt.Sprintf("%d", time.Now().Unix()), nil
+// SeasonCompleted reports whether the season starting in the given year had ended by now, going
+// by its end date in the competition's seasons listing. Season 0, the current one, never has, and
+// neither have seasons missing from the listing or without an end date.
+func SeasonCompleted(seasons *models.CompetitionSeasonsDTO, season int, now time.Time) bool {
+	if season == 0 || seasons == nil {
+		return false
+	}
+	today := now.UTC().Format("2006-01-02")
+	for _, s := range seasons.Seasons {
+		if s.Year == season {
+			// Dates are YYYY-MM-DD, so they compare as strings
+			return s.EndDate != "" && s.EndDate < today
+		}
+	}
+	return false
 }
 
-// GetStandings retrieves the current standings for a competition
-func (s *FootballService) GetStandings(competitionCode string) (*models.CompetitionStandingsDTO, error) {
-	fmt.Printf("[DEBUG] Fetching standings for competition: %s\n", competitionCode)
-	competitionCode = mapCompetitionCode(competitionCode)
-	<-s.rateLimiter.C
-	url := fmt.Sprintf("%s/competitions/%s/standings", strings.TrimRight(s.baseURL, "/"), competitionCode)
-	fmt.Printf("[DEBUG] Standings API URL: %s\n", url)
-	req, err := http.NewRequest("GET", url, nil)
+// standingsTypes are the table types every competition's standings come in
+var standingsTypes = []string{models.StandingsTypeTotal, models.StandingsTypeHome, models.StandingsTypeAway}
+
+// GetStandings retrieves the standings of the given type (TOTAL, HOME or AWAY) for a competition's
+// season, 0 being the current one, with every group's table and each team's form
+func (s *FootballService) GetStandings(ctx context.Context, competitionCode, standingsType string, season int) (*models.CompetitionStandingsDTO, error) {
+	standings, err := s.GetStandingsByType(ctx, competitionCode, season)
 	if err != nil {
-		// Always return empty DTO for any error
-		return &models.CompetitionStandingsDTO{
-			CompetitionName: "",
-			CompetitionCode: competitionCode,
-			Season:          0,
-			Standings:       []models.StandingsTableDTO{},
-		}, nil
+		return nil, err
 	}
-	req.Header.Set("X-Auth-Token", s.apiKey)
+	return standings[standingsType], nil
+}
 
-	resp, err := http.DefaultClient.Do(req)
+// GetStandingsByType retrieves the TOTAL, HOME and AWAY standings of a competition's season, 0
+// being the current one, by type. All three come from a single provider request.
+func (s *FootballService) GetStandingsByType(ctx context.Context, competitionCode string, season int) (map[string]*models.CompetitionStandingsDTO, error) {
+	rawStandings, err := s.dataProvider.Standings(ctx, competitionCode, season)
+	if errors.Is(err, provider.ErrNotFound) {
+		// Return empty DTOs for unsupported competitions
+		rawStandings, err = &models.StandingsResponse{}, nil
+	}
 	if err != nil {
-		fmt.Printf("[ERROR] HTTP request failed: %v\n", err)
-		return &models.CompetitionStandingsDTO{
-			CompetitionName: "",
-			CompetitionCode: competitionCode,
-			Season:          0,
-			Standings:       []models.StandingsTableDTO{},
-		}, nil
+		// Fail rather than answer with an empty table, so callers keep serving the last known standings
+		return nil, fmt.Errorf("standings request failed: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		fmt.Printf("[ERROR] Non-OK status %d for %s. Body: %s\n", resp.StatusCode, url, string(body))
-		return &models.CompetitionStandingsDTO{
-			CompetitionName: "",
-			CompetitionCode: competitionCode,
-			Season:          0,
-			Standings:       []models.StandingsTableDTO{},
-		}, nil
+	// Forms come from the current season's stored results, so they cost no provider request. For
+	// past seasons, and teams without stored results, the provider's own are used.
+	var matches []models.Match
+	if season == 0 && len(rawStandings.Standings) > 0 {
+		matches, err = s.storedResults(competitionCode)
+		if err != nil {
+			fmt.Printf("[WARN] Using provider forms for %s standings: %v\n", competitionCode, err)
+		}
 	}
 
-	var rawStandings models.StandingsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&rawStandings); err != nil {
-		fmt.Printf("[ERROR] Failed to decode standings response: %v\n", err)
-		return &models.CompetitionStandingsDTO{
-			CompetitionName: "",
+	byType := make(map[string]*models.CompetitionStandingsDTO, len(standingsTypes))
+	for _, standingsType := range standingsTypes {
+		result := &models.CompetitionStandingsDTO{
+			CompetitionName: rawStandings.Competition.Name,
 			CompetitionCode: competitionCode,
-			Season:          0,
-			Standings:       []models.StandingsTableDTO{},
-		}, nil
-	}
-	fmt.Printf("[DEBUG] Raw standings response for %s: %+v\n", competitionCode, rawStandings)
+			Season:          rawStandings.Season.ID,
+			Type:            standingsType,
+			Standings:       make([]models.StandingsTableDTO, 0),
+			Groups:          make([]models.StandingsGroupDTO, 0),
+		}
+		if len(rawStandings.Standings) > 0 {
+			result.Demo = s.demo
+		}
 
-	// Convert to our DTO format
-	result := &models.CompetitionStandingsDTO{
-		CompetitionName: rawStandings.Competition.Name,
-		CompetitionCode: competitionCode,
-		Season:          rawStandings.Season.ID,
-		Standings:       make([]models.StandingsTableDTO, 0),
-	}
-
-	// Get the total standings (usually first group for league format)
-	if len(rawStandings.Standings) > 0 {
-		// Find the REGULAR_SEASON and TOTAL type
-		var found bool
-		for _, s := range rawStandings.Standings {
-			if s.Stage == "REGULAR_SEASON" && len(s.Table) > 0 {
-				for _, row := range s.Table {
-					result.Standings = append(result.Standings, models.StandingsTableDTO{
-						Position:       row.Position,
-						TeamName:       row.Team.Name,
-						TeamCrest:      row.Team.Crest,
-						PlayedGames:    row.PlayedGames,
-						Won:            row.Won,
-						Draw:           row.Draw,
-						Lost:           row.Lost,
-						GoalsFor:       row.GoalsFor,
-						GoalsAgainst:   row.GoalsAgainst,
-						GoalDifference: row.GoalDifference,
-						Points:         row.Points,
-					})
+		for _, group := range rawStandings.Standings {
+			groupType := group.Type
+			if groupType == "" {
+				groupType = models.StandingsTypeTotal
+			}
+			if groupType != standingsType || len(group.Table) == 0 {
+				continue
+			}
+			table := standingsTableFromRows(group.Table)
+			forms := teamForms(matches, group.Stage, group.Group, standingsType)
+			for i := range table {
+				if form, ok := forms[table[i].TeamID]; ok {
+					table[i].Form = form
 				}
-				found = true
-				break
 			}
+			result.Groups = append(result.Groups, models.StandingsGroupDTO{Stage: group.Stage, Group: group.Group, Table: table})
 		}
-		// Fallback: if not found, use the first standings group
-		if !found && len(rawStandings.Standings[0].Table) > 0 {
-			for _, row := range rawStandings.Standings[0].Table {
-				result.Standings = append(result.Standings, models.StandingsTableDTO{
-					Position:       row.Position,
-					TeamName:       row.Team.Name,
-					TeamCrest:      row.Team.Crest,
-					PlayedGames:    row.PlayedGames,
-					Won:            row.Won,
-					Draw:           row.Draw,
-					Lost:           row.Lost,
-					GoalsFor:       row.GoalsFor,
-					GoalsAgainst:   row.GoalsAgainst,
-					GoalDifference: row.GoalDifference,
-					Points:         row.Points,
-				})
+
+		// The main table is the regular season's, or else the first group's
+		for _, group := range result.Groups {
+			if group.Stage == "REGULAR_SEASON" {
+				result.Standings = group.Table
+				break
 			}
 		}
+		if len(result.Standings) == 0 && len(result.Groups) > 0 {
+			result.Standings = result.Groups[0].Table
+		}
+		byType[standingsType] = result
 	}
+	return byType, nil
+}
 
-	// Fallback: if no standings data, inject mock data for BL1
-	if len(result.Standings) == 0 && (competitionCode == "BL1" || competitionCode == "bl1") {
-		// Example mock data for Bundesliga (replace with real or more complete mock data as needed)
-		result.Standings = []models.StandingsTableDTO{
-			{Position: 1, TeamName: "Bayern Munich", TeamCrest: "/path/to/bayern.png", PlayedGames: 30, Won: 25, Draw: 3, Lost: 2, GoalsFor: 90, GoalsAgainst: 25, GoalDifference: 65, Points: 78},
-			{Position: 2, TeamName: "Borussia Dortmund", TeamCrest: "/path/to/dortmund.png", PlayedGames: 30, Won: 22, Draw: 5, Lost: 3, GoalsFor: 80, GoalsAgainst: 30, GoalDifference: 50, Points: 71},
-			{Position: 3, TeamName: "RB Leipzig", TeamCrest: "/path/to/leipzig.png", PlayedGames: 30, Won: 20, Draw: 6, Lost: 4, GoalsFor: 70, GoalsAgainst: 35, GoalDifference: 35, Points: 66},
-		}
+// standingsTableFromRows converts a provider standings table into our DTO format
+func standingsTableFromRows(rows []models.StandingsRowResponse) []models.StandingsTableDTO {
+	table := make([]models.StandingsTableDTO, 0, len(rows))
+	for _, row := range rows {
+		table = append(table, models.StandingsTableDTO{
+			Position:       row.Position,
+			TeamID:         row.Team.ID,
+			TeamName:       row.Team.Name,
+			TeamCrest:      row.Team.Crest,
+			PlayedGames:    row.PlayedGames,
+			Won:            row.Won,
+			Draw:           row.Draw,
+			Lost:           row.Lost,
+			GoalsFor:       row.GoalsFor,
+			GoalsAgainst:   row.GoalsAgainst,
+			GoalDifference: row.GoalDifference,
+			Points:         row.Points,
+			Form:           strings.ReplaceAll(row.Form, ",", ""),
+		})
 	}
+	return table
+}
 
-	return result, nil
+// storedResults returns the stored finished matches of a competition's current season, in
+// kickoff order
+func (s *FootballService) storedResults(competitionCode string) ([]models.Match, error) {
+	if s.matchRepo == nil {
+		return nil, nil
+	}
+	from, _ := seasonWindow(seasonStartYear(time.Now().UTC()))
+	return s.matchRepo.FindAll(models.MatchFilter{
+		Competition: competitionCode,
+		Status:      []string{"FINISHED", "AWARDED"},
+		From:        &from,
+	})
 }
 
-// GetTopScorers retrieves the top scorers for a competition
-func (s *FootballService) GetTopScorers(competitionCode string) (*models.CompetitionScorersDTO, error) {
-	competitionCode = mapCompetitionCode(competitionCode)
-	<-s.rateLimiter.C
-	url := fmt.Sprintf("%s/competitions/%s/scorers", strings.TrimRight(s.baseURL, "/"), competitionCode)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create scorers request: %w", err)
+// teamForms returns each team's last results in the finished matches of a table's stage and group,
+// which are in kickoff order, by team ID. HOME and AWAY tables only count the team's home or away
+// matches.
+func teamForms(matches []models.Match, stage, group, standingsType string) map[int]string {
+	finished := make([]models.Match, 0, len(matches))
+	for _, m := range matches {
+		if !isFinishedStatus(m.Status) || (m.Stage != "" && m.Stage != stage) || m.GroupName != group {
+			continue
+		}
+		finished = append(finished, m)
 	}
-	req.Header.Set("X-Auth-Token", s.apiKey)
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute scorers request: %w", err)
+	forms := make(map[int]string)
+	record := func(teamID int, goalsFor, goalsAgainst int) {
+		result := "D"
+		switch {
+		case goalsFor > goalsAgainst:
+			result = "W"
+		case goalsFor < goalsAgainst:
+			result = "L"
+		}
+		form := forms[teamID] + result
+		if len(form) > formLength {
+			form = form[len(form)-formLength:]
+		}
+		forms[teamID] = form
 	}
-	defer resp.Body.Close()
+	for _, m := range finished {
+		if m.HomeScore == nil || m.AwayScore == nil {
+			continue
+		}
+		if standingsType != models.StandingsTypeAway {
+			record(m.HomeTeamID, *m.HomeScore, *m.AwayScore)
+		}
+		if standingsType != models.StandingsTypeHome {
+			record(m.AwayTeamID, *m.AwayScore, *m.HomeScore)
+		}
+	}
+	return forms
+}
 
-	if resp.StatusCode == http.StatusNotFound {
+// GetTopScorers retrieves the top scorers for a competition's season, 0 being the current one
+func (s *FootballService) GetTopScorers(ctx context.Context, competitionCode string, season int) (*models.CompetitionScorersDTO, error) {
+	rawScorers, err := s.dataProvider.Scorers(ctx, competitionCode, season)
+	if errors.Is(err, provider.ErrNotFound) {
 		// Return empty DTO for unsupported competitions
 		return &models.CompetitionScorersDTO{
 			CompetitionName: "",
@@ -213,14 +256,8 @@ func (s *FootballService) GetTopScorers(competitionCode string) (*models.Competi
 			Scorers:         []models.ScorerStatsDTO{},
 		}, nil
 	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("scorers request failed with status %d", resp.StatusCode)
-	}
-
-	// Parse raw response first
-	var rawScorers models.ScorersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&rawScorers); err != nil {
-		return nil, fmt.Errorf("failed to decode scorers response: %w", err)
+	if err != nil {
+		return nil, fmt.Errorf("scorers request failed: %w", err)
 	}
 
 	// Convert to our DTO format
@@ -229,11 +266,13 @@ func (s *FootballService) GetTopScorers(competitionCode string) (*models.Competi
 		CompetitionCode: competitionCode,
 		Season:          rawScorers.Season.ID,
 		Scorers:         make([]models.ScorerStatsDTO, 0),
+		Demo:            s.demo,
 	}
 
 	// Map scorers data
 	for _, scorer := range rawScorers.Scorers {
 		result.Scorers = append(result.Scorers, models.ScorerStatsDTO{
+			PlayerID:   scorer.Player.ID,
 			PlayerName: scorer.Player.Name,
 			TeamName:   scorer.Team.Name,
 			TeamCrest:  scorer.Team.Crest,
@@ -246,4 +285,103 @@ func (s *FootballService) GetTopScorers(competitionCode string) (*models.Competi
 	return result, nil
 }
 
+// GetSeasons lists a competition's seasons, most recent first, with the winners of completed ones
+func (s *FootballService) GetSeasons(ctx context.Context, competitionCode string) (*models.CompetitionSeasonsDTO, error) {
+	result := &models.CompetitionSeasonsDTO{
+		CompetitionCode: competitionCode,
+		Seasons:         make([]models.SeasonDTO, 0),
+		Demo:            s.demo,
+	}
+
+	rawSeasons, err := s.dataProvider.CompetitionSeasons(ctx, competitionCode)
+	if errors.Is(err, provider.ErrNotFound) {
+		// Return an empty list for unsupported competitions
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("seasons request failed: %w", err)
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	for _, raw := range rawSeasons {
+		if len(raw.StartDate) < 4 {
+			continue
+		}
+		year, err := strconv.Atoi(raw.StartDate[:4])
+		if err != nil {
+			continue
+		}
+		// Dates are YYYY-MM-DD, so they compare as strings
+		season := models.SeasonDTO{
+			ID:        raw.ID,
+			Year:      year,
+			StartDate: raw.StartDate,
+			EndDate:   raw.EndDate,
+			Completed: raw.EndDate != "" && raw.EndDate < today,
+		}
+		season.Current = !season.Completed && raw.StartDate <= today
+		if raw.Winner != nil {
+			season.WinnerID = raw.Winner.ID
+			season.WinnerName = raw.Winner.Name
+			season.WinnerCrest = raw.Winner.Crest
+		}
+		result.Seasons = append(result.Seasons, season)
+	}
+	sort.SliceStable(result.Seasons, func(i, j int) bool {
+		return result.Seasons[i].Year > result.Seasons[j].Year
+	})
+	return result, nil
+}
+
+// GetMatchesByIDs retrieves the given matches, including their current status and scores
+func (s *FootballService) GetMatchesByIDs(ctx context.Context, ids []int) ([]models.MatchResponse, error) {
+	if len(ids) == 0 {
+		return []models.MatchResponse{}, nil
+	}
+
+	rawMatches, err := s.dataProvider.Matches(ctx, provider.MatchQuery{IDs: ids})
+	if err != nil {
+		return nil, fmt.Errorf("matches request failed: %w", err)
+	}
+	return rawMatches.Matches, nil
+}
+
+// GetMatchesByDate retrieves the matches of the given competitions kicking off between dateFrom
+// and dateTo (YYYY-MM-DD, inclusive), including their current status and scores
+func (s *FootballService) GetMatchesByDate(ctx context.Context, dateFrom, dateTo string, competitionCodes []string) ([]models.MatchResponse, error) {
+	rawMatches, err := s.dataProvider.Matches(ctx, provider.MatchQuery{DateFrom: dateFrom, DateTo: dateTo, Competitions: competitionCodes})
+	if err != nil {
+		return nil, fmt.Errorf("matches request failed: %w", err)
+	}
+	return rawMatches.Matches, nil
+}
+
+// GetCompetitionMatches retrieves every match of a competition's current season, including
+// their current status and scores
+func (s *FootballService) GetCompetitionMatches(ctx context.Context, competitionCode string) ([]models.MatchResponse, error) {
+	rawMatches, err := s.dataProvider.CompetitionMatches(ctx, competitionCode)
+	if err != nil {
+		return nil, fmt.Errorf("competition matches request failed: %w", err)
+	}
+	return rawMatches.Matches, nil
+}
+
+// GetCompetition retrieves a competition with its area and current season
+func (s *FootballService) GetCompetition(ctx context.Context, competitionCode string) (*models.CompetitionDetailResponse, error) {
+	competition, err := s.dataProvider.Competition(ctx, competitionCode)
+	if err != nil {
+		return nil, fmt.Errorf("competition request failed: %w", err)
+	}
+	return competition, nil
+}
+
+// GetCompetitionTeams retrieves the teams of a competition's current season, including their squads
+func (s *FootballService) GetCompetitionTeams(ctx context.Context, competitionCode string) (*models.CompetitionTeamsResponse, error) {
+	teams, err := s.dataProvider.CompetitionTeams(ctx, competitionCode)
+	if err != nil {
+		return nil, fmt.Errorf("competition teams request failed: %w", err)
+	}
+	return teams, nil
+}
+
 // End of file
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"libero-backend/internal/models"
+	"testing"
+	"time"
+)
+
+// storedResult is a finished league match of the current season, the id-th hour into it
+func storedResult(id int, homeID, awayID, homeScore, awayScore int) models.Match {
+	seasonStart, _ := seasonWindow(seasonStartYear(time.Now().UTC()))
+	return models.Match{
+		ProviderID:      id,
+		CompetitionCode: "PL",
+		Stage:           "REGULAR_SEASON",
+		KickoffAt:       seasonStart.Add(time.Duration(id) * time.Hour),
+		Status:          "FINISHED",
+		HomeTeamID:      homeID,
+		AwayTeamID:      awayID,
+		HomeScore:       intPtr(homeScore),
+		AwayScore:       intPtr(awayScore),
+	}
+}
+
+func TestGetStandingsForms(t *testing.T) {
+	row := func(teamID int, form string) models.StandingsRowResponse {
+		return models.StandingsRowResponse{Team: models.TeamResponse{ID: teamID}, Form: form}
+	}
+	fd := &stubProvider{standings: &models.StandingsResponse{
+		Standings: []models.StandingsGroupResponse{
+			{Stage: "REGULAR_SEASON", Type: models.StandingsTypeTotal, Table: []models.StandingsRowResponse{row(1, "L,L"), row(2, "W,W"), row(3, "D,W,L")}},
+			{Stage: "REGULAR_SEASON", Type: models.StandingsTypeHome, Table: []models.StandingsRowResponse{row(1, ""), row(2, ""), row(3, "")}},
+		},
+	}}
+	matches := &fakeMatchRepo{}
+	_ = matches.UpsertMany([]models.Match{
+		storedResult(1, 1, 2, 2, 0),
+		storedResult(2, 2, 1, 1, 1),
+		storedResult(3, 1, 2, 0, 1),
+	})
+	s := NewFootballService(fd, matches)
+
+	tests := []struct {
+		standingsType string
+		want          map[int]string
+	}{
+		{standingsType: models.StandingsTypeTotal, want: map[int]string{1: "WDL", 2: "LDW", 3: "DWL"}},
+		{standingsType: models.StandingsTypeHome, want: map[int]string{1: "WL", 2: "D", 3: ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.standingsType, func(t *testing.T) {
+			standings, err := s.GetStandings(context.Background(), "PL", tt.standingsType, 0)
+			if err != nil {
+				t.Fatalf("GetStandings() error = %v", err)
+			}
+			for _, row := range standings.Standings {
+				if row.Form != tt.want[row.TeamID] {
+					t.Errorf("team %d form = %q, want %q", row.TeamID, row.Form, tt.want[row.TeamID])
+				}
+			}
+		})
+	}
+
+	// Forms take no provider request besides the standings themselves
+	if fd.calls["CompetitionMatches"] != 0 || fd.calls["Matches"] != 0 {
+		t.Errorf("provider calls = %v, want standings only", fd.calls)
+	}
+}
+
+func TestSeasonCompleted(t *testing.T) {
+	seasons := &models.CompetitionSeasonsDTO{Seasons: []models.SeasonDTO{
+		{Year: 2025, StartDate: "2025-08-15", EndDate: "2026-05-24"},
+		{Year: 2024, StartDate: "2024-08-16", EndDate: "2025-05-25"},
+		{Year: 2023, StartDate: "2023-08-11"},
+	}}
+	now := time.Date(2025, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		seasons *models.CompetitionSeasonsDTO
+		season  int
+		want    bool
+	}{
+		{name: "ended", seasons: seasons, season: 2024, want: true},
+		{name: "still on", seasons: seasons, season: 2025, want: false},
+		{name: "current", seasons: seasons, season: 0, want: false},
+		{name: "no end date", seasons: seasons, season: 2023, want: false},
+		{name: "not listed", seasons: seasons, season: 2019, want: false},
+		{name: "no listing", seasons: nil, season: 2024, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SeasonCompleted(tt.seasons, tt.season, now); got != tt.want {
+				t.Errorf("SeasonCompleted(%d) = %v, want %v", tt.season, got, tt.want)
+			}
+		})
+	}
+
+	// A season ending in the spring of a calendar-year competition isn't taken to run until July
+	calendar := &models.CompetitionSeasonsDTO{Seasons: []models.SeasonDTO{{Year: 2025, StartDate: "2025-02-01", EndDate: "2025-06-30"}}}
+	if !SeasonCompleted(calendar, 2025, now) {
+		t.Error("SeasonCompleted() of a season that ended yesterday = false, want true")
+	}
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"libero-backend/internal/models"
+	"libero-backend/internal/repository"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Error definitions for league service
+var (
+	ErrLeagueNotFound     = errors.New("league not found")
+	ErrNotLeagueMember    = errors.New("user is not a member of this league")
+	ErrAlreadyMember      = errors.New("user is already a member of this league")
+	ErrCannotRemoveOwner  = errors.New("the league owner cannot be removed")
+	ErrSeasonNotFound     = errors.New("league season not found")
+	ErrSeasonConflict     = errors.New("league season was archived in the meantime")
+	ErrRemovedFromLeague  = errors.New("user was removed from this league")
+	ErrInviteCodeConflict = errors.New("could not generate a unique invite code")
+)
+
+const (
+	// inviteCodeLength is the number of characters in a league invite code
+	inviteCodeLength = 8
+	// inviteCodeAttempts is how often a new code is generated if it collides with an existing one
+	inviteCodeAttempts = 3
+)
+
+// LeagueService defines the interface for private prediction leagues
+type LeagueService interface {
+	CreateLeague(ownerID uint, request *models.CreateLeagueRequest) (*models.League, error)
+	GetUserLeagues(userID uint) ([]models.League, error)
+	JoinLeague(userID uint, inviteCode string) (*models.League, error)
+	GetLeague(leagueID uint) (*models.League, error)
+	GetMemberRole(leagueID, userID uint) (string, error)
+	GetStandings(leagueID uint, season int) (*models.LeagueStandingsResponse, error)
+	RemoveMember(leagueID, userID uint) error
+	ArchiveSeason(leagueID uint) (*models.LeagueStandingsResponse, error)
+}
+
+// leagueService implements the LeagueService interface
+type leagueService struct {
+	leagueRepo repository.LeagueRepository
+}
+
+// NewLeagueService creates a new league service instance
+func NewLeagueService(leagueRepo repository.LeagueRepository) LeagueService {
+	return &leagueService{
+		leagueRepo: leagueRepo,
+	}
+}
+
+// CreateLeague creates a league with a fresh invite code, owned and administered by the creator
+func (s *leagueService) CreateLeague(ownerID uint, request *models.CreateLeagueRequest) (*models.League, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, ErrInvalidInput
+	}
+
+	for attempt := 0; attempt < inviteCodeAttempts; attempt++ {
+		code, err := generateInviteCode()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.leagueRepo.FindByInviteCode(code); err == nil {
+			continue // Code already taken, try another one
+		}
+
+		league := &models.League{
+			Name:            name,
+			Description:     strings.TrimSpace(request.Description),
+			InviteCode:      code,
+			OwnerID:         ownerID,
+			Season:          1,
+			SeasonStartedAt: time.Now().UTC(),
+		}
+		if err := s.leagueRepo.Create(league); err != nil {
+			return nil, err
+		}
+		return s.leagueRepo.FindByID(league.ID)
+	}
+
+	return nil, ErrInviteCodeConflict
+}
+
+// GetUserLeagues retrieves all leagues the user is a member of
+func (s *leagueService) GetUserLeagues(userID uint) ([]models.League, error) {
+	return s.leagueRepo.FindByUserID(userID)
+}
+
+// JoinLeague adds the user to the league with the given invite code
+func (s *leagueService) JoinLeague(userID uint, inviteCode string) (*models.League, error) {
+	code := strings.ToUpper(strings.TrimSpace(inviteCode))
+	if code == "" {
+		return nil, ErrInvalidInput
+	}
+
+	league, err := s.leagueRepo.FindByInviteCode(code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLeagueNotFound
+		}
+		return nil, err
+	}
+
+	if _, err := s.leagueRepo.FindMember(league.ID, userID); err == nil {
+		return nil, ErrAlreadyMember
+	}
+	removed, err := s.leagueRepo.IsRemoved(league.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if removed {
+		return nil, ErrRemovedFromLeague
+	}
+
+	member := &models.LeagueMember{
+		LeagueID: league.ID,
+		UserID:   userID,
+		Role:     models.LeagueRoleMember,
+	}
+	if err := s.leagueRepo.AddMember(member); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			// A concurrent join got there first
+			return nil, ErrAlreadyMember
+		}
+		return nil, err
+	}
+
+	return s.leagueRepo.FindByID(league.ID)
+}
+
+// GetLeague retrieves a league with its members
+func (s *leagueService) GetLeague(leagueID uint) (*models.League, error) {
+	league, err := s.leagueRepo.FindByID(leagueID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLeagueNotFound
+		}
+		return nil, err
+	}
+	return league, nil
+}
+
+// GetMemberRole returns the user's role within the league
+func (s *leagueService) GetMemberRole(leagueID, userID uint) (string, error) {
+	member, err := s.leagueRepo.FindMember(leagueID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrNotLeagueMember
+		}
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// GetStandings returns the standings of a league season. Season 0 (or the current season number)
+// is computed live from members' picks; earlier seasons are read from the archive.
+func (s *leagueService) GetStandings(leagueID uint, season int) (*models.LeagueStandingsResponse, error) {
+	league, err := s.GetLeague(leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	if season == 0 || season == league.Season {
+		return s.currentStandings(league)
+	}
+	if season < 0 || season > league.Season {
+		return nil, ErrSeasonNotFound
+	}
+
+	rows, err := s.leagueRepo.FindSeasonStandings(leagueID, season)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrSeasonNotFound
+	}
+
+	ended := rows[0].SeasonEndedAt
+	response := &models.LeagueStandingsResponse{
+		LeagueID:  leagueID,
+		Season:    season,
+		From:      rows[0].SeasonStartedAt,
+		To:        &ended,
+		Archived:  true,
+		Standings: make([]models.LeaderboardEntry, 0, len(rows)),
+	}
+	for _, row := range rows {
+		response.Standings = append(response.Standings, models.LeaderboardEntry{
+			Rank:            row.Rank,
+			UserID:          row.UserID,
+			Username:        row.Username,
+			Name:            row.Name,
+			Points:          row.Points,
+			Picks:           row.Picks,
+			ExactScores:     row.ExactScores,
+			CorrectOutcomes: row.CorrectOutcomes,
+		})
+	}
+	return response, nil
+}
+
+// RemoveMember removes a member from the league, who then can't rejoin it with the invite code.
+// The owner cannot be removed.
+func (s *leagueService) RemoveMember(leagueID, userID uint) error {
+	league, err := s.GetLeague(leagueID)
+	if err != nil {
+		return err
+	}
+	if league.OwnerID == userID {
+		return ErrCannotRemoveOwner
+	}
+	if _, err := s.GetMemberRole(leagueID, userID); err != nil {
+		return err
+	}
+	return s.leagueRepo.RemoveMember(leagueID, userID)
+}
+
+// ArchiveSeason freezes the current season's standings, from the picks settled so far, and starts a
+// new season from now. Picks still pending count toward the season they settle in.
+func (s *leagueService) ArchiveSeason(leagueID uint) (*models.LeagueStandingsResponse, error) {
+	league, err := s.GetLeague(leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	endedAt := time.Now().UTC()
+	entries, err := s.leagueRepo.GetStandings(leagueID, league.SeasonStartedAt, &endedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute league standings: %w", err)
+	}
+
+	rows := make([]models.LeagueSeasonStanding, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, models.LeagueSeasonStanding{
+			LeagueID:        leagueID,
+			Season:          league.Season,
+			SeasonStartedAt: league.SeasonStartedAt,
+			SeasonEndedAt:   endedAt,
+			Rank:            entry.Rank,
+			UserID:          entry.UserID,
+			Username:        entry.Username,
+			Name:            entry.Name,
+			Points:          entry.Points,
+			Picks:           entry.Picks,
+			ExactScores:     entry.ExactScores,
+			CorrectOutcomes: entry.CorrectOutcomes,
+		})
+	}
+	if err := s.leagueRepo.ArchiveSeason(league, rows, endedAt); err != nil {
+		if errors.Is(err, repository.ErrSeasonChanged) {
+			return nil, ErrSeasonConflict
+		}
+		return nil, fmt.Errorf("failed to archive league season: %w", err)
+	}
+
+	if entries == nil {
+		entries = []models.LeaderboardEntry{}
+	}
+	return &models.LeagueStandingsResponse{
+		LeagueID:  leagueID,
+		Season:    league.Season,
+		From:      league.SeasonStartedAt,
+		To:        &endedAt,
+		Archived:  true,
+		Standings: entries,
+	}, nil
+}
+
+// currentStandings computes the live standings of the league's current season
+func (s *leagueService) currentStandings(league *models.League) (*models.LeagueStandingsResponse, error) {
+	entries, err := s.leagueRepo.GetStandings(league.ID, league.SeasonStartedAt, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute league standings: %w", err)
+	}
+	if entries == nil {
+		entries = []models.LeaderboardEntry{}
+	}
+	return &models.LeagueStandingsResponse{
+		LeagueID:  league.ID,
+		Season:    league.Season,
+		From:      league.SeasonStartedAt,
+		Archived:  false,
+		Standings: entries,
+	}, nil
+}
+
+// generateInviteCode creates a random, human-friendly invite code
+func generateInviteCode() (string, error) {
+	b := make([]byte, 5) // 5 bytes encode to exactly 8 base32 characters
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate invite code: %w", err)
+	}
+	return base32.StdEncoding.EncodeToString(b)[:inviteCodeLength], nil
+}
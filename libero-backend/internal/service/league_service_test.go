@@ -0,0 +1,49 @@
+package service
+
+import (
+	"errors"
+	"libero-backend/internal/models"
+	"libero-backend/internal/repository"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestJoinLeague(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      string
+		removed   bool
+		addMember error
+		wantErr   error
+	}{
+		{name: "joins", code: "abcd2345"},
+		{name: "removed before", code: "ABCD2345", removed: true, wantErr: ErrRemovedFromLeague},
+		{name: "concurrent join", code: "ABCD2345", addMember: gorm.ErrDuplicatedKey, wantErr: ErrAlreadyMember},
+		{name: "unknown code", code: "ZZZZ2345", wantErr: ErrLeagueNotFound},
+		{name: "no code", code: " ", wantErr: ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeLeagueRepo{league: models.League{ID: 1, InviteCode: "ABCD2345"}, removed: tt.removed, addMember: tt.addMember}
+			_, err := NewLeagueService(repo).JoinLeague(7, tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("JoinLeague(%q) error = %v, want %v", tt.code, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArchiveSeasonConflict(t *testing.T) {
+	repo := &fakeLeagueRepo{league: models.League{ID: 1, Season: 2}}
+	standings, err := NewLeagueService(repo).ArchiveSeason(1)
+	if err != nil || standings.Season != 2 || len(standings.Standings) != 1 {
+		t.Fatalf("ArchiveSeason() = %v, %v; want season 2 archived", standings, err)
+	}
+
+	// Another request archived the season first
+	repo.archive = repository.ErrSeasonChanged
+	if _, err := NewLeagueService(repo).ArchiveSeason(1); !errors.Is(err, ErrSeasonConflict) {
+		t.Errorf("ArchiveSeason() of a season archived in the meantime error = %v, want ErrSeasonConflict", err)
+	}
+}
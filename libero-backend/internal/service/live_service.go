@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"libero-backend/internal/models"
+	"libero-backend/internal/provider"
+	"libero-backend/internal/repository"
+	"sync"
+	"time"
+)
+
+const (
+	// liveFastInterval is the polling interval while any match is in play
+	liveFastInterval = 30 * time.Second
+	// liveIdleInterval is the polling interval while no match is in play
+	liveIdleInterval = 5 * time.Minute
+	// liveKickoffGrace keeps polling quickly for matches past kickoff that the provider hasn't started yet
+	liveKickoffGrace = 15 * time.Minute
+	// liveRetryInterval is the polling interval after a failed poll
+	liveRetryInterval = 1 * time.Minute
+	// liveSubscriberBuffer is how many events a subscriber may lag behind before it is dropped
+	liveSubscriberBuffer = 32
+)
+
+// liveCompetitions are the competitions whose matches are polled
+var liveCompetitions = []string{"PL", "PD", "SA", "BL1", "FL1", "CL", "EL"}
+
+// LiveService defines the interface for live match polling and event subscriptions
+type LiveService interface {
+	Poll(ctx context.Context) (time.Duration, error)
+	Subscribe(filter models.LiveEventFilter) (<-chan models.LiveEvent, func())
+	Close()
+}
+
+// liveSnapshot is the last seen state of a match
+type liveSnapshot struct {
+	status    string
+	homeScore *int
+	awayScore *int
+}
+
+// liveSubscriber is a single event subscription
+type liveSubscriber struct {
+	filter models.LiveEventFilter
+	events chan models.LiveEvent
+}
+
+// liveService implements the LiveService interface
+type liveService struct {
+	footballService *FootballService
+	matchRepo       repository.MatchRepository
+	cache           CacheService
+	realtime        RealtimeService
+
+	snapshots map[int]liveSnapshot
+
+	mutex       sync.Mutex
+	subscribers map[*liveSubscriber]struct{}
+	nextEventID uint64
+	closed      bool
+}
+
+// NewLiveService creates a new live service instance
+func NewLiveService(footballService *FootballService, matchRepo repository.MatchRepository, cache CacheService, realtime RealtimeService) LiveService {
+	return &liveService{
+		footballService: footballService,
+		matchRepo:       matchRepo,
+		cache:           cache,
+		realtime:        realtime,
+		snapshots:       make(map[int]liveSnapshot),
+		subscribers:     make(map[*liveSubscriber]struct{}),
+	}
+}
+
+// Poll fetches the current state of recent matches, publishes events for everything that changed
+// since the previous poll and returns how long to wait before polling again.
+func (s *liveService) Poll(ctx context.Context) (time.Duration, error) {
+	now := time.Now().UTC()
+	// Include yesterday so matches running past midnight UTC are still followed
+	dateFrom := now.AddDate(0, 0, -1).Format("2006-01-02")
+	dateTo := now.Format("2006-01-02")
+
+	// Polls change between nearly every request, so their responses aren't kept for revalidation
+	matches, err := s.footballService.GetMatchesByDate(provider.WithoutStore(ctx), dateFrom, dateTo, liveCompetitions)
+	if err != nil {
+		return liveRetryInterval, err
+	}
+
+	// Only the poller touches snapshots, so they don't need the subscriber mutex
+	previous := s.snapshots
+	s.snapshots = make(map[int]liveSnapshot, len(matches))
+	finishedCompetitions := make(map[string]bool)
+
+	for _, match := range matches {
+		// Shootout penalties aren't goals, so they don't raise goal events
+		result := match.Score.Result()
+		current := liveSnapshot{
+			status:    match.Status,
+			homeScore: result.Home,
+			awayScore: result.Away,
+		}
+		s.snapshots[match.ID] = current
+
+		// The first sighting of a match is only a baseline
+		before, seen := previous[match.ID]
+		if !seen {
+			continue
+		}
+		events := diffSnapshots(match, before, current, now)
+		if len(events) == 0 {
+			continue
+		}
+
+		if err := s.matchRepo.UpdateLiveState(match.ID, current.status, current.homeScore, current.awayScore); err != nil {
+			fmt.Printf("[ERROR] Failed to store live state of match %d: %v\n", match.ID, err)
+		}
+		for _, event := range events {
+			s.publish(event)
+			s.realtime.Publish(models.MatchTopic(event.MatchID), event.Type, event)
+			if event.Type == models.LiveEventFullTime {
+				finishedCompetitions[event.CompetitionCode] = true
+			}
+		}
+	}
+
+	// A finished match moves the table, so push the rows that changed
+	for code := range finishedCompetitions {
+		s.publishStandings(ctx, code)
+	}
+
+	return nextPollInterval(matches, now), nil
+}
+
+// Subscribe registers a subscriber for events passing the filter. The returned function
+// unsubscribes; the channel is closed when the subscription ends, including when the
+// subscriber falls too far behind.
+func (s *liveService) Subscribe(filter models.LiveEventFilter) (<-chan models.LiveEvent, func()) {
+	subscriber := &liveSubscriber{
+		filter: filter,
+		events: make(chan models.LiveEvent, liveSubscriberBuffer),
+	}
+
+	s.mutex.Lock()
+	if s.closed {
+		close(subscriber.events)
+	} else {
+		s.subscribers[subscriber] = struct{}{}
+	}
+	s.mutex.Unlock()
+
+	unsubscribe := func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.removeSubscriber(subscriber)
+	}
+	return subscriber.events, unsubscribe
+}
+
+// Close ends all subscriptions, e.g. on server shutdown
+func (s *liveService) Close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closed = true
+	for subscriber := range s.subscribers {
+		s.removeSubscriber(subscriber)
+	}
+}
+
+// publish sends an event to every matching subscriber. Subscribers whose buffer is full are
+// dropped rather than blocking the poller; clients are expected to reconnect.
+func (s *liveService) publish(event models.LiveEvent) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.nextEventID++
+	event.ID = s.nextEventID
+
+	for subscriber := range s.subscribers {
+		if !subscriber.filter.Matches(event) {
+			continue
+		}
+		select {
+		case subscriber.events <- event:
+		default:
+			fmt.Printf("[WARN] Dropping slow live event subscriber\n")
+			s.removeSubscriber(subscriber)
+		}
+	}
+}
+
+// publishStandings refreshes a competition's cached standings of every type and publishes the
+// rows of the total table that changed to the competition's standings topic. When the refresh
+// fails, the cached tables are left to be served as they are.
+func (s *liveService) publishStandings(ctx context.Context, competitionCode string) {
+	key := StandingsCacheKey(competitionCode, models.StandingsTypeTotal, 0)
+	var before models.CompetitionStandingsDTO
+	if entry, err := s.cache.Get(CacheStandings, key); err == nil {
+		_ = json.Unmarshal(entry.Value, &before)
+	}
+
+	standings, err := s.footballService.GetStandingsByType(ctx, competitionCode, 0)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to refresh standings for %s: %v\n", competitionCode, err)
+		return
+	}
+	after := standings[models.StandingsTypeTotal]
+	if len(after.Standings) == 0 {
+		return
+	}
+	for standingsType, table := range standings {
+		if len(table.Standings) > 0 {
+			_, _ = s.cache.Store(CacheStandings, StandingsCacheKey(competitionCode, standingsType, 0), table)
+		}
+	}
+
+	rows := changedStandingsRows(before.Standings, after.Standings)
+	if len(rows) == 0 {
+		return
+	}
+	s.realtime.Publish(models.StandingsTopic(competitionCode), models.RealtimeEventStandingsUpdated, models.StandingsDelta{
+		CompetitionCode: competitionCode,
+		Rows:            rows,
+	})
+}
+
+// removeSubscriber removes and closes a subscription. The caller must hold the mutex.
+func (s *liveService) removeSubscriber(subscriber *liveSubscriber) {
+	if _, ok := s.subscribers[subscriber]; !ok {
+		return
+	}
+	delete(s.subscribers, subscriber)
+	close(subscriber.events)
+}
+
+// diffSnapshots returns the events describing how a match changed between two snapshots
+func diffSnapshots(match models.MatchResponse, before, after liveSnapshot, now time.Time) []models.LiveEvent {
+	base := models.LiveEvent{
+		MatchID:         match.ID,
+		CompetitionCode: match.Competition.Code,
+		HomeTeamID:      match.HomeTeam.ID,
+		HomeTeam:        match.HomeTeam.Name,
+		AwayTeamID:      match.AwayTeam.ID,
+		AwayTeam:        match.AwayTeam.Name,
+		Status:          after.status,
+		HomeScore:       after.homeScore,
+		AwayScore:       after.awayScore,
+		OccurredAt:      now,
+	}
+
+	var events []models.LiveEvent
+	homeBefore, homeAfter := scoreValue(before.homeScore), scoreValue(after.homeScore)
+	awayBefore, awayAfter := scoreValue(before.awayScore), scoreValue(after.awayScore)
+	if homeAfter > homeBefore {
+		event := base
+		event.Type = models.LiveEventGoal
+		event.ScoringSide = "HOME"
+		events = append(events, event)
+	}
+	if awayAfter > awayBefore {
+		event := base
+		event.Type = models.LiveEventGoal
+		event.ScoringSide = "AWAY"
+		events = append(events, event)
+	}
+	if homeAfter < homeBefore || awayAfter < awayBefore {
+		// Goals can be disallowed after the fact
+		event := base
+		event.Type = models.LiveEventScoreCorrection
+		events = append(events, event)
+	}
+
+	if after.status != before.status {
+		event := base
+		event.PreviousStatus = before.status
+		event.Type = models.LiveEventStatusChange
+		if isFinishedStatus(after.status) {
+			event.Type = models.LiveEventFullTime
+		}
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// nextPollInterval polls quickly while any match is in play or about to start, and otherwise
+// waits until the next kickoff or the idle interval, whichever comes first
+func nextPollInterval(matches []models.MatchResponse, now time.Time) time.Duration {
+	interval := liveIdleInterval
+	for _, match := range matches {
+		switch match.Status {
+		case "IN_PLAY", "PAUSED":
+			return liveFastInterval
+		case "SCHEDULED", "TIMED":
+			untilKickoff := match.UtcDate.Sub(now)
+			if untilKickoff <= 0 && untilKickoff > -liveKickoffGrace {
+				return liveFastInterval
+			}
+			if untilKickoff > 0 && untilKickoff < interval {
+				interval = untilKickoff
+			}
+		}
+	}
+	if interval < liveFastInterval {
+		interval = liveFastInterval
+	}
+	return interval
+}
+
+// changedStandingsRows returns the rows of after that are new or differ from before, matched by team
+func changedStandingsRows(before, after []models.StandingsTableDTO) []models.StandingsTableDTO {
+	previous := make(map[string]models.StandingsTableDTO, len(before))
+	for _, row := range before {
+		previous[standingsRowKey(row)] = row
+	}
+
+	changed := make([]models.StandingsTableDTO, 0)
+	for _, row := range after {
+		if old, ok := previous[standingsRowKey(row)]; !ok || old != row {
+			changed = append(changed, row)
+		}
+	}
+	return changed
+}
+
+// standingsRowKey identifies a standings row by provider team ID, falling back to the team name
+func standingsRowKey(row models.StandingsTableDTO) string {
+	if row.TeamID != 0 {
+		return fmt.Sprintf("%d", row.TeamID)
+	}
+	return row.TeamName
+}
+
+// scoreValue treats a missing score as zero
+func scoreValue(score *int) int {
+	if score == nil {
+		return 0
+	}
+	return *score
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"libero-backend/internal/models"
+	"libero-backend/internal/repository"
+)
+
+// MatchService defines the interface for querying stored matches
+type MatchService interface {
+	GetMatches(filter models.MatchFilter, page, limit int) ([]models.Match, int64, error)
+}
+
+// matchService implements the MatchService interface
+type matchService struct {
+	matchRepo repository.MatchRepository
+}
+
+// NewMatchService creates a new match service instance
+func NewMatchService(matchRepo repository.MatchRepository) MatchService {
+	return &matchService{
+		matchRepo: matchRepo,
+	}
+}
+
+// GetMatches retrieves stored matches matching the filter with pagination
+func (s *matchService) GetMatches(filter models.MatchFilter, page, limit int) ([]models.Match, int64, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, 0, ErrInvalidInput
+	}
+	return s.matchRepo.Find(filter, page, limit)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"libero-backend/internal/models"
+	"libero-backend/internal/repository"
+	"time"
+)
+
+// Error definitions for pick service
+var (
+	ErrPickLocked    = errors.New("picks are locked for this match")
+	ErrInvalidPeriod = errors.New("invalid leaderboard period")
+)
+
+// maxLeaderboardEntries caps the number of users returned on a leaderboard
+const maxLeaderboardEntries = 100
+
+// PickService defines the interface for user picks and leaderboards
+type PickService interface {
+	SubmitPick(ctx context.Context, userID uint, request *models.SubmitPickRequest) (*models.UserPick, error)
+	GetUserPicks(userID uint, status string, page, limit int) ([]models.UserPick, int64, error)
+	GetLeaderboard(period string, limit int) (*models.Leaderboard, error)
+	CompareWithModel(userID uint) (*models.PickComparisonSummary, error)
+}
+
+// pickService implements the PickService interface
+type pickService struct {
+	pickRepo        repository.UserPickRepository
+	fixturesService FixturesService
+}
+
+// NewPickService creates a new pick service instance
+func NewPickService(pickRepo repository.UserPickRepository, fixturesService FixturesService) PickService {
+	return &pickService{
+		pickRepo:        pickRepo,
+		fixturesService: fixturesService,
+	}
+}
+
+// SubmitPick stores the user's scoreline pick for a match. Picks can be changed until kickoff,
+// after which the match is locked.
+func (s *pickService) SubmitPick(ctx context.Context, userID uint, request *models.SubmitPickRequest) (*models.UserPick, error) {
+	if request.MatchID <= 0 || request.HomeScore < 0 || request.AwayScore < 0 {
+		return nil, ErrInvalidInput
+	}
+
+	// Look up the fixture to get its authoritative kickoff time
+	match, err := s.fixturesService.GetMatch(ctx, request.MatchID)
+	if err != nil {
+		return nil, err
+	}
+	if !isPickable(match, time.Now().UTC()) {
+		return nil, ErrPickLocked
+	}
+
+	pick := &models.UserPick{
+		UserID:             userID,
+		MatchID:            request.MatchID,
+		HomeTeam:           match.HomeTeamName,
+		AwayTeam:           match.AwayTeamName,
+		KickoffAt:          match.MatchDate,
+		PredictedHomeScore: request.HomeScore,
+		PredictedAwayScore: request.AwayScore,
+	}
+	if err := s.pickRepo.Upsert(pick); err != nil {
+		return nil, err
+	}
+
+	// Reload so the response carries the stored ID and timestamps on updates too
+	return s.pickRepo.FindByUserAndMatch(userID, request.MatchID)
+}
+
+// GetUserPicks retrieves picks for a specific user with pagination, optionally filtered by status
+func (s *pickService) GetUserPicks(userID uint, status string, page, limit int) ([]models.UserPick, int64, error) {
+	return s.pickRepo.FindByUserID(userID, status, page, limit)
+}
+
+// GetLeaderboard ranks users by pick points for the current week, month or season
+func (s *pickService) GetLeaderboard(period string, limit int) (*models.Leaderboard, error) {
+	from, to, err := leaderboardWindow(period, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > maxLeaderboardEntries {
+		limit = maxLeaderboardEntries
+	}
+
+	entries, err := s.pickRepo.GetLeaderboard(from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load leaderboard: %w", err)
+	}
+	if entries == nil {
+		entries = []models.LeaderboardEntry{}
+	}
+
+	return &models.Leaderboard{
+		Period:  period,
+		From:    from,
+		To:      to,
+		Entries: entries,
+	}, nil
+}
+
+// CompareWithModel scores the model's predictions with the same rules as the user's picks
+// for every settled match the user picked
+func (s *pickService) CompareWithModel(userID uint) (*models.PickComparisonSummary, error) {
+	rows, err := s.pickRepo.FindComparisons(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pick comparisons: %w", err)
+	}
+
+	summary := &models.PickComparisonSummary{
+		Comparisons: make([]models.PickComparison, 0, len(rows)),
+	}
+	for _, row := range rows {
+		userPoints := pickPoints(row.PredictedHomeScore, row.PredictedAwayScore, row.ActualHomeScore, row.ActualAwayScore)
+		modelPoints := pickPoints(row.ModelHomeScore, row.ModelAwayScore, row.ActualHomeScore, row.ActualAwayScore)
+
+		summary.Matches++
+		summary.UserPoints += userPoints
+		summary.ModelPoints += modelPoints
+		switch {
+		case userPoints > modelPoints:
+			summary.UserBetter++
+		case userPoints < modelPoints:
+			summary.ModelBetter++
+		default:
+			summary.Level++
+		}
+
+		summary.Comparisons = append(summary.Comparisons, models.PickComparison{
+			MatchID:         row.MatchID,
+			HomeTeam:        row.HomeTeam,
+			AwayTeam:        row.AwayTeam,
+			KickoffAt:       row.KickoffAt,
+			ActualHomeScore: row.ActualHomeScore,
+			ActualAwayScore: row.ActualAwayScore,
+			UserHomeScore:   row.PredictedHomeScore,
+			UserAwayScore:   row.PredictedAwayScore,
+			UserPoints:      userPoints,
+			ModelHomeScore:  row.ModelHomeScore,
+			ModelAwayScore:  row.ModelAwayScore,
+			ModelPoints:     modelPoints,
+		})
+	}
+
+	return summary, nil
+}
+
+// isPickable reports whether a match still accepts picks: it must be scheduled and not yet kicked off
+func isPickable(match *models.FixtureMatchDTO, now time.Time) bool {
+	if match.MatchDate.IsZero() || !now.Before(match.MatchDate) {
+		return false
+	}
+	return match.MatchStatus == "SCHEDULED" || match.MatchStatus == "TIMED"
+}
+
+// pickPoints awards points for a scoreline against the final score
+func pickPoints(predictedHome, predictedAway, actualHome, actualAway int) int {
+	if predictedHome == actualHome && predictedAway == actualAway {
+		return models.PickPointsExactScore
+	}
+	if outcomeOf(predictedHome, predictedAway) == outcomeOf(actualHome, actualAway) {
+		return models.PickPointsCorrectOutcome
+	}
+	return 0
+}
+
+// leaderboardWindow returns the [from, to) range for a leaderboard period containing now.
+// Weeks start on Monday and seasons on the 1st of July (UTC).
+func leaderboardWindow(period string, now time.Time) (time.Time, time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case models.LeaderboardWeekly:
+		offset := (int(today.Weekday()) + 6) % 7 // days since Monday
+		from := today.AddDate(0, 0, -offset)
+		return from, from.AddDate(0, 0, 7), nil
+	case models.LeaderboardMonthly:
+		from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return from, from.AddDate(0, 1, 0), nil
+	case models.LeaderboardSeason:
+		year := now.Year()
+		if now.Month() < time.July {
+			year--
+		}
+		from := time.Date(year, time.July, 1, 0, 0, 0, 0, time.UTC)
+		return from, from.AddDate(1, 0, 0), nil
+	}
+	return time.Time{}, time.Time{}, ErrInvalidPeriod
+}
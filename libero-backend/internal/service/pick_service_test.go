@@ -0,0 +1,42 @@
+package service
+
+import (
+	"errors"
+	"libero-backend/internal/models"
+	"testing"
+	"time"
+)
+
+func TestLeaderboardWindow(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		period   string
+		now      time.Time
+		from, to time.Time
+	}{
+		{"weekly on Sunday night", models.LeaderboardWeekly, time.Date(2025, time.March, 2, 23, 59, 0, 0, time.UTC), day(2025, time.February, 24), day(2025, time.March, 3)},
+		{"weekly on Monday", models.LeaderboardWeekly, day(2025, time.March, 3), day(2025, time.March, 3), day(2025, time.March, 10)},
+		{"weekly across the year", models.LeaderboardWeekly, day(2025, time.January, 1), day(2024, time.December, 30), day(2025, time.January, 6)},
+		{"monthly on the first", models.LeaderboardMonthly, day(2025, time.March, 1), day(2025, time.March, 1), day(2025, time.April, 1)},
+		{"monthly on the last day", models.LeaderboardMonthly, time.Date(2025, time.February, 28, 23, 59, 0, 0, time.UTC), day(2025, time.February, 1), day(2025, time.March, 1)},
+		{"monthly in December", models.LeaderboardMonthly, day(2024, time.December, 31), day(2024, time.December, 1), day(2025, time.January, 1)},
+		{"season before July 1", models.LeaderboardSeason, time.Date(2025, time.June, 30, 23, 59, 0, 0, time.UTC), day(2024, time.July, 1), day(2025, time.July, 1)},
+		{"season on July 1", models.LeaderboardSeason, day(2025, time.July, 1), day(2025, time.July, 1), day(2026, time.July, 1)},
+		{"season in January", models.LeaderboardSeason, day(2025, time.January, 15), day(2024, time.July, 1), day(2025, time.July, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := leaderboardWindow(tt.period, tt.now)
+			if err != nil || !from.Equal(tt.from) || !to.Equal(tt.to) {
+				t.Errorf("leaderboardWindow(%q, %v) = %v, %v, %v; want %v, %v", tt.period, tt.now, from, to, err, tt.from, tt.to)
+			}
+		})
+	}
+
+	if _, _, err := leaderboardWindow("daily", day(2025, time.March, 1)); !errors.Is(err, ErrInvalidPeriod) {
+		t.Errorf("leaderboardWindow(\"daily\") error = %v, want %v", err, ErrInvalidPeriod)
+	}
+}
@@ -14,10 +14,12 @@ var (
 // PredictionHistoryService defines the interface for prediction history business logic
 type PredictionHistoryService interface {
 	CreatePrediction(userID uint, request *models.CreatePredictionRequest) (*models.PredictionHistory, error)
-	GetUserPredictions(userID uint, page, limit int) ([]models.PredictionHistory, int64, error)
+	GetUserPredictions(userID uint, status string, page, limit int) ([]models.PredictionHistory, int64, error)
+	CountUserPredictionsByStatus(userID uint) (settled int64, pending int64, err error)
 	DeletePrediction(predictionID, userID uint) error
 	DeleteAllUserPredictions(userID uint) error
 	GetUserStatistics(userID uint) (*models.PredictionStatistics, error)
+	GetUserAccuracy(userID uint, filter models.PredictionAccuracyFilter) (*models.PredictionAccuracyStatistics, error)
 }
 
 // predictionHistoryService implements the PredictionHistoryService interface
@@ -59,6 +61,11 @@ func (s *predictionHistoryService) CreatePrediction(userID uint, request *models
 		DrawProbability:    request.DrawProbability,
 		AwayWinProbability: request.AwayWinProbability,
 		PredictedResult:    request.PredictedResult,
+		KickoffAt:          request.KickoffAt,
+	}
+	if request.MatchID > 0 {
+		matchID := request.MatchID
+		prediction.MatchID = &matchID
 	}
 
 	// Save to database
@@ -69,9 +76,14 @@ func (s *predictionHistoryService) CreatePrediction(userID uint, request *models
 	return prediction, nil
 }
 
-// GetUserPredictions retrieves predictions for a specific user with pagination
-func (s *predictionHistoryService) GetUserPredictions(userID uint, page, limit int) ([]models.PredictionHistory, int64, error) {
-	return s.predictionRepo.FindByUserID(userID, page, limit)
+// GetUserPredictions retrieves predictions for a specific user with pagination, optionally filtered by status
+func (s *predictionHistoryService) GetUserPredictions(userID uint, status string, page, limit int) ([]models.PredictionHistory, int64, error) {
+	return s.predictionRepo.FindByUserID(userID, status, page, limit)
+}
+
+// CountUserPredictionsByStatus returns how many of a user's predictions are settled and pending
+func (s *predictionHistoryService) CountUserPredictionsByStatus(userID uint) (int64, int64, error) {
+	return s.predictionRepo.CountByStatus(userID)
 }
 
 // DeletePrediction removes a specific prediction (only if it belongs to the user)
@@ -88,3 +100,11 @@ func (s *predictionHistoryService) DeleteAllUserPredictions(userID uint) error {
 func (s *predictionHistoryService) GetUserStatistics(userID uint) (*models.PredictionStatistics, error) {
 	return s.predictionRepo.GetStatistics(userID)
 }
+
+// GetUserAccuracy returns accuracy and calibration statistics for a user's settled predictions
+func (s *predictionHistoryService) GetUserAccuracy(userID uint, filter models.PredictionAccuracyFilter) (*models.PredictionAccuracyStatistics, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, ErrInvalidInput
+	}
+	return s.predictionRepo.GetAccuracyStatistics(userID, filter)
+}
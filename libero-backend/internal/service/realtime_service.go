@@ -0,0 +1,216 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"libero-backend/internal/models"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// realtimeClientBuffer is how many messages a client may lag behind before it is dropped
+	realtimeClientBuffer = 64
+	// realtimeMaxTopics caps the number of topics a single client may subscribe to
+	realtimeMaxTopics = 50
+)
+
+var (
+	ErrInvalidTopic   = errors.New("invalid topic")
+	ErrTopicForbidden = errors.New("topic belongs to another user")
+	ErrTooManyTopics  = errors.New("too many subscriptions")
+)
+
+// competitionCodePattern matches provider competition codes such as PL or BL1
+var competitionCodePattern = regexp.MustCompile(`^[A-Z0-9]{2,5}$`)
+
+// RealtimeService defines the interface for the topic hub behind the WebSocket channel
+type RealtimeService interface {
+	// Connect registers a new client. The client receives nothing until it subscribes.
+	Connect() *RealtimeClient
+	// Publish sends an update to every client subscribed to the topic
+	Publish(topic, event string, data interface{})
+	// ResolveTopic validates a topic and returns its canonical form. userID is 0 for anonymous clients.
+	ResolveTopic(topic string, userID uint) (string, error)
+	// Close disconnects all clients, e.g. on server shutdown
+	Close()
+}
+
+// RealtimeClient is a single connection's set of subscriptions
+type RealtimeClient struct {
+	hub    *realtimeService
+	send   chan models.RealtimeMessage
+	topics map[string]struct{} // Guarded by the hub mutex
+}
+
+// realtimeService implements the RealtimeService interface
+type realtimeService struct {
+	mutex   sync.Mutex
+	clients map[*RealtimeClient]struct{}
+	topics  map[string]map[*RealtimeClient]struct{}
+	closed  bool
+}
+
+// NewRealtimeService creates a new realtime service instance
+func NewRealtimeService() RealtimeService {
+	return &realtimeService{
+		clients: make(map[*RealtimeClient]struct{}),
+		topics:  make(map[string]map[*RealtimeClient]struct{}),
+	}
+}
+
+// Connect registers a new client
+func (s *realtimeService) Connect() *RealtimeClient {
+	client := &RealtimeClient{
+		hub:    s,
+		send:   make(chan models.RealtimeMessage, realtimeClientBuffer),
+		topics: make(map[string]struct{}),
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		close(client.send)
+	} else {
+		s.clients[client] = struct{}{}
+	}
+	return client
+}
+
+// Publish sends an update to every client subscribed to the topic. Clients whose buffer is
+// full are dropped rather than blocking the publisher; they are expected to reconnect.
+func (s *realtimeService) Publish(topic, event string, data interface{}) {
+	message := models.RealtimeMessage{
+		Type:   models.RealtimeMessageUpdate,
+		Topic:  topic,
+		Event:  event,
+		Data:   data,
+		SentAt: time.Now().UTC(),
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for client := range s.topics[topic] {
+		select {
+		case client.send <- message:
+		default:
+			fmt.Printf("[WARN] Dropping slow realtime client\n")
+			s.removeClient(client)
+		}
+	}
+}
+
+// ResolveTopic validates a topic and returns its canonical form. Supported topics are
+// match:{id}, competition:{code}:standings and the private user:{id}:predictions.
+func (s *realtimeService) ResolveTopic(topic string, userID uint) (string, error) {
+	parts := strings.Split(strings.TrimSpace(topic), ":")
+
+	switch {
+	case len(parts) == 2 && parts[0] == "match":
+		matchID, err := strconv.Atoi(parts[1])
+		if err != nil || matchID <= 0 {
+			return "", ErrInvalidTopic
+		}
+		return models.MatchTopic(matchID), nil
+
+	case len(parts) == 3 && parts[0] == "competition" && parts[2] == "standings":
+		code := strings.ToUpper(parts[1])
+		if !competitionCodePattern.MatchString(code) {
+			return "", ErrInvalidTopic
+		}
+		return models.StandingsTopic(code), nil
+
+	case len(parts) == 3 && parts[0] == "user" && parts[2] == "predictions":
+		topicUserID, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil || topicUserID == 0 {
+			return "", ErrInvalidTopic
+		}
+		if uint(topicUserID) != userID {
+			return "", ErrTopicForbidden
+		}
+		return models.UserPredictionsTopic(userID), nil
+	}
+
+	return "", ErrInvalidTopic
+}
+
+// Close disconnects all clients
+func (s *realtimeService) Close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closed = true
+	for client := range s.clients {
+		s.removeClient(client)
+	}
+}
+
+// removeClient removes a client from all its topics and closes its channel. The caller must hold the mutex.
+func (s *realtimeService) removeClient(client *RealtimeClient) {
+	if _, ok := s.clients[client]; !ok {
+		return
+	}
+	for topic := range client.topics {
+		s.removeFromTopic(client, topic)
+	}
+	delete(s.clients, client)
+	close(client.send)
+}
+
+// removeFromTopic unsubscribes a client from a single topic. The caller must hold the mutex.
+func (s *realtimeService) removeFromTopic(client *RealtimeClient, topic string) {
+	delete(client.topics, topic)
+	if subscribers, ok := s.topics[topic]; ok {
+		delete(subscribers, client)
+		if len(subscribers) == 0 {
+			delete(s.topics, topic)
+		}
+	}
+}
+
+// Messages returns the client's outgoing messages. The channel is closed when the client is
+// disconnected by the hub, either because it fell too far behind or on shutdown.
+func (c *RealtimeClient) Messages() <-chan models.RealtimeMessage {
+	return c.send
+}
+
+// Subscribe adds a resolved topic to the client's subscriptions
+func (c *RealtimeClient) Subscribe(topic string) error {
+	c.hub.mutex.Lock()
+	defer c.hub.mutex.Unlock()
+
+	if _, ok := c.hub.clients[c]; !ok {
+		return nil // Already disconnected; the closed channel ends the connection
+	}
+	if _, ok := c.topics[topic]; ok {
+		return nil
+	}
+	if len(c.topics) >= realtimeMaxTopics {
+		return ErrTooManyTopics
+	}
+
+	c.topics[topic] = struct{}{}
+	if c.hub.topics[topic] == nil {
+		c.hub.topics[topic] = make(map[*RealtimeClient]struct{})
+	}
+	c.hub.topics[topic][c] = struct{}{}
+	return nil
+}
+
+// Unsubscribe removes a topic from the client's subscriptions
+func (c *RealtimeClient) Unsubscribe(topic string) {
+	c.hub.mutex.Lock()
+	defer c.hub.mutex.Unlock()
+	c.hub.removeFromTopic(c, topic)
+}
+
+// Close disconnects the client from the hub
+func (c *RealtimeClient) Close() {
+	c.hub.mutex.Lock()
+	defer c.hub.mutex.Unlock()
+	c.hub.removeClient(c)
+}
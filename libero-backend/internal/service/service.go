@@ -1,8 +1,15 @@
 package service
 
 import (
+	"fmt"
 	"libero-backend/config"
+	"libero-backend/internal/demodata"
+	"libero-backend/internal/fakeprovider"
+	"libero-backend/internal/provider"
 	"libero-backend/internal/repository"
+	"log"
+
+	"github.com/redis/go-redis/v9"
 )
 
 // Service provides access to all service operations
@@ -13,21 +20,37 @@ type Service struct {
 	ML                MLService
 	Fixtures          FixturesService
 	Football          *FootballService // Add Football service
+	Bracket           BracketService
+	StandingsHistory  StandingsHistoryService
 	PredictionHistory PredictionHistoryService
+	Settlement        SettlementService
+	Pick              PickService
+	League            LeagueService
+	Match             MatchService
+	Catalog           CatalogService
+	Feed              FeedService
+	Live              LiveService
+	Realtime          RealtimeService
+	Cache             CacheService
 }
 
-// New creates a new service instance with all services
-func New(repo *repository.Repository) *Service {
+// New creates a new service instance with all services. redisClient is only needed when provider
+// rate limits are kept in Redis.
+func New(repo *repository.Repository, redisClient *redis.Client) *Service {
 	// Load configuration (including ThirdPartyAPIKey & BaseURL)
 	cfg := config.New()
 
 	// Initialize services in dependency order
 	userService := NewUserService(repo.User, cfg)
-	authService := NewAuthService(userService, cfg.JWT) // AuthService depends on UserService
-	oauthService := NewOAuthService(cfg, authService)   // OAuthService depends on Config and AuthService
-	mlService := NewMLService(cfg)                      // MLService depends on Config
-	fixturesService := NewFixturesService(cfg.ThirdPartyAPIKey, cfg.ThirdPartyBaseURL, repo.Cache)
-	footballService := NewFootballService(cfg.ThirdPartyBaseURL, cfg.ThirdPartyAPIKey) // Initialize with API config
+	authService := NewAuthService(userService, cfg.JWT)              // AuthService depends on UserService
+	oauthService := NewOAuthService(cfg, authService)                // OAuthService depends on Config and AuthService
+	mlService := NewMLService(cfg)                                   // MLService depends on Config
+	cacheService := NewCacheService(repo.Cache, cacheKeyPrefix(cfg)) // Single cache for sports data, feeds and provider responses
+	dataProvider := newDataProvider(cfg, cacheService.ResponseStore(), redisClient)
+	fixturesService := NewFixturesService(dataProvider, repo.Match)
+	footballService := NewFootballService(dataProvider, repo.Match)                                                    // Initialize with API config
+	realtimeService := NewRealtimeService()                                                                            // Topic hub behind the WebSocket channel
+	settlementService := NewSettlementService(repo.PredictionHistory, repo.UserPick, footballService, realtimeService) // Settles predictions via the football API
 
 	return &Service{
 		User:              userService,
@@ -36,6 +59,89 @@ func New(repo *repository.Repository) *Service {
 		ML:                mlService,
 		Fixtures:          fixturesService,
 		Football:          footballService, // Add to returned service
+		Bracket:           NewBracketService(footballService),
+		StandingsHistory:  NewStandingsHistoryService(repo.Match, footballService),
 		PredictionHistory: NewPredictionHistoryService(repo.PredictionHistory),
+		Settlement:        settlementService,
+		Pick:              NewPickService(repo.UserPick, fixturesService),
+		League:            NewLeagueService(repo.League),
+		Match:             NewMatchService(repo.Match),
+		Catalog:           NewCatalogService(repo.Competition, repo.Team, repo.Player, repo.User, footballService),
+		Feed:              NewFeedService(repo.User, repo.Team, repo.Match, repo.PredictionHistory, cacheService, footballService),
+		Live:              NewLiveService(footballService, repo.Match, cacheService, realtimeService),
+		Realtime:          realtimeService,
+		Cache:             cacheService,
+	}
+}
+
+// newDataProvider builds the provider router. There is one adapter per provider for the whole process,
+// so every caller shares its rate limiter. The providers count requests per rolling minute, so requests
+// are evenly spaced rather than burst. In demo mode the demo provider serves everything instead.
+func newDataProvider(cfg *config.Config, responseStore provider.ResponseStore, redisClient *redis.Client) provider.FootballDataProvider {
+	if cfg.DemoMode {
+		demo, err := demodata.NewProvider()
+		if err != nil {
+			log.Fatalf("Failed to load demo data: %v", err)
+		}
+		fmt.Printf("[WARN] Demo mode: serving generated demo data instead of a football data provider\n")
+		return demo
+	}
+
+	baseURL := cfg.ThirdPartyBaseURL
+	if cfg.FakeProvider != "" {
+		baseURL = startFakeProvider(cfg.FakeProvider)
+	}
+	providers := []provider.FootballDataProvider{
+		provider.NewFootballData(baseURL, cfg.ThirdPartyAPIKey, newLimiter(cfg, redisClient, provider.FootballDataName, cfg.ThirdPartyRateLimit), responseStore),
+	}
+	if cfg.APIFootballAPIKey != "" {
+		providers = append(providers, provider.NewAPIFootball(cfg.APIFootballBaseURL, cfg.APIFootballAPIKey, newLimiter(cfg, redisClient, provider.APIFootballName, cfg.APIFootballRateLimit), responseStore))
+	}
+
+	routes, err := provider.ParseRoutes(cfg.ProviderRoutes)
+	if err != nil {
+		fmt.Printf("[WARN] Ignoring PROVIDER_ROUTES: %v\n", err)
+		routes = provider.DefaultRoutes
+	}
+	router, err := provider.NewRouter(providers, routes)
+	if err != nil {
+		fmt.Printf("[WARN] Falling back to default provider routes: %v\n", err)
+		router, err = provider.NewRouter(providers, provider.DefaultRoutes)
+		if err != nil {
+			log.Fatalf("Failed to set up data providers: %v", err)
+		}
+	}
+	return router
+}
+
+// cacheKeyPrefix keeps demo data apart from real data in the cache, so neither is served in the
+// other mode
+func cacheKeyPrefix(cfg *config.Config) string {
+	if cfg.DemoMode {
+		return "demo:"
+	}
+	return ""
+}
+
+// newLimiter creates the rate limiter of a provider: shared through Redis by every backend process
+// if configured, otherwise for this process alone
+func newLimiter(cfg *config.Config, redisClient *redis.Client, providerName string, requestsPerMinute int) provider.Limiter {
+	if cfg.RateLimitBackend == config.BackendRedis && redisClient != nil {
+		return provider.NewRedisTokenBucket(redisClient, providerName, requestsPerMinute, 1)
+	}
+	return provider.NewTokenBucket(requestsPerMinute, 1)
+}
+
+// startFakeProvider starts an in-process fake provider replaying the built-in fixtures and returns its base URL
+func startFakeProvider(scenarioName string) string {
+	scenario, err := fakeprovider.LoadScenario(scenarioName)
+	if err != nil {
+		log.Fatalf("Failed to load fake provider scenario: %v", err)
+	}
+	baseURL, _, err := fakeprovider.Start(fakeprovider.Options{Scenario: scenario, ShiftDates: true})
+	if err != nil {
+		log.Fatalf("Failed to start fake provider: %v", err)
 	}
+	fmt.Printf("[WARN] Using fake provider at %s with scenario %q\n", baseURL, scenario.Name)
+	return baseURL
 }
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"libero-backend/internal/models"
+	"libero-backend/internal/repository"
+	"math"
+	"time"
+)
+
+const (
+	// settlementBatchSize caps how many pending predictions are processed per run
+	settlementBatchSize = 200
+	// settlementMatchChunk caps how many match IDs are requested from the provider at once
+	settlementMatchChunk = 20
+	// logLossEpsilon keeps the log loss finite when the actual outcome was given zero probability
+	logLossEpsilon = 1e-15
+)
+
+// Match outcomes used when scoring predictions
+const (
+	outcomeHomeWin = "HOME_WIN"
+	outcomeDraw    = "DRAW"
+	outcomeAwayWin = "AWAY_WIN"
+)
+
+// SettlementService defines the interface for settling predictions and picks against final scores
+type SettlementService interface {
+	SettlePending(ctx context.Context) (int, error)
+}
+
+// settlementService implements the SettlementService interface
+type settlementService struct {
+	predictionRepo  repository.PredictionHistoryRepository
+	pickRepo        repository.UserPickRepository
+	footballService *FootballService
+	realtime        RealtimeService
+}
+
+// NewSettlementService creates a new settlement service instance
+func NewSettlementService(predictionRepo repository.PredictionHistoryRepository, pickRepo repository.UserPickRepository, footballService *FootballService, realtime RealtimeService) SettlementService {
+	return &settlementService{
+		predictionRepo:  predictionRepo,
+		pickRepo:        pickRepo,
+		footballService: footballService,
+		realtime:        realtime,
+	}
+}
+
+// SettlePending looks up the linked matches of all pending predictions and picks that have kicked off,
+// and records the actual score and scoring metrics for every match that has finished. Predictions
+// and picks of cancelled matches are voided; the rest, e.g. of postponed or suspended matches, are
+// marked as checked so they make way for others in the next batch.
+// It returns the number of predictions and picks settled.
+func (s *settlementService) SettlePending(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	predictions, err := s.predictionRepo.FindUnsettled(now, settlementBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load unsettled predictions: %w", err)
+	}
+	picks, err := s.pickRepo.FindUnsettled(now, settlementBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load unsettled picks: %w", err)
+	}
+	if len(predictions) == 0 && len(picks) == 0 {
+		return 0, nil
+	}
+
+	// Collect the distinct matches we need results for
+	seen := make(map[int]bool)
+	matchIDs := make([]int, 0, len(predictions)+len(picks))
+	addMatch := func(matchID int) {
+		if !seen[matchID] {
+			seen[matchID] = true
+			matchIDs = append(matchIDs, matchID)
+		}
+	}
+	for _, p := range predictions {
+		if p.MatchID != nil {
+			addMatch(*p.MatchID)
+		}
+	}
+	for _, p := range picks {
+		addMatch(p.MatchID)
+	}
+
+	finished, cancelled, err := s.fetchFinishedScores(ctx, matchIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	var uncheckedPredictions, uncheckedPicks []uint
+	for i := range predictions {
+		p := &predictions[i]
+		if cancelled[*p.MatchID] {
+			if err := s.predictionRepo.Void(p.ID, now); err != nil {
+				fmt.Printf("[ERROR] Failed to void prediction %d: %v\n", p.ID, err)
+			}
+			continue
+		}
+		score, ok := finished[*p.MatchID]
+		if !ok {
+			uncheckedPredictions = append(uncheckedPredictions, p.ID)
+			continue
+		}
+		settlePrediction(p, score[0], score[1], now)
+		if err := s.predictionRepo.Settle(p); err != nil {
+			fmt.Printf("[ERROR] Failed to settle prediction %d: %v\n", p.ID, err)
+			uncheckedPredictions = append(uncheckedPredictions, p.ID)
+			continue
+		}
+		s.realtime.Publish(models.UserPredictionsTopic(p.UserID), models.RealtimeEventPredictionSettled, p.ToResponse())
+		settled++
+	}
+	for i := range picks {
+		p := &picks[i]
+		if cancelled[p.MatchID] {
+			if err := s.pickRepo.Void(p.ID, now); err != nil {
+				fmt.Printf("[ERROR] Failed to void pick %d: %v\n", p.ID, err)
+			}
+			continue
+		}
+		score, ok := finished[p.MatchID]
+		if !ok {
+			uncheckedPicks = append(uncheckedPicks, p.ID)
+			continue
+		}
+		settlePick(p, score[0], score[1], now)
+		if err := s.pickRepo.Settle(p); err != nil {
+			fmt.Printf("[ERROR] Failed to settle pick %d: %v\n", p.ID, err)
+			uncheckedPicks = append(uncheckedPicks, p.ID)
+			continue
+		}
+		s.realtime.Publish(models.UserPredictionsTopic(p.UserID), models.RealtimeEventPickSettled, p.ToResponse())
+		settled++
+	}
+
+	if err := s.predictionRepo.MarkChecked(uncheckedPredictions, now); err != nil {
+		fmt.Printf("[ERROR] Failed to mark predictions as checked: %v\n", err)
+	}
+	if err := s.pickRepo.MarkChecked(uncheckedPicks, now); err != nil {
+		fmt.Printf("[ERROR] Failed to mark picks as checked: %v\n", err)
+	}
+	return settled, nil
+}
+
+// fetchFinishedScores returns the final home/away score of every given match that has finished,
+// and which of them were cancelled
+func (s *settlementService) fetchFinishedScores(ctx context.Context, matchIDs []int) (map[int][2]int, map[int]bool, error) {
+	finished := make(map[int][2]int)
+	cancelled := make(map[int]bool)
+	for start := 0; start < len(matchIDs); start += settlementMatchChunk {
+		end := start + settlementMatchChunk
+		if end > len(matchIDs) {
+			end = len(matchIDs)
+		}
+		matches, err := s.footballService.GetMatchesByIDs(ctx, matchIDs[start:end])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch match results: %w", err)
+		}
+		for _, m := range matches {
+			if m.Status == "CANCELLED" {
+				cancelled[m.ID] = true
+				continue
+			}
+			if !isFinishedStatus(m.Status) {
+				continue
+			}
+			if homeScore, awayScore, ok := finalScore(m); ok {
+				finished[m.ID] = [2]int{homeScore, awayScore}
+			}
+		}
+	}
+	return finished, cancelled, nil
+}
+
+// isFinishedStatus reports whether a provider match status means the result is final
+func isFinishedStatus(status string) bool {
+	return status == "FINISHED" || status == "AWARDED"
+}
+
+// finalScore returns the score after regular time. Predictions are made on the 90-minute
+// result, so extra time and penalties are ignored when the provider reports them.
+func finalScore(match models.MatchResponse) (int, int, bool) {
+	regular := match.Score.RegularTime
+	if match.Score.Duration != "" && match.Score.Duration != "REGULAR" && regular.Home != nil && regular.Away != nil {
+		return *regular.Home, *regular.Away, true
+	}
+	fullTime := match.Score.FullTime
+	if fullTime.Home == nil || fullTime.Away == nil {
+		return 0, 0, false
+	}
+	return *fullTime.Home, *fullTime.Away, true
+}
+
+// outcomeOf maps a scoreline to a match outcome
+func outcomeOf(homeScore, awayScore int) string {
+	switch {
+	case homeScore > awayScore:
+		return outcomeHomeWin
+	case homeScore < awayScore:
+		return outcomeAwayWin
+	default:
+		return outcomeDraw
+	}
+}
+
+// settlePrediction fills in the settlement fields of a prediction for the given final score
+func settlePrediction(p *models.PredictionHistory, homeScore, awayScore int, settledAt time.Time) {
+	actual := outcomeOf(homeScore, awayScore)
+	outcomeCorrect := outcomeOf(p.PredictedHomeScore, p.PredictedAwayScore) == actual
+	exactScore := p.PredictedHomeScore == homeScore && p.PredictedAwayScore == awayScore
+	brier, logLoss := probabilityScores(p.HomeWinProbability, p.DrawProbability, p.AwayWinProbability, actual)
+
+	p.ActualHomeScore = &homeScore
+	p.ActualAwayScore = &awayScore
+	p.OutcomeCorrect = &outcomeCorrect
+	p.ExactScore = &exactScore
+	p.BrierScore = &brier
+	p.LogLoss = &logLoss
+	p.SettledAt = &settledAt
+}
+
+// settlePick fills in the settlement fields of a pick for the given final score
+func settlePick(p *models.UserPick, homeScore, awayScore int, settledAt time.Time) {
+	points := pickPoints(p.PredictedHomeScore, p.PredictedAwayScore, homeScore, awayScore)
+
+	p.ActualHomeScore = &homeScore
+	p.ActualAwayScore = &awayScore
+	p.Points = &points
+	p.SettledAt = &settledAt
+}
+
+// probabilityScores computes the multi-class Brier score and the log loss of the
+// home/draw/away probabilities against the actual outcome
+func probabilityScores(home, draw, away float64, actual string) (float64, float64) {
+	observed := map[string]float64{outcomeHomeWin: 0, outcomeDraw: 0, outcomeAwayWin: 0}
+	observed[actual] = 1
+
+	brier := math.Pow(home-observed[outcomeHomeWin], 2) +
+		math.Pow(draw-observed[outcomeDraw], 2) +
+		math.Pow(away-observed[outcomeAwayWin], 2)
+
+	var actualProbability float64
+	switch actual {
+	case outcomeHomeWin:
+		actualProbability = home
+	case outcomeDraw:
+		actualProbability = draw
+	default:
+		actualProbability = away
+	}
+	logLoss := -math.Log(math.Max(actualProbability, logLossEpsilon))
+
+	return brier, logLoss
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"libero-backend/internal/models"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFinalScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		score     models.MatchScoreResponse
+		wantHome  int
+		wantAway  int
+		wantFinal bool
+	}{
+		{
+			name:      "regular time",
+			score:     models.MatchScoreResponse{Duration: "REGULAR", FullTime: models.ScoreResponse{Home: intPtr(2), Away: intPtr(1)}},
+			wantHome:  2,
+			wantAway:  1,
+			wantFinal: true,
+		},
+		{
+			name:      "no duration reported",
+			score:     models.MatchScoreResponse{FullTime: models.ScoreResponse{Home: intPtr(0), Away: intPtr(0)}},
+			wantHome:  0,
+			wantAway:  0,
+			wantFinal: true,
+		},
+		{
+			name: "extra time counts the 90-minute score",
+			score: models.MatchScoreResponse{
+				Duration:    "EXTRA_TIME",
+				FullTime:    models.ScoreResponse{Home: intPtr(2), Away: intPtr(1)},
+				RegularTime: models.ScoreResponse{Home: intPtr(1), Away: intPtr(1)},
+			},
+			wantHome:  1,
+			wantAway:  1,
+			wantFinal: true,
+		},
+		{
+			name: "penalty shootout counts the 90-minute score",
+			score: models.MatchScoreResponse{
+				Duration:    "PENALTY_SHOOTOUT",
+				FullTime:    models.ScoreResponse{Home: intPtr(6), Away: intPtr(5)},
+				RegularTime: models.ScoreResponse{Home: intPtr(2), Away: intPtr(2)},
+				Penalties:   models.ScoreResponse{Home: intPtr(4), Away: intPtr(3)},
+			},
+			wantHome:  2,
+			wantAway:  2,
+			wantFinal: true,
+		},
+		{
+			name:  "no score yet",
+			score: models.MatchScoreResponse{Duration: "REGULAR"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home, away, ok := finalScore(models.MatchResponse{Score: tt.score})
+			if ok != tt.wantFinal || home != tt.wantHome || away != tt.wantAway {
+				t.Errorf("finalScore() = %d, %d, %v; want %d, %d, %v", home, away, ok, tt.wantHome, tt.wantAway, tt.wantFinal)
+			}
+		})
+	}
+}
+
+func TestProbabilityScores(t *testing.T) {
+	tests := []struct {
+		name             string
+		home, draw, away float64
+		actual           string
+		wantBrier        float64
+		wantLogLoss      float64
+	}{
+		{name: "certain and right", home: 1, draw: 0, away: 0, actual: outcomeHomeWin, wantBrier: 0, wantLogLoss: 0},
+		{name: "certain and wrong", home: 1, draw: 0, away: 0, actual: outcomeAwayWin, wantBrier: 2, wantLogLoss: -math.Log(logLossEpsilon)},
+		{name: "uniform", home: 1.0 / 3, draw: 1.0 / 3, away: 1.0 / 3, actual: outcomeDraw, wantBrier: 2.0 / 3, wantLogLoss: math.Log(3)},
+		{name: "favourite wins", home: 0.5, draw: 0.3, away: 0.2, actual: outcomeHomeWin, wantBrier: 0.25 + 0.09 + 0.04, wantLogLoss: -math.Log(0.5)},
+		{name: "draw", home: 0.5, draw: 0.3, away: 0.2, actual: outcomeDraw, wantBrier: 0.25 + 0.49 + 0.04, wantLogLoss: -math.Log(0.3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			brier, logLoss := probabilityScores(tt.home, tt.draw, tt.away, tt.actual)
+			if math.Abs(brier-tt.wantBrier) > 1e-9 {
+				t.Errorf("brier = %v, want %v", brier, tt.wantBrier)
+			}
+			if math.Abs(logLoss-tt.wantLogLoss) > 1e-9 {
+				t.Errorf("log loss = %v, want %v", logLoss, tt.wantLogLoss)
+			}
+		})
+	}
+}
+
+func TestSettlePrediction(t *testing.T) {
+	settledAt := time.Date(2025, 5, 1, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name                   string
+		predictedHome          int
+		predictedAway          int
+		actualHome, actualAway int
+		wantOutcome, wantExact bool
+	}{
+		{name: "exact score", predictedHome: 2, predictedAway: 1, actualHome: 2, actualAway: 1, wantOutcome: true, wantExact: true},
+		{name: "right outcome", predictedHome: 1, predictedAway: 0, actualHome: 3, actualAway: 1, wantOutcome: true},
+		{name: "right draw", predictedHome: 0, predictedAway: 0, actualHome: 2, actualAway: 2, wantOutcome: true},
+		{name: "wrong outcome", predictedHome: 1, predictedAway: 2, actualHome: 1, actualAway: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &models.PredictionHistory{
+				PredictedHomeScore: tt.predictedHome,
+				PredictedAwayScore: tt.predictedAway,
+				HomeWinProbability: 0.5,
+				DrawProbability:    0.3,
+				AwayWinProbability: 0.2,
+			}
+			settlePrediction(p, tt.actualHome, tt.actualAway, settledAt)
+
+			if *p.ActualHomeScore != tt.actualHome || *p.ActualAwayScore != tt.actualAway {
+				t.Errorf("actual score = %d-%d, want %d-%d", *p.ActualHomeScore, *p.ActualAwayScore, tt.actualHome, tt.actualAway)
+			}
+			if *p.OutcomeCorrect != tt.wantOutcome || *p.ExactScore != tt.wantExact {
+				t.Errorf("outcome correct, exact = %v, %v; want %v, %v", *p.OutcomeCorrect, *p.ExactScore, tt.wantOutcome, tt.wantExact)
+			}
+			brier, logLoss := probabilityScores(0.5, 0.3, 0.2, outcomeOf(tt.actualHome, tt.actualAway))
+			if *p.BrierScore != brier || *p.LogLoss != logLoss {
+				t.Errorf("brier, log loss = %v, %v; want %v, %v", *p.BrierScore, *p.LogLoss, brier, logLoss)
+			}
+			if p.Status() != models.PredictionStatusSettled || !p.SettledAt.Equal(settledAt) {
+				t.Errorf("status = %s at %v, want settled at %v", p.Status(), p.SettledAt, settledAt)
+			}
+		})
+	}
+}
+
+func TestPickPoints(t *testing.T) {
+	tests := []struct {
+		name                         string
+		predictedHome, predictedAway int
+		actualHome, actualAway       int
+		want                         int
+	}{
+		{name: "exact score", predictedHome: 2, predictedAway: 2, actualHome: 2, actualAway: 2, want: models.PickPointsExactScore},
+		{name: "right winner", predictedHome: 0, predictedAway: 1, actualHome: 1, actualAway: 4, want: models.PickPointsCorrectOutcome},
+		{name: "right draw", predictedHome: 1, predictedAway: 1, actualHome: 0, actualAway: 0, want: models.PickPointsCorrectOutcome},
+		{name: "wrong", predictedHome: 3, predictedAway: 0, actualHome: 0, actualAway: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pickPoints(tt.predictedHome, tt.predictedAway, tt.actualHome, tt.actualAway); got != tt.want {
+				t.Errorf("pickPoints() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettlePending(t *testing.T) {
+	finished := models.MatchResponse{ID: 1, Status: "FINISHED", Score: models.MatchScoreResponse{
+		Duration: "REGULAR",
+		FullTime: models.ScoreResponse{Home: intPtr(1), Away: intPtr(0)},
+	}}
+	cancelled := models.MatchResponse{ID: 2, Status: "CANCELLED"}
+	postponed := models.MatchResponse{ID: 3, Status: "POSTPONED"}
+	fd := &stubProvider{matches: []models.MatchResponse{finished, cancelled, postponed}}
+
+	predictions := &fakePredictionRepo{
+		unsettled: []models.PredictionHistory{
+			{ID: 10, MatchID: intPtr(1), PredictedHomeScore: 1, PredictedAwayScore: 0, HomeWinProbability: 1},
+			{ID: 11, MatchID: intPtr(2)},
+			{ID: 12, MatchID: intPtr(3)},
+			{ID: 13, MatchID: intPtr(4)}, // Unknown to the provider
+		},
+		settled: make(map[uint]models.PredictionHistory),
+		voided:  make(map[uint]time.Time),
+		checked: make(map[uint]time.Time),
+	}
+	picks := &fakePickRepo{
+		unsettled: []models.UserPick{
+			{ID: 20, MatchID: 1, PredictedHomeScore: 2, PredictedAwayScore: 2},
+			{ID: 21, MatchID: 2},
+			{ID: 22, MatchID: 3},
+		},
+		settled: make(map[uint]models.UserPick),
+		voided:  make(map[uint]time.Time),
+		checked: make(map[uint]time.Time),
+	}
+
+	s := NewSettlementService(predictions, picks, NewFootballService(fd, nil), NewRealtimeService())
+	settled, err := s.SettlePending(context.Background())
+	if err != nil {
+		t.Fatalf("SettlePending() error = %v", err)
+	}
+	if settled != 2 {
+		t.Errorf("settled = %d, want 2", settled)
+	}
+
+	if p, ok := predictions.settled[10]; !ok || !*p.ExactScore || *p.BrierScore != 0 {
+		t.Errorf("prediction 10 = %+v, want settled as an exact score with a Brier score of 0", p)
+	}
+	if p, ok := picks.settled[20]; !ok || *p.Points != 0 {
+		t.Errorf("pick 20 = %+v, want settled with 0 points", p)
+	}
+	if _, ok := predictions.voided[11]; !ok || len(predictions.voided) != 1 {
+		t.Errorf("voided predictions = %v, want just 11", predictions.voided)
+	}
+	if _, ok := picks.voided[21]; !ok || len(picks.voided) != 1 {
+		t.Errorf("voided picks = %v, want just 21", picks.voided)
+	}
+
+	// Postponed and unknown matches rotate to the back of the queue
+	for _, id := range []uint{12, 13} {
+		if _, ok := predictions.checked[id]; !ok {
+			t.Errorf("prediction %d not marked as checked", id)
+		}
+	}
+	if _, ok := picks.checked[22]; !ok || len(picks.checked) != 1 {
+		t.Errorf("checked picks = %v, want just 22", picks.checked)
+	}
+	if len(predictions.checked) != 2 {
+		t.Errorf("checked predictions = %v, want 12 and 13", predictions.checked)
+	}
+}
@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"libero-backend/internal/models"
+	"libero-backend/internal/repository"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Tiebreakers separating teams level on points, as the history table names them
+const (
+	rankByPoints           = "POINTS" // What every table is ranked by before its tiebreakers
+	tiebreakGoalDifference = "GOAL_DIFFERENCE"
+	tiebreakGoalsFor       = "GOALS_FOR"
+	tiebreakHeadToHead     = "HEAD_TO_HEAD" // Points, then goal difference in the matches between the level teams
+	tiebreakAwayGoals      = "AWAY_GOALS"
+	tiebreakWins           = "WINS"
+	tiebreakAwayWins       = "AWAY_WINS"
+)
+
+// tiebreakRules are the tiebreakers of each competition after points, in order. Teams still level
+// after them are ordered by name, where the competition would use a play-off or a draw.
+var tiebreakRules = map[string][]string{
+	"PL":  {tiebreakGoalDifference, tiebreakGoalsFor, tiebreakHeadToHead},
+	"BL1": {tiebreakGoalDifference, tiebreakGoalsFor, tiebreakHeadToHead, tiebreakAwayGoals},
+	"FL1": {tiebreakGoalDifference, tiebreakHeadToHead, tiebreakGoalsFor, tiebreakAwayGoals},
+	"PD":  {tiebreakHeadToHead, tiebreakGoalDifference, tiebreakGoalsFor},
+	"SA":  {tiebreakHeadToHead, tiebreakGoalDifference, tiebreakGoalsFor},
+	"CL":  {tiebreakGoalDifference, tiebreakGoalsFor, tiebreakAwayGoals, tiebreakWins, tiebreakAwayWins},
+	"EL":  {tiebreakGoalDifference, tiebreakGoalsFor, tiebreakAwayGoals, tiebreakWins, tiebreakAwayWins},
+}
+
+// defaultTiebreakers apply to competitions without rules of their own
+var defaultTiebreakers = []string{tiebreakGoalDifference, tiebreakGoalsFor}
+
+// leaguePhaseStages are the stages played as one league table. Knockout rounds and group stages
+// split into several groups aren't part of it.
+var leaguePhaseStages = map[string]bool{"": true, "REGULAR_SEASON": true, "LEAGUE_STAGE": true}
+
+// StandingsHistoryService defines the interface for computing past league tables
+type StandingsHistoryService interface {
+	GetStandingsHistory(query models.StandingsHistoryQuery) (*models.StandingsHistoryDTO, error)
+	BackfillSeason(ctx context.Context, competitionCode string) (int, error)
+}
+
+// standingsHistoryService implements the StandingsHistoryService interface on top of stored matches
+type standingsHistoryService struct {
+	matchRepo       repository.MatchRepository
+	footballService *FootballService
+}
+
+// NewStandingsHistoryService creates a new standings history service instance
+func NewStandingsHistoryService(matchRepo repository.MatchRepository, footballService *FootballService) StandingsHistoryService {
+	return &standingsHistoryService{
+		matchRepo:       matchRepo,
+		footballService: footballService,
+	}
+}
+
+// BackfillSeason stores every match of a competition's current season, so its tables count the
+// results of matches no fixtures request has come across. It returns the number of matches stored.
+func (s *standingsHistoryService) BackfillSeason(ctx context.Context, competitionCode string) (int, error) {
+	matches, err := s.footballService.GetCompetitionMatches(ctx, competitionCode)
+	if err != nil {
+		return 0, err
+	}
+	records := make([]models.Match, 0, len(matches))
+	for _, m := range matches {
+		if m.ID == 0 {
+			continue
+		}
+		record := matchRecordFromResponse(m)
+		if record.CompetitionCode == "" {
+			record.CompetitionCode = competitionCode
+		}
+		records = append(records, record)
+	}
+	if err := s.matchRepo.UpsertMany(records); err != nil {
+		return 0, fmt.Errorf("failed to store %s season matches: %w", competitionCode, err)
+	}
+	return len(records), nil
+}
+
+// GetStandingsHistory computes a competition's league table from the stored results of a season, as
+// of a date or matchday, along with each team's position after every matchday up to then. Only
+// stored results are counted, so the table is independent of provider snapshots; the current season
+// is kept complete by BackfillSeason, and ResultsCounted tells how many there were. Asking for a date outside the season returns ErrInvalidInput.
+func (s *standingsHistoryService) GetStandingsHistory(query models.StandingsHistoryQuery) (*models.StandingsHistoryDTO, error) {
+	now := time.Now().UTC()
+	season := query.Season
+	if season == 0 {
+		season = seasonStartYear(now)
+		if query.Date != nil {
+			season = seasonStartYear(*query.Date)
+		}
+	}
+	from, to := seasonWindow(season)
+	if query.Date != nil && (query.Date.Before(from) || !query.Date.Before(to)) {
+		return nil, ErrInvalidInput
+	}
+
+	// Results kicking off on the day asked for count, whatever their time
+	until := to
+	if query.Date != nil {
+		day := time.Date(query.Date.Year(), query.Date.Month(), query.Date.Day(), 0, 0, 0, 0, time.UTC)
+		until = day.AddDate(0, 0, 1)
+	}
+	matches, err := s.matchRepo.FindAll(models.MatchFilter{
+		Competition: query.Competition,
+		Status:      []string{"FINISHED", "AWARDED"},
+		From:        &from,
+		To:          &until,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Only league-phase results count, up to the matchday asked for
+	counted := make([]models.Match, 0, len(matches))
+	for _, m := range matches {
+		if !leaguePhaseStages[m.Stage] || m.GroupName != "" || m.HomeScore == nil || m.AwayScore == nil {
+			continue
+		}
+		if query.Matchday > 0 && (m.Matchday == nil || *m.Matchday > query.Matchday) {
+			continue
+		}
+		counted = append(counted, m)
+	}
+
+	tiebreakers, ok := tiebreakRules[query.Competition]
+	if !ok {
+		tiebreakers = defaultTiebreakers
+	}
+	result := &models.StandingsHistoryDTO{
+		CompetitionCode: query.Competition,
+		Season:          season,
+		Matchday:        query.Matchday,
+		Tiebreakers:     tiebreakers,
+		ResultsCounted:  len(counted),
+		Standings:       make([]models.StandingsTableDTO, 0),
+		Positions:       make([]models.PositionSeriesDTO, 0),
+	}
+	if query.Date != nil {
+		result.AsOf = query.Date.Format("2006-01-02")
+	}
+	if len(counted) == 0 {
+		return result, nil
+	}
+
+	last := counted[len(counted)-1]
+	result.CompetitionName = last.CompetitionName
+	if result.AsOf == "" {
+		result.AsOf = last.KickoffAt.UTC().Format("2006-01-02")
+	}
+	if result.Matchday == 0 {
+		for _, m := range counted {
+			if m.Matchday != nil && *m.Matchday > result.Matchday {
+				result.Matchday = *m.Matchday
+			}
+		}
+	}
+
+	rows := historyTable(counted, tiebreakers, func(models.Match) bool { return true })
+	positions := make(map[int][]models.MatchdayPositionDTO, len(rows))
+	for matchday := 1; matchday <= result.Matchday; matchday++ {
+		table := historyTable(counted, tiebreakers, func(m models.Match) bool {
+			return m.Matchday != nil && *m.Matchday <= matchday
+		})
+		for i, row := range table {
+			positions[row.teamID] = append(positions[row.teamID], models.MatchdayPositionDTO{Matchday: matchday, Position: i + 1})
+		}
+	}
+
+	for i, row := range rows {
+		result.Standings = append(result.Standings, models.StandingsTableDTO{
+			Position:       i + 1,
+			TeamID:         row.teamID,
+			TeamName:       row.teamName,
+			TeamCrest:      row.teamCrest,
+			PlayedGames:    row.played,
+			Won:            row.won,
+			Draw:           row.drawn,
+			Lost:           row.lost,
+			GoalsFor:       row.goalsFor,
+			GoalsAgainst:   row.goalsAgainst,
+			GoalDifference: row.goalsFor - row.goalsAgainst,
+			Points:         row.points(),
+			Form:           row.form,
+		})
+		series := positions[row.teamID]
+		if series == nil {
+			series = make([]models.MatchdayPositionDTO, 0)
+		}
+		result.Positions = append(result.Positions, models.PositionSeriesDTO{TeamID: row.teamID, TeamName: row.teamName, Positions: series})
+	}
+	return result, nil
+}
+
+// historyRow is a team's record over the results counted for a table
+type historyRow struct {
+	teamID       int
+	teamName     string
+	teamCrest    string
+	played       int
+	won          int
+	drawn        int
+	lost         int
+	goalsFor     int
+	goalsAgainst int
+	awayGoals    int
+	awayWins     int
+	form         string // Last results, most recent last
+}
+
+// points are three for a win and one for a draw
+func (r *historyRow) points() int {
+	return 3*r.won + r.drawn
+}
+
+// record adds a result to the row
+func (r *historyRow) record(goalsFor, goalsAgainst int, away bool) {
+	r.played++
+	r.goalsFor += goalsFor
+	r.goalsAgainst += goalsAgainst
+	result := "D"
+	switch {
+	case goalsFor > goalsAgainst:
+		r.won++
+		result = "W"
+		if away {
+			r.awayWins++
+		}
+	case goalsFor < goalsAgainst:
+		r.lost++
+		result = "L"
+	default:
+		r.drawn++
+	}
+	if away {
+		r.awayGoals += goalsFor
+	}
+	r.form += result
+	if len(r.form) > formLength {
+		r.form = r.form[len(r.form)-formLength:]
+	}
+}
+
+// historyTable ranks every team of the matches, which are in kickoff order, over the results
+// include picks. Teams yet to play are listed too.
+func historyTable(matches []models.Match, tiebreakers []string, include func(models.Match) bool) []*historyRow {
+	byTeam := make(map[int]*historyRow)
+	rows := make([]*historyRow, 0)
+	team := func(id int, name, crest string) *historyRow {
+		row, ok := byTeam[id]
+		if !ok {
+			row = &historyRow{teamID: id}
+			byTeam[id] = row
+			rows = append(rows, row)
+		}
+		// Later matches carry the current name and crest
+		row.teamName, row.teamCrest = name, crest
+		return row
+	}
+	for _, m := range matches {
+		home := team(m.HomeTeamID, m.HomeTeamName, m.HomeTeamCrest)
+		away := team(m.AwayTeamID, m.AwayTeamName, m.AwayTeamCrest)
+		if !include(m) {
+			continue
+		}
+		home.record(*m.HomeScore, *m.AwayScore, false)
+		away.record(*m.AwayScore, *m.HomeScore, true)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].teamName < rows[j].teamName
+	})
+	rankLevel(rows, matches, include, append([]string{rankByPoints}, tiebreakers...))
+	return rows
+}
+
+// rankLevel orders rows that are level so far by the first criterion, then orders the teams still
+// level among themselves by the criteria after it
+func rankLevel(rows []*historyRow, matches []models.Match, include func(models.Match) bool, criteria []string) {
+	if len(rows) < 2 || len(criteria) == 0 {
+		return
+	}
+
+	keys := tiebreakKeys(rows, matches, include, criteria[0])
+	sort.SliceStable(rows, func(i, j int) bool {
+		return slices.Compare(keys[rows[i].teamID], keys[rows[j].teamID]) > 0
+	})
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && slices.Equal(keys[rows[start].teamID], keys[rows[end].teamID]) {
+			end++
+		}
+		rankLevel(rows[start:end], matches, include, criteria[1:])
+		start = end
+	}
+}
+
+// tiebreakKeys returns what each row ranks by for a criterion, higher first, by team ID
+func tiebreakKeys(rows []*historyRow, matches []models.Match, include func(models.Match) bool, criterion string) map[int][]int {
+	keys := make(map[int][]int, len(rows))
+	if criterion == tiebreakHeadToHead {
+		// A table of just the matches between the level teams
+		level := make(map[int]bool, len(rows))
+		for _, row := range rows {
+			level[row.teamID] = true
+		}
+		headToHead := historyTable(matches, nil, func(m models.Match) bool {
+			return include(m) && level[m.HomeTeamID] && level[m.AwayTeamID]
+		})
+		for _, row := range headToHead {
+			if level[row.teamID] {
+				keys[row.teamID] = []int{row.points(), row.goalsFor - row.goalsAgainst}
+			}
+		}
+		return keys
+	}
+
+	for _, row := range rows {
+		switch criterion {
+		case tiebreakGoalDifference:
+			keys[row.teamID] = []int{row.goalsFor - row.goalsAgainst}
+		case tiebreakGoalsFor:
+			keys[row.teamID] = []int{row.goalsFor}
+		case tiebreakAwayGoals:
+			keys[row.teamID] = []int{row.awayGoals}
+		case tiebreakWins:
+			keys[row.teamID] = []int{row.won}
+		case tiebreakAwayWins:
+			keys[row.teamID] = []int{row.awayWins}
+		default:
+			keys[row.teamID] = []int{row.points()}
+		}
+	}
+	return keys
+}
+
+// seasonStartYear is the year the season under way at t started in. Seasons are taken to run
+// from July to June.
+func seasonStartYear(t time.Time) int {
+	if t.Month() >= time.July {
+		return t.Year()
+	}
+	return t.Year() - 1
+}
+
+// seasonWindow is when the season starting in the given year is played: from July of that year
+// until July of the next
+func seasonWindow(season int) (from, to time.Time) {
+	from = time.Date(season, time.July, 1, 0, 0, 0, 0, time.UTC)
+	return from, from.AddDate(1, 0, 0)
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"libero-backend/internal/models"
+	"slices"
+	"testing"
+	"time"
+)
+
+// Teams of the history tests, by provider ID
+var historyTeams = map[int]string{1: "Alpha", 2: "Bravo", 3: "Charlie", 4: "Delta"}
+
+// historyResult is a stored league result of the 2024 season, kicking off a week after the previous matchday
+func historyResult(competition string, matchday, homeID, awayID, homeScore, awayScore int) models.Match {
+	return models.Match{
+		ProviderID:      matchday*100 + homeID*10 + awayID,
+		CompetitionCode: competition,
+		Matchday:        intPtr(matchday),
+		Stage:           "REGULAR_SEASON",
+		KickoffAt:       time.Date(2024, time.August, 3+7*matchday, 15, 0, 0, 0, time.UTC),
+		Status:          "FINISHED",
+		HomeTeamID:      homeID,
+		HomeTeamName:    historyTeams[homeID],
+		AwayTeamID:      awayID,
+		AwayTeamName:    historyTeams[awayID],
+		HomeScore:       intPtr(homeScore),
+		AwayScore:       intPtr(awayScore),
+	}
+}
+
+// headToHeadSeason leaves Alpha and Bravo level on points, Alpha with the better goal difference
+// and Bravo with the win between them
+func headToHeadSeason(competition string) []models.Match {
+	return []models.Match{
+		historyResult(competition, 1, 2, 1, 1, 0),
+		historyResult(competition, 1, 3, 4, 1, 0),
+		historyResult(competition, 2, 1, 4, 4, 0),
+		historyResult(competition, 2, 3, 2, 0, 0),
+		historyResult(competition, 3, 1, 3, 0, 0),
+		historyResult(competition, 3, 4, 2, 1, 0),
+	}
+}
+
+// miniLeagueSeason leaves Alpha, Bravo and Charlie level on points, each having beaten one of the
+// others. Their mini-league is level on points and goal difference, though not on goals.
+func miniLeagueSeason(competition string) []models.Match {
+	return []models.Match{
+		historyResult(competition, 1, 1, 2, 2, 1),
+		historyResult(competition, 1, 3, 4, 3, 0),
+		historyResult(competition, 2, 2, 3, 1, 0),
+		historyResult(competition, 2, 1, 4, 1, 0),
+		historyResult(competition, 3, 3, 1, 1, 0),
+		historyResult(competition, 3, 2, 4, 2, 1),
+	}
+}
+
+func TestGetStandingsHistory(t *testing.T) {
+	mdTwo := time.Date(2024, time.August, 17, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		matches      []models.Match
+		query        models.StandingsHistoryQuery
+		wantOrder    []string
+		wantMatchday int
+		wantResults  int
+	}{
+		{
+			name:         "PL ranks level teams by goal difference",
+			matches:      headToHeadSeason("PL"),
+			query:        models.StandingsHistoryQuery{Competition: "PL", Season: 2024},
+			wantOrder:    []string{"Charlie", "Alpha", "Bravo", "Delta"},
+			wantMatchday: 3,
+			wantResults:  6,
+		},
+		{
+			name:         "PD ranks level teams by head-to-head",
+			matches:      headToHeadSeason("PD"),
+			query:        models.StandingsHistoryQuery{Competition: "PD", Season: 2024},
+			wantOrder:    []string{"Charlie", "Bravo", "Alpha", "Delta"},
+			wantMatchday: 3,
+			wantResults:  6,
+		},
+		{
+			name:         "PL three-way tie by goal difference then goals",
+			matches:      miniLeagueSeason("PL"),
+			query:        models.StandingsHistoryQuery{Competition: "PL", Season: 2024},
+			wantOrder:    []string{"Charlie", "Bravo", "Alpha", "Delta"},
+			wantMatchday: 3,
+			wantResults:  6,
+		},
+		{
+			// Head-to-head goals don't count, so the overall goal difference decides
+			name:         "PD mini-league level on points and goal difference",
+			matches:      miniLeagueSeason("PD"),
+			query:        models.StandingsHistoryQuery{Competition: "PD", Season: 2024},
+			wantOrder:    []string{"Charlie", "Bravo", "Alpha", "Delta"},
+			wantMatchday: 3,
+			wantResults:  6,
+		},
+		{
+			name:         "as of a matchday",
+			matches:      headToHeadSeason("PD"),
+			query:        models.StandingsHistoryQuery{Competition: "PD", Season: 2024, Matchday: 2},
+			wantOrder:    []string{"Bravo", "Charlie", "Alpha", "Delta"},
+			wantMatchday: 2,
+			wantResults:  4,
+		},
+		{
+			name:         "as of a date",
+			matches:      headToHeadSeason("PD"),
+			query:        models.StandingsHistoryQuery{Competition: "PD", Date: &mdTwo},
+			wantOrder:    []string{"Bravo", "Charlie", "Alpha", "Delta"},
+			wantMatchday: 2,
+			wantResults:  4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStandingsHistoryService(&fakeMatchRepo{matches: tt.matches}, nil)
+			history, err := s.GetStandingsHistory(tt.query)
+			if err != nil {
+				t.Fatalf("GetStandingsHistory() error = %v", err)
+			}
+
+			order := make([]string, 0, len(history.Standings))
+			for i, row := range history.Standings {
+				order = append(order, row.TeamName)
+				if row.Position != i+1 {
+					t.Errorf("%s position = %d, want %d", row.TeamName, row.Position, i+1)
+				}
+			}
+			if !slices.Equal(order, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", order, tt.wantOrder)
+			}
+			if history.Matchday != tt.wantMatchday || history.ResultsCounted != tt.wantResults {
+				t.Errorf("matchday, results counted = %d, %d; want %d, %d", history.Matchday, history.ResultsCounted, tt.wantMatchday, tt.wantResults)
+			}
+		})
+	}
+}
+
+func TestGetStandingsHistoryPositions(t *testing.T) {
+	s := NewStandingsHistoryService(&fakeMatchRepo{matches: headToHeadSeason("PD")}, nil)
+	history, err := s.GetStandingsHistory(models.StandingsHistoryQuery{Competition: "PD", Season: 2024})
+	if err != nil {
+		t.Fatalf("GetStandingsHistory() error = %v", err)
+	}
+
+	want := map[string][]int{
+		"Alpha":   {3, 3, 3},
+		"Bravo":   {1, 1, 2},
+		"Charlie": {2, 2, 1},
+		"Delta":   {4, 4, 4},
+	}
+	if len(history.Positions) != len(want) {
+		t.Fatalf("got %d position series, want %d", len(history.Positions), len(want))
+	}
+	for i, series := range history.Positions {
+		if series.TeamID != history.Standings[i].TeamID {
+			t.Errorf("series %d is %s's, want it in table order", i, series.TeamName)
+		}
+		positions := make([]int, 0, len(series.Positions))
+		for matchday, p := range series.Positions {
+			if p.Matchday != matchday+1 {
+				t.Errorf("%s series has matchday %d at %d", series.TeamName, p.Matchday, matchday)
+			}
+			positions = append(positions, p.Position)
+		}
+		if !slices.Equal(positions, want[series.TeamName]) {
+			t.Errorf("%s positions = %v, want %v", series.TeamName, positions, want[series.TeamName])
+		}
+	}
+}
+
+func TestGetStandingsHistoryOutsideSeason(t *testing.T) {
+	date := time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC)
+	s := NewStandingsHistoryService(&fakeMatchRepo{}, nil)
+	if _, err := s.GetStandingsHistory(models.StandingsHistoryQuery{Competition: "PL", Season: 2024, Date: &date}); err != ErrInvalidInput {
+		t.Errorf("GetStandingsHistory() error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestBackfillSeason(t *testing.T) {
+	played := func(id, homeID, awayID, homeScore, awayScore int) models.MatchResponse {
+		return models.MatchResponse{
+			ID:       id,
+			UtcDate:  time.Date(2024, time.August, 10+id, 15, 0, 0, 0, time.UTC),
+			Status:   "FINISHED",
+			Matchday: id,
+			Stage:    "REGULAR_SEASON",
+			HomeTeam: models.TeamResponse{ID: homeID, Name: historyTeams[homeID]},
+			AwayTeam: models.TeamResponse{ID: awayID, Name: historyTeams[awayID]},
+			Score:    models.MatchScoreResponse{Duration: "REGULAR", FullTime: models.ScoreResponse{Home: intPtr(homeScore), Away: intPtr(awayScore)}},
+		}
+	}
+	fd := &stubProvider{matches: []models.MatchResponse{played(1, 1, 2, 0, 2), played(2, 2, 1, 1, 1)}}
+	repo := &fakeMatchRepo{}
+	s := NewStandingsHistoryService(repo, NewFootballService(fd, repo))
+
+	stored, err := s.BackfillSeason(context.Background(), "PL")
+	if err != nil {
+		t.Fatalf("BackfillSeason() error = %v", err)
+	}
+	if stored != 2 || len(repo.matches) != 2 {
+		t.Fatalf("stored %d matches, repository has %d; want 2", stored, len(repo.matches))
+	}
+
+	history, err := s.GetStandingsHistory(models.StandingsHistoryQuery{Competition: "PL", Season: 2024})
+	if err != nil {
+		t.Fatalf("GetStandingsHistory() error = %v", err)
+	}
+	if history.ResultsCounted != 2 || history.Standings[0].TeamName != "Bravo" || history.Standings[0].Points != 4 {
+		t.Errorf("standings = %+v, want Bravo top on 4 points from 2 results", history.Standings)
+	}
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentETag returns a strong ETag derived from a response body, so it only changes when the
+// content does
+func ContentETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// RespondWithConditionalJSON writes an already encoded JSON body with its ETag and Last-Modified,
+// or a bodiless 304 when the request's If-None-Match or If-Modified-Since shows the client has it
+func RespondWithConditionalJSON(w http.ResponseWriter, r *http.Request, body []byte, etag string, lastModified time.Time) {
+	w.Header().Set("ETag", etag)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	// Let clients keep the response but have them revalidate it on every use
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// RespondWithStaleJSON writes a body served from stale data, marked by an Age header with its age
+// in seconds, an X-Data-Stale: true header and "stale": true and "age" fields in the body. JSON
+// objects get the fields next to their own; any other JSON is wrapped as {"stale", "age", "data"}.
+// The ETag is weakened since the body changes with its age while the data doesn't.
+func RespondWithStaleJSON(w http.ResponseWriter, r *http.Request, body []byte, etag string, lastModified time.Time, age time.Duration) {
+	seconds := int64(age.Seconds())
+	w.Header().Set("Age", strconv.FormatInt(seconds, 10))
+	w.Header().Set("X-Data-Stale", "true")
+	RespondWithConditionalJSON(w, r, markStale(body, seconds), "W/"+etag, lastModified)
+}
+
+// markStale adds the stale and age fields to a JSON body
+func markStale(body []byte, ageSeconds int64) []byte {
+	fields := `{"stale":true,"age":` + strconv.FormatInt(ageSeconds, 10)
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) < 2 || trimmed[0] != '{' {
+		return []byte(fields + `,"data":` + string(trimmed) + "}")
+	}
+	rest := bytes.TrimSpace(trimmed[1:])
+	if rest[0] != '}' {
+		fields += ","
+	}
+	return append([]byte(fields), rest...)
+}
+
+// notModified evaluates a GET's preconditions. If-None-Match takes precedence over
+// If-Modified-Since, as in RFC 9110.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		// Weak comparison, so clients holding the fresh or the stale body of the same data match
+		opaque := strings.TrimPrefix(etag, "W/")
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == opaque {
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := r.Header.Get("If-Modified-Since"); header != "" && !lastModified.IsZero() {
+		if since, err := http.ParseTime(header); err == nil {
+			// Last-Modified only has second precision
+			return !lastModified.Truncate(time.Second).After(since)
+		}
+	}
+	return false
+}
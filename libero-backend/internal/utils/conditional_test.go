@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRespondWithStaleJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "object", body: `{"standings":[]}`, want: `{"stale":true,"age":90,"standings":[]}`},
+		{name: "empty object", body: `{}`, want: `{"stale":true,"age":90}`},
+		{name: "array", body: `[{"id":1}]`, want: `{"stale":true,"age":90,"data":[{"id":1}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			RespondWithStaleJSON(rec, httptest.NewRequest(http.MethodGet, "/", nil), []byte(tt.body), `"abc"`, time.Time{}, 90*time.Second)
+			if got := rec.Body.String(); got != tt.want {
+				t.Errorf("body = %s, want %s", got, tt.want)
+			}
+			if rec.Header().Get("Age") != "90" || rec.Header().Get("X-Data-Stale") != "true" || rec.Header().Get("ETag") != `W/"abc"` {
+				t.Errorf("headers = %v, want Age 90, X-Data-Stale and a weak ETag", rec.Header())
+			}
+		})
+	}
+
+	// Fresh responses are written as they are
+	rec := httptest.NewRecorder()
+	RespondWithConditionalJSON(rec, httptest.NewRequest(http.MethodGet, "/", nil), []byte(`[1]`), `"abc"`, time.Time{})
+	if rec.Body.String() != `[1]` || rec.Header().Get("X-Data-Stale") != "" {
+		t.Errorf("fresh response = %s with %v, want the body unchanged", rec.Body.String(), rec.Header())
+	}
+}